@@ -13,6 +13,8 @@ import (
 
 type Changelog = apitypes.Changelog
 type FullChangelog = apitypes.FullChangelog
+type SourceHealth = apitypes.SourceHealth
+type Preview = apitypes.Preview
 
 func (c *Client) GetChangelog(ctx context.Context, changelogID string) (Changelog, error) {
 	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("/changelogs/%s", changelogID), nil)
@@ -64,6 +66,52 @@ func (c *Client) GetFullChangelog(ctx context.Context, args GetFullChangelogPara
 	return cl, err
 }
 
+// Loads all release notes of the changelog and returns the health of each of it's sources.
+func (c *Client) GetChangelogHealth(ctx context.Context, changelogID string) ([]SourceHealth, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("/changelogs/%s/health", changelogID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.rawRequestWithContext(req)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting health of changelog %s: %w", changelogID, err)
+	}
+	defer resp.Body.Close()
+
+	var health []SourceHealth
+	err = resp.DecodeJSON(&health)
+	return health, err
+}
+
+// Creates a signed link to preview the release note of the changelog, including drafts and scheduled release notes.
+func (c *Client) CreatePreview(ctx context.Context, changelogID string, releaseNoteID string, args apitypes.CreatePreviewBody) (Preview, error) {
+	body, err := json.Marshal(args)
+	if err != nil {
+		return Preview{}, err
+	}
+
+	req, err := c.NewRequest(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("/changelogs/%s/releases/%s/preview", changelogID, url.PathEscape(releaseNoteID)),
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return Preview{}, err
+	}
+
+	resp, err := c.rawRequestWithContext(req)
+	if err != nil {
+		return Preview{}, fmt.Errorf("error while creating preview of release note %s: %w", releaseNoteID, err)
+	}
+	defer resp.Body.Close()
+
+	var p Preview
+	err = resp.DecodeJSON(&p)
+	return p, err
+}
+
 func (c *Client) ListChangelogs(ctx context.Context) ([]Changelog, error) {
 	req, err := c.NewRequest(ctx, http.MethodGet, "/changelogs", nil)
 	if err != nil {
@@ -183,3 +231,27 @@ func (c *Client) SetChangelogSource(ctx context.Context, changelogID string, sou
 	}
 	return nil
 }
+
+// Combines the release notes of the sources in the changelog, in the order of sourceIDs.
+func (c *Client) SetChangelogSources(ctx context.Context, changelogID string, sourceIDs []string) error {
+	body, err := json.Marshal(apitypes.SetChangelogSourcesBody{SourceIDs: sourceIDs})
+	if err != nil {
+		return err
+	}
+
+	req, err := c.NewRequest(
+		ctx,
+		http.MethodPut,
+		fmt.Sprintf("/changelogs/%s/sources", changelogID),
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.rawRequestWithContext(req)
+	if err != nil {
+		return fmt.Errorf("error while setting changelog %s sources: %w", changelogID, err)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/jonashiltl/openchangelog/apitypes"
+)
+
+type GHInstallation = apitypes.GHInstallation
+type GHRepository = apitypes.GHRepository
+type CreateGHInstallationSourceBody = apitypes.CreateGHInstallationSourceBody
+
+// Returns the url installing the GitHub App for the workspace.
+// redirect is optional, it's the url GitHub redirects to after the installation was set up.
+func (c *Client) GetGHInstallURL(ctx context.Context, redirect string) (string, error) {
+	p := "/github/install"
+	if redirect != "" {
+		p += "?" + url.Values{"redirect": {redirect}}.Encode()
+	}
+	req, err := c.NewRequest(ctx, http.MethodGet, p, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := c.rawRequestWithContext(req)
+	if err != nil {
+		return "", fmt.Errorf("error while getting github install url: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var u apitypes.GHInstallURL
+	err = resp.DecodeJSON(&u)
+	return u.URL, err
+}
+
+func (c *Client) ListGHInstallations(ctx context.Context) ([]GHInstallation, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, "/github/installations", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.rawRequestWithContext(req)
+	if err != nil {
+		return nil, fmt.Errorf("error while listing github installations: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var installations []GHInstallation
+	err = resp.DecodeJSON(&installations)
+	return installations, err
+}
+
+func (c *Client) ListGHInstallationRepos(ctx context.Context, installationID int64) ([]GHRepository, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("/github/installations/%d/repos", installationID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.rawRequestWithContext(req)
+	if err != nil {
+		return nil, fmt.Errorf("error while listing repositories of github installation %d: %w", installationID, err)
+	}
+	defer resp.Body.Close()
+
+	var repos []GHRepository
+	err = resp.DecodeJSON(&repos)
+	return repos, err
+}
+
+func (c *Client) CreateGHInstallationSource(ctx context.Context, installationID int64, args CreateGHInstallationSourceBody) (GHSource, error) {
+	body, err := json.Marshal(args)
+	if err != nil {
+		return GHSource{}, err
+	}
+
+	req, err := c.NewRequest(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("/github/installations/%d/sources", installationID),
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return GHSource{}, err
+	}
+
+	resp, err := c.rawRequestWithContext(req)
+	if err != nil {
+		return GHSource{}, fmt.Errorf("error while creating source of github installation %d: %w", installationID, err)
+	}
+	defer resp.Body.Close()
+
+	var gh GHSource
+	err = resp.DecodeJSON(&gh)
+	return gh, err
+}
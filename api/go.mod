@@ -9,3 +9,5 @@ require (
 )
 
 require golang.org/x/text v0.38.0 // indirect
+
+replace github.com/jonashiltl/openchangelog/apitypes => ../apitypes
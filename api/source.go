@@ -13,6 +13,18 @@ import (
 type Source = apitypes.Source
 type GHSource = apitypes.CreateGHSourceBody
 type CreateGHSourceBody = apitypes.CreateGHSourceBody
+type GLSource = apitypes.GLSource
+type CreateGLSourceBody = apitypes.CreateGLSourceBody
+type FJSource = apitypes.FJSource
+type CreateFJSourceBody = apitypes.CreateFJSourceBody
+type LocalSource = apitypes.LocalSource
+type CreateLocalSourceBody = apitypes.CreateLocalSourceBody
+type GitSource = apitypes.GitSource
+type CreateGitSourceBody = apitypes.CreateGitSourceBody
+type URLSource = apitypes.URLSource
+type CreateURLSourceBody = apitypes.CreateURLSourceBody
+type S3Source = apitypes.S3Source
+type CreateS3SourceBody = apitypes.CreateS3SourceBody
 
 func (c *Client) CreateGHSource(ctx context.Context, args CreateGHSourceBody) (GHSource, error) {
 	body, err := json.Marshal(args)
@@ -59,6 +71,276 @@ func (c *Client) DeleteGHSource(ctx context.Context, sourceID string) error {
 	return nil
 }
 
+func (c *Client) CreateGLSource(ctx context.Context, args CreateGLSourceBody) (GLSource, error) {
+	body, err := json.Marshal(args)
+	if err != nil {
+		return GLSource{}, err
+	}
+
+	req, err := c.NewRequest(
+		ctx,
+		http.MethodPost,
+		"/sources/gl",
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return GLSource{}, err
+	}
+
+	resp, err := c.rawRequestWithContext(req)
+	if err != nil {
+		return GLSource{}, fmt.Errorf("error while creating gitlab source: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var s GLSource
+	err = resp.DecodeJSON(&s)
+	return s, err
+}
+
+func (c *Client) DeleteGLSource(ctx context.Context, sourceID string) error {
+	req, err := c.NewRequest(
+		ctx,
+		http.MethodDelete,
+		fmt.Sprintf("/sources/gl/%s", sourceID),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.rawRequestWithContext(req)
+	if err != nil {
+		return fmt.Errorf("error while deleting gitlab source %s: %w", sourceID, err)
+	}
+	return nil
+}
+
+func (c *Client) CreateFJSource(ctx context.Context, args CreateFJSourceBody) (FJSource, error) {
+	body, err := json.Marshal(args)
+	if err != nil {
+		return FJSource{}, err
+	}
+
+	req, err := c.NewRequest(
+		ctx,
+		http.MethodPost,
+		"/sources/fj",
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return FJSource{}, err
+	}
+
+	resp, err := c.rawRequestWithContext(req)
+	if err != nil {
+		return FJSource{}, fmt.Errorf("error while creating forgejo source: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var s FJSource
+	err = resp.DecodeJSON(&s)
+	return s, err
+}
+
+func (c *Client) DeleteFJSource(ctx context.Context, sourceID string) error {
+	req, err := c.NewRequest(
+		ctx,
+		http.MethodDelete,
+		fmt.Sprintf("/sources/fj/%s", sourceID),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.rawRequestWithContext(req)
+	if err != nil {
+		return fmt.Errorf("error while deleting forgejo source %s: %w", sourceID, err)
+	}
+	return nil
+}
+
+func (c *Client) CreateLocalSource(ctx context.Context, args CreateLocalSourceBody) (LocalSource, error) {
+	body, err := json.Marshal(args)
+	if err != nil {
+		return LocalSource{}, err
+	}
+
+	req, err := c.NewRequest(
+		ctx,
+		http.MethodPost,
+		"/sources/local",
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return LocalSource{}, err
+	}
+
+	resp, err := c.rawRequestWithContext(req)
+	if err != nil {
+		return LocalSource{}, fmt.Errorf("error while creating local source: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var s LocalSource
+	err = resp.DecodeJSON(&s)
+	return s, err
+}
+
+func (c *Client) DeleteLocalSource(ctx context.Context, sourceID string) error {
+	req, err := c.NewRequest(
+		ctx,
+		http.MethodDelete,
+		fmt.Sprintf("/sources/local/%s", sourceID),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.rawRequestWithContext(req)
+	if err != nil {
+		return fmt.Errorf("error while deleting local source %s: %w", sourceID, err)
+	}
+	return nil
+}
+
+func (c *Client) CreateGitSource(ctx context.Context, args CreateGitSourceBody) (GitSource, error) {
+	body, err := json.Marshal(args)
+	if err != nil {
+		return GitSource{}, err
+	}
+
+	req, err := c.NewRequest(
+		ctx,
+		http.MethodPost,
+		"/sources/git",
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return GitSource{}, err
+	}
+
+	resp, err := c.rawRequestWithContext(req)
+	if err != nil {
+		return GitSource{}, fmt.Errorf("error while creating git source: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var s GitSource
+	err = resp.DecodeJSON(&s)
+	return s, err
+}
+
+func (c *Client) DeleteGitSource(ctx context.Context, sourceID string) error {
+	req, err := c.NewRequest(
+		ctx,
+		http.MethodDelete,
+		fmt.Sprintf("/sources/git/%s", sourceID),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.rawRequestWithContext(req)
+	if err != nil {
+		return fmt.Errorf("error while deleting git source %s: %w", sourceID, err)
+	}
+	return nil
+}
+
+func (c *Client) CreateURLSource(ctx context.Context, args CreateURLSourceBody) (URLSource, error) {
+	body, err := json.Marshal(args)
+	if err != nil {
+		return URLSource{}, err
+	}
+
+	req, err := c.NewRequest(
+		ctx,
+		http.MethodPost,
+		"/sources/url",
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return URLSource{}, err
+	}
+
+	resp, err := c.rawRequestWithContext(req)
+	if err != nil {
+		return URLSource{}, fmt.Errorf("error while creating url source: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var s URLSource
+	err = resp.DecodeJSON(&s)
+	return s, err
+}
+
+func (c *Client) DeleteURLSource(ctx context.Context, sourceID string) error {
+	req, err := c.NewRequest(
+		ctx,
+		http.MethodDelete,
+		fmt.Sprintf("/sources/url/%s", sourceID),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.rawRequestWithContext(req)
+	if err != nil {
+		return fmt.Errorf("error while deleting url source %s: %w", sourceID, err)
+	}
+	return nil
+}
+
+func (c *Client) CreateS3Source(ctx context.Context, args CreateS3SourceBody) (S3Source, error) {
+	body, err := json.Marshal(args)
+	if err != nil {
+		return S3Source{}, err
+	}
+
+	req, err := c.NewRequest(
+		ctx,
+		http.MethodPost,
+		"/sources/s3",
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return S3Source{}, err
+	}
+
+	resp, err := c.rawRequestWithContext(req)
+	if err != nil {
+		return S3Source{}, fmt.Errorf("error while creating s3 source: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var s S3Source
+	err = resp.DecodeJSON(&s)
+	return s, err
+}
+
+func (c *Client) DeleteS3Source(ctx context.Context, sourceID string) error {
+	req, err := c.NewRequest(
+		ctx,
+		http.MethodDelete,
+		fmt.Sprintf("/sources/s3/%s", sourceID),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.rawRequestWithContext(req)
+	if err != nil {
+		return fmt.Errorf("error while deleting s3 source %s: %w", sourceID, err)
+	}
+	return nil
+}
+
 func (c *Client) ListSources(ctx context.Context) ([]Source, error) {
 	req, err := c.NewRequest(ctx, http.MethodGet, "/sources", nil)
 	if err != nil {
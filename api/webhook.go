@@ -0,0 +1,93 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/jonashiltl/openchangelog/apitypes"
+)
+
+type Webhook = apitypes.Webhook
+type WebhookDelivery = apitypes.WebhookDelivery
+
+func (c *Client) CreateWebhook(ctx context.Context, changelogID string, args apitypes.CreateWebhookBody) (Webhook, error) {
+	body, err := json.Marshal(args)
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	req, err := c.NewRequest(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("/changelogs/%s/webhooks", changelogID),
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	resp, err := c.rawRequestWithContext(req)
+	if err != nil {
+		return Webhook{}, fmt.Errorf("error while creating webhook of changelog %s: %w", changelogID, err)
+	}
+	defer resp.Body.Close()
+
+	var wh Webhook
+	err = resp.DecodeJSON(&wh)
+	return wh, err
+}
+
+func (c *Client) ListWebhooks(ctx context.Context, changelogID string) ([]Webhook, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("/changelogs/%s/webhooks", changelogID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.rawRequestWithContext(req)
+	if err != nil {
+		return nil, fmt.Errorf("error while listing webhooks of changelog %s: %w", changelogID, err)
+	}
+	defer resp.Body.Close()
+
+	var whs []Webhook
+	err = resp.DecodeJSON(&whs)
+	return whs, err
+}
+
+func (c *Client) DeleteWebhook(ctx context.Context, changelogID string, webhookID string) error {
+	req, err := c.NewRequest(
+		ctx,
+		http.MethodDelete,
+		fmt.Sprintf("/changelogs/%s/webhooks/%s", changelogID, webhookID),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.rawRequestWithContext(req)
+	if err != nil {
+		return fmt.Errorf("error while deleting webhook %s: %w", webhookID, err)
+	}
+	return nil
+}
+
+func (c *Client) ListWebhookDeliveries(ctx context.Context, changelogID string, webhookID string) ([]WebhookDelivery, error) {
+	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("/changelogs/%s/webhooks/%s/deliveries", changelogID, webhookID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.rawRequestWithContext(req)
+	if err != nil {
+		return nil, fmt.Errorf("error while listing deliveries of webhook %s: %w", webhookID, err)
+	}
+	defer resp.Body.Close()
+
+	var deliveries []WebhookDelivery
+	err = resp.DecodeJSON(&deliveries)
+	return deliveries, err
+}
@@ -23,7 +23,9 @@ type Changelog struct {
 	Searchable    bool
 	Logo          Logo
 	Source        Source
-	CreatedAt     time.Time
+	// The ordered sources of a changelog combining multiple sources, Source is the first one.
+	Sources   []Source
+	CreatedAt time.Time
 }
 
 type ColorScheme string
@@ -46,7 +48,14 @@ type Article struct {
 	Description string    `json:"description"`
 	PublishedAt time.Time `json:"publishedAt"`
 	Tags        []string  `json:"tags"`
-	HTMLContent string    `json:"htmlContent"`
+	Version     string    `json:"version,omitempty"`
+	Slug        string    `json:"slug,omitempty"`
+	Authors     []string  `json:"authors,omitempty"`
+	// The url of the cover image.
+	Image       string `json:"image,omitempty"`
+	Draft       bool   `json:"draft,omitempty"`
+	Pinned      bool   `json:"pinned,omitempty"`
+	HTMLContent string `json:"htmlContent"`
 }
 
 func (cl Changelog) MarshalJSON() ([]byte, error) {
@@ -66,6 +75,7 @@ func (cl Changelog) MarshalJSON() ([]byte, error) {
 		Searchable    bool       `json:"searchable"`
 		Logo          *Logo      `json:"logo,omitempty"`
 		Source        Source     `json:"source,omitempty"`
+		Sources       []Source   `json:"sources,omitempty"`
 		CreatedAt     *time.Time `json:"createdAt,omitempty"`
 	}{
 		ID:            cl.ID,
@@ -82,6 +92,7 @@ func (cl Changelog) MarshalJSON() ([]byte, error) {
 		Analytics:     cl.Analytics,
 		Searchable:    cl.Searchable,
 		Source:        cl.Source,
+		Sources:       cl.Sources,
 	}
 
 	if cl.Logo.IsValid() {
@@ -211,6 +222,19 @@ func (c *Changelog) UnmarshalJSON(b []byte) error {
 		c.Source = DecodeSource(*sourceRaw)
 	}
 
+	if sourcesRaw, ok := objMap["sources"]; ok && sourcesRaw != nil {
+		var sources []json.RawMessage
+		err = json.Unmarshal(*sourcesRaw, &sources)
+		if err != nil {
+			return err
+		}
+		for _, raw := range sources {
+			if source := DecodeSource(raw); source != nil {
+				c.Sources = append(c.Sources, source)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -241,6 +265,48 @@ func DecodeSource(in json.RawMessage) Source {
 			return nil
 		}
 		return ghSource
+	case string(GitLab):
+		var glSource GLSource
+		err = json.Unmarshal(in, &glSource)
+		if err != nil {
+			return nil
+		}
+		return glSource
+	case string(Forgejo):
+		var fjSource FJSource
+		err = json.Unmarshal(in, &fjSource)
+		if err != nil {
+			return nil
+		}
+		return fjSource
+	case string(Local):
+		var lcSource LocalSource
+		err = json.Unmarshal(in, &lcSource)
+		if err != nil {
+			return nil
+		}
+		return lcSource
+	case string(Git):
+		var gitSource GitSource
+		err = json.Unmarshal(in, &gitSource)
+		if err != nil {
+			return nil
+		}
+		return gitSource
+	case string(URL):
+		var urlSource URLSource
+		err = json.Unmarshal(in, &urlSource)
+		if err != nil {
+			return nil
+		}
+		return urlSource
+	case string(S3):
+		var s3Source S3Source
+		err = json.Unmarshal(in, &s3Source)
+		if err != nil {
+			return nil
+		}
+		return s3Source
 	}
 	return nil
 }
@@ -308,3 +374,9 @@ type UpdateChangelogBody struct {
 	Analytics     *bool       `json:"analytics,omitempty"`
 	Searchable    *bool       `json:"searchable,omitempty"`
 }
+
+// Combines the release notes of multiple sources in one changelog.
+type SetChangelogSourcesBody struct {
+	// The ids of the sources, release notes are merged by their publishedAt.
+	SourceIDs []string `json:"sourceIds"`
+}
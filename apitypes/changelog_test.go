@@ -164,6 +164,64 @@ func TestChangelogUnmarshal(t *testing.T) {
 			Protected:  true,
 			Searchable: true,
 		},
+		{
+			ID: "cl_xxxx",
+			Source: GLSource{
+				ID:          "gl_xxxx",
+				WorkspaceID: "ws_xxxx",
+				Project:     "jonashiltl/openchangelog",
+				Path:        ".testdata",
+				Ref:         "main",
+			},
+		},
+		{
+			ID: "cl_xxxx",
+			Source: FJSource{
+				ID:          "fj_xxxx",
+				WorkspaceID: "ws_xxxx",
+				BaseURL:     "codeberg.org",
+				Project:     "jonashiltl/openchangelog",
+			},
+		},
+		{
+			ID: "cl_xxxx",
+			Source: LocalSource{
+				ID:          "lc_xxxx",
+				WorkspaceID: "ws_xxxx",
+				Path:        "release-notes",
+				Include:     []string{"**/*.md"},
+				Exclude:     []string{"**/draft-*"},
+			},
+		},
+		{
+			ID: "cl_xxxx",
+			Source: GitSource{
+				ID:          "git_xxxx",
+				WorkspaceID: "ws_xxxx",
+				URL:         "https://git.example.com/org/repo.git",
+				Ref:         "main",
+				Path:        "release-notes",
+			},
+		},
+		{
+			ID: "cl_xxxx",
+			Source: URLSource{
+				ID:          "url_xxxx",
+				WorkspaceID: "ws_xxxx",
+				URL:         "https://cdn.example.com/releases.txt",
+				Manifest:    true,
+			},
+		},
+		{
+			ID: "cl_xxxx",
+			Source: S3Source{
+				ID:          "s3_xxxx",
+				WorkspaceID: "ws_xxxx",
+				Endpoint:    "http://localhost:9000",
+				Bucket:      "changelog",
+				Prefix:      "release-notes",
+			},
+		},
 	}
 
 	for _, table := range tables {
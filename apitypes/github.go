@@ -0,0 +1,34 @@
+package apitypes
+
+import "time"
+
+type GHInstallURL struct {
+	// Installs the GitHub App, GitHub redirects to the setup url of Openchangelog afterwards.
+	URL string `json:"url"`
+}
+
+type GHInstallation struct {
+	ID int64 `json:"id"`
+	// The login of the user or organization the app is installed on.
+	Account   string    `json:"account"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// A repository the GitHub App is installed on.
+type GHRepository struct {
+	Owner         string `json:"owner"`
+	Name          string `json:"name"`
+	Private       bool   `json:"private"`
+	DefaultBranch string `json:"defaultBranch"`
+}
+
+// Creates a GitHub source for a repository of an installation, see CreateGHSourceBody.
+type CreateGHInstallationSourceBody struct {
+	Owner    string   `json:"owner"`
+	Repo     string   `json:"repo"`
+	Path     string   `json:"path"`
+	Ref      string   `json:"ref"`
+	Releases bool     `json:"releases"`
+	Include  []string `json:"include"`
+	Exclude  []string `json:"exclude"`
+}
@@ -0,0 +1,18 @@
+package apitypes
+
+import "time"
+
+type CreatePreviewBody struct {
+	// Seconds until the preview link expires, defaults to 7 days.
+	ExpiresIn int `json:"expiresIn"`
+}
+
+// A signed link that renders a draft or scheduled release note until it expires.
+type Preview struct {
+	ReleaseNoteID string `json:"releaseNoteId"`
+	// The path of the link, relative to the url of the changelog.
+	Path string `json:"path"`
+	// The full link, only set if the changelog has a custom domain.
+	URL       string    `json:"url,omitempty"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
@@ -0,0 +1,42 @@
+package apitypes
+
+import "time"
+
+// Event sent to webhooks when a release note is published,
+// either by adding it to the source or once its scheduled publishedAt passes.
+const ReleaseNotePublishedEvent = "release_note.published"
+
+type Webhook struct {
+	ID          string `json:"id"`
+	WorkspaceID string `json:"workspaceId"`
+	ChangelogID string `json:"changelogId"`
+	URL         string `json:"url"`
+	// Only returned on creation, used to verify the payload signatures.
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type CreateWebhookBody struct {
+	URL string `json:"url"`
+}
+
+type WebhookDelivery struct {
+	ID            string    `json:"id"`
+	WebhookID     string    `json:"webhookId"`
+	ReleaseNoteID string    `json:"releaseNoteId"`
+	Event         string    `json:"event"`
+	Attempt       int       `json:"attempt"`
+	StatusCode    int       `json:"statusCode"`
+	Success       bool      `json:"success"`
+	Error         string    `json:"error,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// The json body sent to webhooks.
+// Signed with the webhook secret, the signature is sent in the X-Openchangelog-Signature header.
+type WebhookPayload struct {
+	Event       string  `json:"event"`
+	WorkspaceID string  `json:"workspaceId"`
+	ChangelogID string  `json:"changelogId"`
+	Article     Article `json:"article"`
+}
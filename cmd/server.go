@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"flag"
 	"fmt"
@@ -8,26 +9,38 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/btvoidx/mint"
 	"github.com/jonashiltl/openchangelog/internal/config"
 	"github.com/jonashiltl/openchangelog/internal/events"
+	"github.com/jonashiltl/openchangelog/internal/ghapp"
 	"github.com/jonashiltl/openchangelog/internal/handler/rest"
 	"github.com/jonashiltl/openchangelog/internal/handler/rss"
 	"github.com/jonashiltl/openchangelog/internal/handler/web"
 	"github.com/jonashiltl/openchangelog/internal/handler/web/admin"
 	"github.com/jonashiltl/openchangelog/internal/load"
+	"github.com/jonashiltl/openchangelog/internal/mail"
 	"github.com/jonashiltl/openchangelog/internal/parse"
 	"github.com/jonashiltl/openchangelog/internal/search"
 	"github.com/jonashiltl/openchangelog/internal/store"
+	"github.com/jonashiltl/openchangelog/internal/subscribe"
+	"github.com/jonashiltl/openchangelog/internal/webhook"
 	"github.com/jonashiltl/openchangelog/internal/xcache"
 	"github.com/jonashiltl/openchangelog/internal/xlog"
+	"github.com/jonashiltl/openchangelog/migrations"
 	"github.com/naveensrinivasan/httpcache"
+	"github.com/pressly/goose/v3"
 	"github.com/rs/cors"
 )
 
 func main() {
-	cfg, err := parseConfig()
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrate(os.Args[2:]))
+	}
+
+	cfg, err := parseConfig(flag.CommandLine, os.Args[1:])
 	if err != nil {
 		slog.Error("failed to read config", xlog.ErrAttr(err))
 		os.Exit(1)
@@ -56,31 +69,146 @@ func main() {
 
 	e := new(mint.Emitter)
 	parser := parse.NewParser(parse.CreateGoldmark())
-	loader := load.NewLoader(cfg, st, cache, parser, e)
+	parsed := parse.NewCache(parser, cache)
+	loader := load.NewLoader(cfg, st, cache, parsed, e)
+	defer loader.Close()
 	renderer := web.NewRenderer(cfg)
-	listener := events.NewListener(cfg, e, parser, searcher, cache)
+	listener := events.NewListener(cfg, e, parsed, searcher, cache)
 	listener.Start()
 	defer listener.Close()
+	scheduler := load.NewScheduler(loader)
+	scheduler.Start()
+	defer scheduler.Close()
+	watcher := load.NewWatcher(loader)
+	watcher.Start()
+	defer watcher.Close()
+	subscriptions := subscribe.NewService(cfg, st, mail.NewSender(cfg))
+	dispatcher := subscribe.NewDispatcher(e, subscriptions, parsed)
+	dispatcher.Start()
+	defer dispatcher.Close()
+	webhooks := webhook.NewDispatcher(cfg, e, st, parsed)
+	webhooks.Start()
+	defer webhooks.Close()
+	ghApp, err := ghapp.New(cfg)
+	if err != nil {
+		slog.Error("failed to create github app", xlog.ErrAttr(err))
+		os.Exit(1)
+	}
 
-	rest.RegisterRestHandler(mux, rest.NewEnv(st, loader, parser, e))
-	web.RegisterWebHandler(mux, web.NewEnv(cfg, loader, parser, renderer, searcher))
-	admin.RegisterAdminHandler(mux, admin.NewEnv(cfg, st))
-	rss.RegisterRSSHandler(mux, rss.NewEnv(cfg, loader, parser))
+	rest.RegisterRestHandler(mux, rest.NewEnv(cfg, st, loader, parser, e, ghApp))
+	web.RegisterWebHandler(mux, web.NewEnv(cfg, loader, parser, renderer, searcher, subscriptions))
+	admin.RegisterAdminHandler(mux, admin.NewEnv(cfg, st, loader))
+	rss.RegisterRSSHandler(mux, rss.NewEnv(cfg, loader, parser, searcher))
 	handler := cors.Default().Handler(mux)
 
 	slog.Info("Ready to serve requests", slog.String("addr", fmt.Sprintf("http://%s", cfg.Addr)))
 	log.Fatal(http.ListenAndServe(cfg.Addr, handler))
 }
 
-func parseConfig() (config.Config, error) {
-	configPath := flag.String("config", "", "config file path")
-	flag.Parse()
+func parseConfig(fs *flag.FlagSet, args []string) (config.Config, error) {
+	configPath := fs.String("config", "", "config file path")
+	err := fs.Parse(args)
+	if err != nil {
+		return config.Config{}, err
+	}
 	return config.Load(*configPath)
 }
 
+const migrateUsage = "usage: openchangelog migrate up|down|status [-config path]"
+
+// Runs the migrate subcommand, returns the exit code.
+func runMigrate(args []string) int {
+	if len(args) < 1 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+	action := args[0]
+	if action != "up" && action != "down" && action != "status" {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	cfg, err := parseConfig(flag.NewFlagSet("migrate", flag.ExitOnError), args[1:])
+	if err != nil {
+		slog.Error("failed to read config", xlog.ErrAttr(err))
+		return 1
+	}
+	if !cfg.IsDBMode() {
+		slog.Error("migrations require 'sqliteUrl' to be configured")
+		return 1
+	}
+
+	m, err := store.NewMigrator(cfg.SqliteURL, migrations.FS)
+	if err != nil {
+		slog.Error("failed to create migrator", xlog.ErrAttr(err))
+		return 1
+	}
+	defer m.Close()
+
+	ctx := context.Background()
+	switch action {
+	case "up":
+		err = migrateUp(ctx, m)
+	case "down":
+		var res *goose.MigrationResult
+		res, err = m.Down(ctx)
+		if err == nil && res == nil {
+			slog.Info("no migrations to roll back")
+		} else if err == nil {
+			slog.Info("rolled back migration", slog.String("migration", filepath.Base(res.Source.Path)))
+		}
+	case "status":
+		err = printMigrationStatus(ctx, m)
+	}
+
+	if err != nil {
+		slog.Error("migration failed", xlog.ErrAttr(err))
+		return 1
+	}
+	return 0
+}
+
+func migrateUp(ctx context.Context, m *store.Migrator) error {
+	res, err := m.Up(ctx)
+	if err != nil {
+		return err
+	}
+	for _, r := range res {
+		slog.Info("applied migration", slog.String("migration", filepath.Base(r.Source.Path)))
+	}
+	return nil
+}
+
+func printMigrationStatus(ctx context.Context, m *store.Migrator) error {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%-20s %s\n", "Applied At", "Migration")
+	for _, s := range status {
+		appliedAt := "Pending"
+		if s.State == goose.StateApplied {
+			appliedAt = s.AppliedAt.Format(time.DateTime)
+		}
+		fmt.Printf("%-20s %s\n", appliedAt, filepath.Base(s.Source.Path))
+	}
+	return nil
+}
+
 func createStore(cfg config.Config) (store.Store, error) {
 	if cfg.IsDBMode() {
 		slog.Info("Starting Openchangelog backed by sqlite")
+		m, err := store.NewMigrator(cfg.SqliteURL, migrations.FS)
+		if err != nil {
+			return nil, err
+		}
+		defer m.Close()
+
+		// apply pending migrations so the schema always matches the binary
+		err = migrateUp(context.Background(), m)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply migrations: %w", err)
+		}
 		return store.NewSQLiteStore(cfg.SqliteURL)
 	} else {
 		slog.Info("Starting Openchangelog in config mode")
@@ -3,14 +3,19 @@ package main
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"os/exec"
 	"path/filepath"
-	"sort"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -25,10 +30,13 @@ import (
 	"github.com/jonashiltl/openchangelog/internal/handler/web"
 	"github.com/jonashiltl/openchangelog/internal/handler/web/admin"
 	"github.com/jonashiltl/openchangelog/internal/load"
+	"github.com/jonashiltl/openchangelog/internal/mail"
 	"github.com/jonashiltl/openchangelog/internal/parse"
 	"github.com/jonashiltl/openchangelog/internal/search"
 	"github.com/jonashiltl/openchangelog/internal/store"
+	"github.com/jonashiltl/openchangelog/internal/subscribe"
 	"github.com/jonashiltl/openchangelog/internal/xcache"
+	"github.com/jonashiltl/openchangelog/migrations"
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/naveensrinivasan/httpcache"
 	"github.com/rs/cors"
@@ -39,64 +47,15 @@ import (
 func runMigrations(t *testing.T, dbPath string) {
 	t.Helper()
 
-	db, err := sql.Open("sqlite3", dbPath)
+	m, err := store.NewMigrator(dbPath, migrations.FS)
 	if err != nil {
 		t.Fatalf("Failed to open database: %v", err)
 	}
-	defer db.Close()
-
-	// Force SQLite to create the database file
-	_, err = db.Exec("SELECT 1")
-	if err != nil {
-		t.Fatalf("Failed to initialize database: %v", err)
-	}
+	defer m.Close()
 
-	// Read and execute migration files in order
-	migrationsDir := "migrations"
-	entries, err := os.ReadDir(migrationsDir)
+	_, err = m.Up(context.Background())
 	if err != nil {
-		t.Fatalf("Failed to read migrations directory: %v", err)
-	}
-
-	var migrationFiles []string
-	for _, entry := range entries {
-		if strings.HasSuffix(entry.Name(), ".sql") {
-			migrationFiles = append(migrationFiles, entry.Name())
-		}
-	}
-	sort.Strings(migrationFiles)
-
-	for _, filename := range migrationFiles {
-		content, err := os.ReadFile(filepath.Join(migrationsDir, filename))
-		if err != nil {
-			t.Fatalf("Failed to read migration file %s: %v", filename, err)
-		}
-
-		// Extract SQL between goose Up markers
-		sqlContent := string(content)
-		lines := strings.Split(sqlContent, "\n")
-		var upSQL strings.Builder
-		inUpSection := false
-
-		for _, line := range lines {
-			if strings.Contains(line, "-- +goose Up") {
-				inUpSection = true
-				continue
-			}
-			if strings.Contains(line, "-- +goose Down") {
-				break
-			}
-			if inUpSection && !strings.Contains(line, "-- +goose StatementBegin") && !strings.Contains(line, "-- +goose StatementEnd") {
-				upSQL.WriteString(line + "\n")
-			}
-		}
-
-		if upSQL.Len() > 0 {
-			_, err = db.Exec(upSQL.String())
-			if err != nil {
-				t.Fatalf("Failed to execute migration %s: %v", filename, err)
-			}
-		}
+		t.Fatalf("Failed to execute migrations: %v", err)
 	}
 }
 
@@ -147,22 +106,25 @@ func NewTestApp(t *testing.T, cfg config.Config, tempDir string) *TestApp {
 	mux := http.NewServeMux()
 	e := new(mint.Emitter)
 	parser := parse.NewParser(parse.CreateGoldmark())
-	loader := load.NewLoader(cfg, st, cache, parser, e)
+	parsed := parse.NewCache(parser, cache)
+	loader := load.NewLoader(cfg, st, cache, parsed, e)
 	renderer := web.NewRenderer(cfg)
 
-	listener := events.NewListener(cfg, e, parser, searcher, cache)
+	listener := events.NewListener(cfg, e, parsed, searcher, cache)
 	listener.Start()
+	subscriptions := subscribe.NewService(cfg, st, mail.NewSender(cfg))
 
-	rest.RegisterRestHandler(mux, rest.NewEnv(st, loader, parser, e))
-	web.RegisterWebHandler(mux, web.NewEnv(cfg, loader, parser, renderer, searcher))
-	admin.RegisterAdminHandler(mux, admin.NewEnv(cfg, st))
-	rss.RegisterRSSHandler(mux, rss.NewEnv(cfg, loader, parser))
+	rest.RegisterRestHandler(mux, rest.NewEnv(cfg, st, loader, parser, e, nil))
+	web.RegisterWebHandler(mux, web.NewEnv(cfg, loader, parser, renderer, searcher, subscriptions))
+	admin.RegisterAdminHandler(mux, admin.NewEnv(cfg, st, loader))
+	rss.RegisterRSSHandler(mux, rss.NewEnv(cfg, loader, parser, searcher))
 
 	handler := cors.Default().Handler(mux)
 	server := httptest.NewServer(handler)
 
 	cleanup := func() {
 		listener.Close()
+		loader.Close()
 		searcher.Close()
 		server.Close()
 		os.RemoveAll(tempDir)
@@ -259,6 +221,44 @@ func createForgejoTestConfig(t *testing.T, tempDir string) config.Config {
 	}
 }
 
+// Serves the test release notes over http, listed newest first in /manifest.txt.
+func newReleaseNotesServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	entries, err := os.ReadDir(".testdata")
+	if err != nil {
+		t.Fatalf("Failed to read release notes: %v", err)
+	}
+	var manifest strings.Builder
+	for _, e := range slices.Backward(entries) {
+		if filepath.Ext(e.Name()) == ".md" {
+			manifest.WriteString(e.Name() + "\n")
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /manifest.txt", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(manifest.String()))
+	})
+	mux.Handle("GET /", http.FileServer(http.Dir(".testdata")))
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func createURLTestConfig(t *testing.T, tempDir string) config.Config {
+	t.Helper()
+
+	srv := newReleaseNotesServer(t)
+	return config.Config{
+		Addr: "127.0.0.1:0",
+		URL: &config.URLConfig{
+			URL:      srv.URL + "/manifest.txt",
+			Manifest: true,
+		},
+	}
+}
+
 func createGitLabTestConfig(t *testing.T, tempDir string) config.Config {
 	t.Helper()
 
@@ -439,6 +439,123 @@ func TestRSSEndpoint(t *testing.T) {
 	}
 }
 
+func TestAtomAndJSONFeedEndpoints(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "openchangelog-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	cfg := createTestConfig(t, tempDir)
+	app := NewTestApp(t, cfg, tempDir)
+	defer app.Close()
+
+	tests := []struct {
+		path                string
+		expectedContentType string
+		expectedContent     string
+	}{
+		{
+			path:                "/feed.atom",
+			expectedContentType: "application/atom+xml",
+			expectedContent:     `<feed xmlns="http://www.w3.org/2005/Atom">`,
+		},
+		{
+			path:                "/feed.json",
+			expectedContentType: "application/feed+json",
+			expectedContent:     `"version":"https://jsonfeed.org/version/1.1"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			resp, err := app.Get(tt.path)
+			if err != nil {
+				t.Fatalf("Failed to make request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("Expected status %d, got %d", http.StatusOK, resp.StatusCode)
+			}
+			if contentType := resp.Header.Get("Content-Type"); contentType != tt.expectedContentType {
+				t.Errorf("Expected content type %s, got %s", tt.expectedContentType, contentType)
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("Failed to read response body: %v", err)
+			}
+			if !strings.Contains(string(body), tt.expectedContent) {
+				t.Errorf("Expected body to contain %q, got %s", tt.expectedContent, string(body))
+			}
+		})
+	}
+
+	t.Run("alternate links", func(t *testing.T) {
+		resp, err := app.Get("/")
+		if err != nil {
+			t.Fatalf("Failed to make request: %v", err)
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("Failed to read response body: %v", err)
+		}
+		for _, typ := range []string{"application/rss+xml", "application/atom+xml", "application/feed+json"} {
+			if !strings.Contains(string(body), `rel="alternate" type="`+typ+`"`) {
+				t.Errorf("Expected alternate link of type %s", typ)
+			}
+		}
+	})
+}
+
+func TestFilteredFeed(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "openchangelog-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	cfg := createTestConfig(t, tempDir)
+	app := NewTestApp(t, cfg, tempDir)
+	defer app.Close()
+
+	resp, err := app.Get("/feed.json?tags=community")
+	if err != nil {
+		t.Fatalf("Failed to make request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+
+	var feed struct {
+		Title string `json:"title"`
+		Items []struct {
+			Tags []string `json:"tags"`
+		} `json:"items"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&feed)
+	if err != nil {
+		t.Fatalf("Failed to decode feed: %v", err)
+	}
+
+	if feed.Title != "Test Changelog - community" {
+		t.Errorf("Expected title to reflect the filter, got %s", feed.Title)
+	}
+	if len(feed.Items) == 0 {
+		t.Fatalf("Expected feed to contain release notes tagged community")
+	}
+	for _, item := range feed.Items {
+		if !slices.ContainsFunc(item.Tags, func(tag string) bool { return strings.EqualFold(tag, "community") }) {
+			t.Errorf("Expected only release notes tagged community, got %v", item.Tags)
+		}
+	}
+}
+
 // Database Integration Tests
 
 func TestDatabaseMode(t *testing.T) {
@@ -469,7 +586,7 @@ func TestDatabaseMode(t *testing.T) {
 	defer db.Close()
 
 	// Test that tables exist
-	tables := []string{"workspaces", "tokens", "changelogs", "gh_sources"}
+	tables := []string{"workspaces", "tokens", "changelogs", "gh_sources", "gl_sources", "fj_sources", "local_sources", "git_sources", "subscribers", "dispatched_release_notes", "webhooks", "webhook_deliveries", "webhook_sources", "webhook_skipped_release_notes"}
 	for _, table := range tables {
 		var count int
 		err := db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name='%s'", table)).Scan(&count)
@@ -482,7 +599,7 @@ func TestDatabaseMode(t *testing.T) {
 	}
 
 	// Test that views exist
-	views := []string{"changelog_source"}
+	views := []string{"changelog_source", "changelog_gl_source", "changelog_fj_source", "changelog_local_source", "changelog_git_source"}
 	for _, view := range views {
 		var count int
 		err := db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM sqlite_master WHERE type='view' AND name='%s'", view)).Scan(&count)
@@ -597,6 +714,10 @@ func TestDifferentConfigurations(t *testing.T) {
 			name:     "GitLab source configuration",
 			setupCfg: createGitLabTestConfig,
 		},
+		{
+			name:     "URL source configuration",
+			setupCfg: createURLTestConfig,
+		},
 	}
 
 	for _, tt := range tests {
@@ -819,6 +940,717 @@ func TestE2EMultiTenantWithAPIClient(t *testing.T) {
 	})
 }
 
+// TestE2ELocalSourceInDatabaseMode tests attaching a local source to a DB-backed changelog
+func TestE2ELocalSourceInDatabaseMode(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "openchangelog-local-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	cfg := config.Config{
+		Addr:      "127.0.0.1:0",
+		SqliteURL: fmt.Sprintf("file:%s/local_test.db?cache=shared&mode=rwc", tempDir),
+		Local: &config.LocalConfig{
+			FilesPath: ".",
+		},
+	}
+
+	app := NewTestApp(t, cfg, tempDir)
+	defer app.Close()
+
+	ctx := context.Background()
+	client, err := api.NewClient(&api.Config{
+		Address:   app.Server.URL + "/api",
+		AuthToken: "temp-token",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create API client: %v", err)
+	}
+
+	ws, err := client.CreateWorkspace(ctx, apitypes.CreateWorkspaceBody{Name: "Local"})
+	if err != nil {
+		t.Fatalf("Failed to create workspace: %v", err)
+	}
+
+	client, err = api.NewClient(&api.Config{
+		Address:   app.Server.URL + "/api",
+		AuthToken: ws.Token,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create API client: %v", err)
+	}
+
+	lc, err := client.CreateLocalSource(ctx, apitypes.CreateLocalSourceBody{
+		// must not escape the configured files path
+		Path: "../../.testdata",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create local source: %v", err)
+	}
+	if lc.Path != ".testdata" {
+		t.Errorf("Expected local source path to be confined to files path, got %s", lc.Path)
+	}
+
+	cl, err := client.CreateChangelog(ctx, apitypes.CreateChangelogBody{
+		Title: apitypes.NewString("Local"),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create changelog: %v", err)
+	}
+
+	err = client.SetChangelogSource(ctx, cl.ID, lc.ID)
+	if err != nil {
+		t.Fatalf("Failed to set changelog source: %v", err)
+	}
+
+	cl, err = client.GetChangelog(ctx, cl.ID)
+	if err != nil {
+		t.Fatalf("Failed to get changelog: %v", err)
+	}
+	if cl.Source == nil || cl.Source.Type() != apitypes.Local {
+		t.Fatalf("Expected changelog to have a local source, got %v", cl.Source)
+	}
+
+	full, err := client.GetFullChangelog(ctx, api.GetFullChangelogParams{ChangelogID: cl.ID})
+	if err != nil {
+		t.Fatalf("Failed to get full changelog: %v", err)
+	}
+	if len(full.Articles) == 0 {
+		t.Errorf("Expected articles to be loaded from local source")
+	}
+
+	health, err := client.GetChangelogHealth(ctx, cl.ID)
+	if err != nil {
+		t.Fatalf("Failed to get changelog health: %v", err)
+	}
+	if len(health) != 1 {
+		t.Fatalf("Expected health of 1 source, got %d", len(health))
+	}
+	if !health[0].Healthy || health[0].ReleaseNotes < len(full.Articles) {
+		t.Errorf("Expected local source to be healthy with all release notes, got %+v", health[0])
+	}
+	if s, ok := health[0].Source.(apitypes.LocalSource); !ok || s.ID != lc.ID {
+		t.Errorf("Expected health of local source %s, got %+v", lc.ID, health[0].Source)
+	}
+
+	sources, err := client.ListSources(ctx)
+	if err != nil {
+		t.Fatalf("Failed to list sources: %v", err)
+	}
+	if len(sources) != 1 {
+		t.Errorf("Expected 1 source, got %d", len(sources))
+	}
+}
+
+// TestE2EGitSourceInDatabaseMode tests attaching a git source to a DB-backed changelog
+func TestE2EGitSourceInDatabaseMode(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	tempDir, err := os.MkdirTemp("", "openchangelog-git-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	repo := filepath.Join(tempDir, "repo")
+	for _, args := range [][]string{
+		{"init", "--quiet", "--initial-branch=main", repo},
+		{"-C", repo, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "--allow-empty", "-m", "init"},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+	}
+	err = os.CopyFS(filepath.Join(repo, "notes"), os.DirFS(".testdata"))
+	if err != nil {
+		t.Fatalf("Failed to copy release notes: %v", err)
+	}
+	for _, args := range [][]string{
+		{"-C", repo, "add", "-A"},
+		{"-C", repo, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "feat: add release notes"},
+		{"-C", repo, "tag", "v1.0.0"},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+	}
+
+	cfg := config.Config{
+		Addr:      "127.0.0.1:0",
+		SqliteURL: fmt.Sprintf("file:%s/git_test.db?cache=shared&mode=rwc", tempDir),
+		Local: &config.LocalConfig{
+			FilesPath: tempDir,
+		},
+		Git: &config.GitConfig{
+			MirrorPath: filepath.Join(tempDir, "mirrors"),
+		},
+	}
+
+	app := NewTestApp(t, cfg, tempDir)
+	defer app.Close()
+
+	ctx := context.Background()
+	client, err := api.NewClient(&api.Config{
+		Address:   app.Server.URL + "/api",
+		AuthToken: "temp-token",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create API client: %v", err)
+	}
+
+	ws, err := client.CreateWorkspace(ctx, apitypes.CreateWorkspaceBody{Name: "Git"})
+	if err != nil {
+		t.Fatalf("Failed to create workspace: %v", err)
+	}
+
+	client, err = api.NewClient(&api.Config{
+		Address:   app.Server.URL + "/api",
+		AuthToken: ws.Token,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create API client: %v", err)
+	}
+
+	_, err = client.CreateGitSource(ctx, apitypes.CreateGitSourceBody{URL: "ext::sh -c id"})
+	if err == nil {
+		t.Errorf("Expected unsupported remote to be rejected")
+	}
+
+	_, err = client.CreateGitSource(ctx, apitypes.CreateGitSourceBody{URL: "file://repo", Ref: "unknown", Path: "notes"})
+	if err == nil {
+		t.Errorf("Expected unknown ref to be rejected")
+	}
+
+	gs, err := client.CreateGitSource(ctx, apitypes.CreateGitSourceBody{URL: "file://repo", Ref: "main", Path: "notes"})
+	if err != nil {
+		t.Fatalf("Failed to create git source: %v", err)
+	}
+
+	cl, err := client.CreateChangelog(ctx, apitypes.CreateChangelogBody{
+		Title: apitypes.NewString("Git"),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create changelog: %v", err)
+	}
+
+	err = client.SetChangelogSource(ctx, cl.ID, gs.ID)
+	if err != nil {
+		t.Fatalf("Failed to set changelog source: %v", err)
+	}
+
+	cl, err = client.GetChangelog(ctx, cl.ID)
+	if err != nil {
+		t.Fatalf("Failed to get changelog: %v", err)
+	}
+	if cl.Source == nil || cl.Source.Type() != apitypes.Git {
+		t.Fatalf("Expected changelog to have a git source, got %v", cl.Source)
+	}
+
+	full, err := client.GetFullChangelog(ctx, api.GetFullChangelogParams{ChangelogID: cl.ID})
+	if err != nil {
+		t.Fatalf("Failed to get full changelog: %v", err)
+	}
+	if len(full.Articles) == 0 {
+		t.Errorf("Expected articles to be loaded from git source")
+	}
+	gitArticles := len(full.Articles)
+
+	cc, err := client.CreateGitSource(ctx, apitypes.CreateGitSourceBody{URL: "file://repo", Ref: "main", ConventionalCommits: true})
+	if err != nil {
+		t.Fatalf("Failed to create conventional commits git source: %v", err)
+	}
+	if !cc.ConventionalCommits {
+		t.Errorf("Expected git source to generate release notes from conventional commits")
+	}
+	err = client.SetChangelogSource(ctx, cl.ID, cc.ID)
+	if err != nil {
+		t.Fatalf("Failed to set changelog source: %v", err)
+	}
+	full, err = client.GetFullChangelog(ctx, api.GetFullChangelogParams{ChangelogID: cl.ID})
+	if err != nil {
+		t.Fatalf("Failed to get full changelog: %v", err)
+	}
+	if len(full.Articles) != 1 || full.Articles[0].Title != "v1.0.0" {
+		t.Errorf("Expected one article generated from the v1.0.0 tag, got %v", full.Articles)
+	}
+	err = client.DeleteGitSource(ctx, cc.ID)
+	if err != nil {
+		t.Fatalf("Failed to delete git source: %v", err)
+	}
+
+	// the local source reads the same release notes, their ids collide without a prefix
+	lc, err := client.CreateLocalSource(ctx, apitypes.CreateLocalSourceBody{Path: "repo/notes"})
+	if err != nil {
+		t.Fatalf("Failed to create local source: %v", err)
+	}
+	err = client.SetChangelogSources(ctx, cl.ID, []string{gs.ID, lc.ID, gs.ID})
+	if err == nil {
+		t.Errorf("Expected duplicate sources to be rejected")
+	}
+	deleted, err := client.CreateLocalSource(ctx, apitypes.CreateLocalSourceBody{Path: "repo"})
+	if err != nil {
+		t.Fatalf("Failed to create local source: %v", err)
+	}
+	err = client.DeleteLocalSource(ctx, deleted.ID)
+	if err != nil {
+		t.Fatalf("Failed to delete local source: %v", err)
+	}
+	err = client.SetChangelogSources(ctx, cl.ID, []string{gs.ID, deleted.ID})
+	if err == nil {
+		t.Errorf("Expected sources which don't exist in the workspace to be rejected")
+	}
+	err = client.SetChangelogSources(ctx, cl.ID, []string{gs.ID, lc.ID})
+	if err != nil {
+		t.Fatalf("Failed to set changelog sources: %v", err)
+	}
+	cl, err = client.GetChangelog(ctx, cl.ID)
+	if err != nil {
+		t.Fatalf("Failed to get changelog: %v", err)
+	}
+	if len(cl.Sources) != 2 || cl.Sources[0].Type() != apitypes.Git || cl.Sources[1].Type() != apitypes.Local {
+		t.Errorf("Expected changelog to combine the git and local source, got %v", cl.Sources)
+	}
+	full, err = client.GetFullChangelog(ctx, api.GetFullChangelogParams{ChangelogID: cl.ID, PageSize: 2 * gitArticles})
+	if err != nil {
+		t.Fatalf("Failed to get full changelog: %v", err)
+	}
+	if len(full.Articles) != 2*gitArticles {
+		t.Errorf("Expected %d articles of both sources, got %d", 2*gitArticles, len(full.Articles))
+	}
+	ids := make(map[string]bool)
+	for _, a := range full.Articles {
+		ids[a.ID] = true
+	}
+	if len(ids) != len(full.Articles) {
+		t.Errorf("Expected the ids of combined sources to be unique")
+	}
+
+	err = client.SetChangelogSource(ctx, cl.ID, gs.ID)
+	if err != nil {
+		t.Fatalf("Failed to set changelog source: %v", err)
+	}
+	cl, err = client.GetChangelog(ctx, cl.ID)
+	if err != nil {
+		t.Fatalf("Failed to get changelog: %v", err)
+	}
+	if len(cl.Sources) != 0 {
+		t.Errorf("Expected setting a single source to replace the combined sources, got %v", cl.Sources)
+	}
+
+	err = client.DeleteChangelogSource(ctx, cl.ID)
+	if err != nil {
+		t.Fatalf("Failed to detach git source: %v", err)
+	}
+	err = client.DeleteGitSource(ctx, gs.ID)
+	if err != nil {
+		t.Fatalf("Failed to delete git source: %v", err)
+	}
+}
+
+// TestE2EURLSourceInDatabaseMode tests attaching url sources to a DB-backed changelog
+func TestE2EURLSourceInDatabaseMode(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "openchangelog-url-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	srv := newReleaseNotesServer(t)
+	cfg := config.Config{
+		Addr:      "127.0.0.1:0",
+		SqliteURL: fmt.Sprintf("file:%s/url_test.db?cache=shared&mode=rwc", tempDir),
+		// the test server listens on localhost
+		URL: &config.URLConfig{AllowPrivateAddresses: true},
+	}
+
+	app := NewTestApp(t, cfg, tempDir)
+	defer app.Close()
+
+	ctx := context.Background()
+	client, err := api.NewClient(&api.Config{
+		Address:   app.Server.URL + "/api",
+		AuthToken: "temp-token",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create API client: %v", err)
+	}
+
+	ws, err := client.CreateWorkspace(ctx, apitypes.CreateWorkspaceBody{Name: "URL"})
+	if err != nil {
+		t.Fatalf("Failed to create workspace: %v", err)
+	}
+
+	client, err = api.NewClient(&api.Config{
+		Address:   app.Server.URL + "/api",
+		AuthToken: ws.Token,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create API client: %v", err)
+	}
+
+	_, err = client.CreateURLSource(ctx, apitypes.CreateURLSourceBody{URL: "file:///etc/passwd"})
+	if err == nil {
+		t.Errorf("Expected non http url to be rejected")
+	}
+
+	_, err = client.CreateURLSource(ctx, apitypes.CreateURLSourceBody{URL: srv.URL + "/missing.md"})
+	if err == nil {
+		t.Errorf("Expected unreachable url to be rejected")
+	}
+
+	us, err := client.CreateURLSource(ctx, apitypes.CreateURLSourceBody{URL: srv.URL + "/manifest.txt", Manifest: true})
+	if err != nil {
+		t.Fatalf("Failed to create url source: %v", err)
+	}
+	if us.Type() != apitypes.URL || !us.Manifest {
+		t.Errorf("Expected url manifest source, got %+v", us)
+	}
+
+	cl, err := client.CreateChangelog(ctx, apitypes.CreateChangelogBody{
+		Title: apitypes.NewString("URL"),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create changelog: %v", err)
+	}
+
+	err = client.SetChangelogSource(ctx, cl.ID, us.ID)
+	if err != nil {
+		t.Fatalf("Failed to set changelog source: %v", err)
+	}
+
+	cl, err = client.GetChangelog(ctx, cl.ID)
+	if err != nil {
+		t.Fatalf("Failed to get changelog: %v", err)
+	}
+	if cl.Source == nil || cl.Source.Type() != apitypes.URL {
+		t.Fatalf("Expected changelog to have a url source, got %v", cl.Source)
+	}
+
+	full, err := client.GetFullChangelog(ctx, api.GetFullChangelogParams{ChangelogID: cl.ID, PageSize: 3})
+	if err != nil {
+		t.Fatalf("Failed to get full changelog: %v", err)
+	}
+	if len(full.Articles) != 3 || !full.HasMoreArticles {
+		t.Errorf("Expected first page of 3 articles from the manifest, got %d", len(full.Articles))
+	}
+
+	sources, err := client.ListSources(ctx)
+	if err != nil {
+		t.Fatalf("Failed to list sources: %v", err)
+	}
+	if len(sources) != 1 {
+		t.Errorf("Expected 1 source, got %d", len(sources))
+	}
+
+	err = client.DeleteChangelogSource(ctx, cl.ID)
+	if err != nil {
+		t.Fatalf("Failed to detach url source: %v", err)
+	}
+	err = client.DeleteURLSource(ctx, us.ID)
+	if err != nil {
+		t.Fatalf("Failed to delete url source: %v", err)
+	}
+}
+
+// TestChangelogWebhooks tests managing the outgoing webhooks of a changelog through the api
+func TestChangelogWebhooks(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "openchangelog-webhooks-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	cfg := config.Config{
+		Addr:      "127.0.0.1:0",
+		SqliteURL: fmt.Sprintf("file:%s/webhooks_test.db?cache=shared&mode=rwc", tempDir),
+	}
+
+	app := NewTestApp(t, cfg, tempDir)
+	defer app.Close()
+
+	ctx := context.Background()
+	client, err := api.NewClient(&api.Config{
+		Address:   app.Server.URL + "/api",
+		AuthToken: "temp-token",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create API client: %v", err)
+	}
+
+	ws, err := client.CreateWorkspace(ctx, apitypes.CreateWorkspaceBody{Name: "Webhooks"})
+	if err != nil {
+		t.Fatalf("Failed to create workspace: %v", err)
+	}
+
+	client, err = api.NewClient(&api.Config{
+		Address:   app.Server.URL + "/api",
+		AuthToken: ws.Token,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create API client: %v", err)
+	}
+
+	cl, err := client.CreateChangelog(ctx, apitypes.CreateChangelogBody{
+		Title: apitypes.NewString("Webhooks"),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create changelog: %v", err)
+	}
+
+	_, err = client.CreateWebhook(ctx, cl.ID, apitypes.CreateWebhookBody{URL: "not-a-url"})
+	if err == nil {
+		t.Error("Expected invalid webhook url to be rejected")
+	}
+
+	wh, err := client.CreateWebhook(ctx, cl.ID, apitypes.CreateWebhookBody{URL: "https://example.com/hook"})
+	if err != nil {
+		t.Fatalf("Failed to create webhook: %v", err)
+	}
+	if wh.Secret == "" {
+		t.Error("Expected secret to be returned on creation")
+	}
+
+	whs, err := client.ListWebhooks(ctx, cl.ID)
+	if err != nil {
+		t.Fatalf("Failed to list webhooks: %v", err)
+	}
+	if len(whs) != 1 || whs[0].ID != wh.ID {
+		t.Fatalf("Expected webhook %s to be listed, got %v", wh.ID, whs)
+	}
+	if whs[0].Secret != "" {
+		t.Error("Expected secret to only be returned on creation")
+	}
+
+	deliveries, err := client.ListWebhookDeliveries(ctx, cl.ID, wh.ID)
+	if err != nil {
+		t.Fatalf("Failed to list webhook deliveries: %v", err)
+	}
+	if len(deliveries) != 0 {
+		t.Errorf("Expected no deliveries, got %d", len(deliveries))
+	}
+
+	err = client.DeleteWebhook(ctx, cl.ID, wh.ID)
+	if err != nil {
+		t.Fatalf("Failed to delete webhook: %v", err)
+	}
+	whs, err = client.ListWebhooks(ctx, cl.ID)
+	if err != nil {
+		t.Fatalf("Failed to list webhooks: %v", err)
+	}
+	if len(whs) != 0 {
+		t.Errorf("Expected webhook to be deleted, got %d webhooks", len(whs))
+	}
+}
+
+// TestWebhookEndpoints tests the signature verification of the webhook endpoints
+func TestWebhookEndpoints(t *testing.T) {
+	cfg := config.Config{
+		Addr: "127.0.0.1:0",
+		Github: &config.GithubConfig{
+			Owner: "jonashiltl",
+			Repo:  "openchangelog",
+			Path:  ".testdata",
+		},
+		Refresh: &config.RefreshConfig{
+			Webhooks: &config.WebhooksConfig{
+				GithubSecret: "gh-secret",
+				GitlabToken:  "gl-token",
+			},
+		},
+	}
+
+	app := NewTestApp(t, cfg, "")
+	defer app.Close()
+
+	payload := []byte(`{"ref":"refs/heads/main","repository":{"full_name":"jonashiltl/openchangelog"},"project":{"path_with_namespace":"jonashiltl/openchangelog"}}`)
+	mac := hmac.New(sha256.New, []byte("gh-secret"))
+	mac.Write(payload)
+	ghSignature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	tests := []struct {
+		name    string
+		path    string
+		headers map[string]string
+		status  int
+	}{
+		{
+			name:    "valid github signature",
+			path:    "/api/webhooks/github",
+			headers: map[string]string{"X-GitHub-Event": "push", "X-Hub-Signature-256": ghSignature},
+			status:  http.StatusOK,
+		},
+		{
+			name:    "invalid github signature",
+			path:    "/api/webhooks/github",
+			headers: map[string]string{"X-GitHub-Event": "push", "X-Hub-Signature-256": "sha256=abcd"},
+			status:  http.StatusUnauthorized,
+		},
+		{
+			name:    "missing github signature",
+			path:    "/api/webhooks/github",
+			headers: map[string]string{"X-GitHub-Event": "push"},
+			status:  http.StatusUnauthorized,
+		},
+		{
+			name:    "valid gitlab token",
+			path:    "/api/webhooks/gitlab",
+			headers: map[string]string{"X-Gitlab-Event": "Push Hook", "X-Gitlab-Token": "gl-token"},
+			status:  http.StatusOK,
+		},
+		{
+			name:    "invalid gitlab token",
+			path:    "/api/webhooks/gitlab",
+			headers: map[string]string{"X-Gitlab-Event": "Push Hook", "X-Gitlab-Token": "wrong"},
+			status:  http.StatusUnauthorized,
+		},
+		{
+			name:    "forgejo not configured",
+			path:    "/api/webhooks/forgejo",
+			headers: map[string]string{"X-Forgejo-Event": "push"},
+			status:  http.StatusNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, app.Server.URL+test.path, bytes.NewReader(payload))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for k, v := range test.headers {
+				req.Header.Set(k, v)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != test.status {
+				t.Errorf("Expected status %d, got %d", test.status, resp.StatusCode)
+			}
+		})
+	}
+}
+
+// TestGitHubInstallationDeleted tests that uninstalling the GitHub App detaches the sources of the installation
+func TestGitHubInstallationDeleted(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "openchangelog-ghapp-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+
+	cfg := createTestConfigWithDB(t, tempDir)
+	cfg.Refresh = &config.RefreshConfig{
+		Webhooks: &config.WebhooksConfig{GithubSecret: "gh-secret"},
+	}
+
+	app := NewTestApp(t, cfg, tempDir)
+	defer app.Close()
+
+	ctx := context.Background()
+	client, err := api.NewClient(&api.Config{
+		Address:   app.Server.URL + "/api",
+		AuthToken: "temp-token",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create API client: %v", err)
+	}
+	ws, err := client.CreateWorkspace(ctx, apitypes.CreateWorkspaceBody{Name: "GitHub App"})
+	if err != nil {
+		t.Fatalf("Failed to create workspace: %v", err)
+	}
+	client, err = api.NewClient(&api.Config{
+		Address:   app.Server.URL + "/api",
+		AuthToken: ws.Token,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create API client: %v", err)
+	}
+
+	wID := store.WorkspaceID(ws.ID)
+	_, err = app.Store.SaveGHInstallation(ctx, store.GHInstallation{WorkspaceID: wID, ID: 42, Account: "acme"})
+	if err != nil {
+		t.Fatalf("Failed to save installation: %v", err)
+	}
+	// installations can't be verified without the app setup
+	_, err = client.CreateGHSource(ctx, apitypes.CreateGHSourceBody{Owner: "acme", Repo: "app", InstallationID: 42})
+	if err == nil {
+		t.Fatal("Expected source with installation id to be rejected without the GitHub App")
+	}
+	gh, err := app.Store.CreateGHSource(ctx, store.GHSource{
+		WorkspaceID:    wID,
+		ID:             store.NewGHID(),
+		Owner:          "acme",
+		Repo:           "app",
+		InstallationID: 42,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create github source: %v", err)
+	}
+
+	cl, err := client.CreateChangelog(ctx, apitypes.CreateChangelogBody{Title: apitypes.NewString("GitHub App")})
+	if err != nil {
+		t.Fatalf("Failed to create changelog: %v", err)
+	}
+	err = client.SetChangelogSource(ctx, cl.ID, gh.ID.String())
+	if err != nil {
+		t.Fatalf("Failed to set changelog source: %v", err)
+	}
+
+	installations, err := client.ListGHInstallations(ctx)
+	if err != nil {
+		t.Fatalf("Failed to list installations: %v", err)
+	}
+	if len(installations) != 1 || installations[0].Account != "acme" {
+		t.Fatalf("Expected installation of acme, got %+v", installations)
+	}
+
+	payload := []byte(`{"action":"deleted","installation":{"id":42}}`)
+	mac := hmac.New(sha256.New, []byte("gh-secret"))
+	mac.Write(payload)
+	req, err := http.NewRequest(http.MethodPost, app.Server.URL+"/api/webhooks/github", bytes.NewReader(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-GitHub-Event", "installation")
+	req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+
+	installations, err = client.ListGHInstallations(ctx)
+	if err != nil {
+		t.Fatalf("Failed to list installations: %v", err)
+	}
+	if len(installations) != 0 {
+		t.Errorf("Expected installation to be deleted, got %+v", installations)
+	}
+
+	cl, err = client.GetChangelog(ctx, cl.ID)
+	if err != nil {
+		t.Fatalf("Failed to get changelog: %v", err)
+	}
+	if cl.Source != nil {
+		t.Errorf("Expected github source to be detached from changelog, got %v", cl.Source)
+	}
+	sources, err := client.ListSources(ctx)
+	if err != nil {
+		t.Fatalf("Failed to list sources: %v", err)
+	}
+	if len(sources) != 1 {
+		t.Errorf("Expected github source to be kept, got %d sources", len(sources))
+	}
+}
+
 // TestE2EMultiTenantIsolation tests that multi-tenant isolation works correctly
 func TestE2EMultiTenantIsolation(t *testing.T) {
 	tempDir, err := os.MkdirTemp("", "openchangelog-isolation-*")
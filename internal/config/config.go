@@ -2,6 +2,7 @@ package config
 
 import (
 	"log/slog"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -20,10 +21,22 @@ const (
 )
 
 type GithubConfig struct {
-	Owner string      `mapstructure:"owner"`
-	Repo  string      `mapstructure:"repo"`
-	Path  string      `mapstructure:"path"`
-	Auth  *GithubAuth `mapstructure:"auth"`
+	Owner string `mapstructure:"owner"`
+	Repo  string `mapstructure:"repo"`
+	Path  string `mapstructure:"path"`
+	// The branch, tag or commit to load from, defaults to the default branch.
+	Ref string `mapstructure:"ref"`
+	// The api url of a GitHub Enterprise Server, e.g. https://github.example.com, defaults to github.com.
+	BaseURL string `mapstructure:"baseUrl"`
+	// Hosts of GitHub Enterprise Servers workspaces may use as baseUrl in db mode, e.g. github.example.com.
+	// The credentials of auth are sent to them.
+	EnterpriseHosts []string `mapstructure:"enterpriseHosts"`
+	// Load the release notes from the GitHub releases of the repository, path and ref are ignored.
+	Releases bool `mapstructure:"releases"`
+	// Glob patterns selecting the files if path is a directory, "**" matches any number of directories.
+	Include []string    `mapstructure:"include"`
+	Exclude []string    `mapstructure:"exclude"`
+	Auth    *GithubAuth `mapstructure:"auth"`
 }
 
 type GitlabConfig struct {
@@ -32,6 +45,11 @@ type GitlabConfig struct {
 	Path    string `mapstructure:"path"`
 	Ref     string `mapstructure:"ref"`
 	Token   string `mapstructure:"token"`
+	// Load the release notes from the GitLab releases of the project, path and ref are ignored.
+	Releases bool `mapstructure:"releases"`
+	// Glob patterns selecting the files if path is a directory, "**" matches any number of directories.
+	Include []string `mapstructure:"include"`
+	Exclude []string `mapstructure:"exclude"`
 }
 
 type ForgejoConfig struct {
@@ -40,12 +58,56 @@ type ForgejoConfig struct {
 	Path    string `mapstructure:"path"`
 	Ref     string `mapstructure:"ref"`
 	Token   string `mapstructure:"token"`
+	// Load the release notes from the Forgejo releases of the project, path and ref are ignored.
+	Releases bool `mapstructure:"releases"`
+	// Glob patterns selecting the files if path is a directory, "**" matches any number of directories.
+	Include []string `mapstructure:"include"`
+	Exclude []string `mapstructure:"exclude"`
+}
+
+type GitConfig struct {
+	// The remote to read the release notes from, https, ssh or file urls are supported.
+	URL  string `mapstructure:"url"`
+	Ref  string `mapstructure:"ref"`
+	Path string `mapstructure:"path"`
+	// Generate the release notes from the tags and their Conventional Commits, path only limits the commits.
+	ConventionalCommits bool `mapstructure:"conventionalCommits"`
+	// Glob patterns selecting the files if path is a directory, "**" matches any number of directories.
+	Include []string `mapstructure:"include"`
+	Exclude []string `mapstructure:"exclude"`
+	// Directory where the mirrors of git sources are kept, defaults to a directory in the os temp dir.
+	MirrorPath string `mapstructure:"mirrorPath"`
+}
+
+type URLConfig struct {
+	// The http(s) url of a markdown file, or of a manifest if manifest is true.
+	URL string `mapstructure:"url"`
+	// The url points to a manifest listing the urls of the release notes, one per line, newest first.
+	// Relative urls are resolved against the manifest url.
+	Manifest bool `mapstructure:"manifest"`
+	// In db mode, url sources can't reach private, loopback or link-local addresses unless this is true.
+	// Only enable it if every workspace is trusted.
+	AllowPrivateAddresses bool `mapstructure:"allowPrivateAddresses"`
+}
+
+type S3Config struct {
+	// The url of the S3 compatible endpoint, e.g. https://s3.eu-central-1.amazonaws.com or http://localhost:9000 for MinIO.
+	Endpoint string `mapstructure:"endpoint"`
+	Region   string `mapstructure:"region"`
+	Bucket   string `mapstructure:"bucket"`
+	// The prefix of the markdown objects, or the key of a single markdown object.
+	Prefix string `mapstructure:"prefix"`
+	// Only used by the s3 source of the config, sources created through the api need their own credentials.
+	AccessKeyID     string `mapstructure:"accessKeyId"`
+	SecretAccessKey string `mapstructure:"secretAccessKey"`
 }
 
 type CacheConfig struct {
 	Type CacheTyp         `mapstructure:"type"`
 	Disk *DiskCacheConfig `mapstructure:"disk"`
 	S3   *S3CacheConfig   `mapstructure:"s3"`
+	// Serve the last successfully loaded release notes if a source fails to load.
+	StaleIfError bool `mapstructure:"staleIfError"`
 }
 
 type DiskCacheConfig struct {
@@ -58,8 +120,11 @@ type S3CacheConfig struct {
 }
 
 type GithubAuth struct {
-	AppID             int64  `mapstructure:"appId"`
-	AppSecret         string `mapstructure:"appSecret"`
+	AppID int64 `mapstructure:"appId"`
+	// The client id and secret of the app, used to verify the installations of the setup flow.
+	AppClientID string `mapstructure:"appClientId"`
+	AppSecret   string `mapstructure:"appSecret"`
+	// Path to the private key file of the app.
 	AppPrivateKey     string `mapstructure:"appPrivateKey"`
 	AppInstallationId int64  `mapstructure:"appInstallationId"`
 	AccessToken       string `mapstructure:"accessToken"`
@@ -67,6 +132,9 @@ type GithubAuth struct {
 
 type LocalConfig struct {
 	FilesPath string `mapstructure:"filesPath"`
+	// Glob patterns selecting the files of the files path, "**" matches any number of directories.
+	Include []string `mapstructure:"include"`
+	Exclude []string `mapstructure:"exclude"`
 }
 
 type LogoConfig struct {
@@ -125,12 +193,54 @@ type SearchConfig struct {
 	} `mapstructure:"disk"`
 }
 
+type RefreshConfig struct {
+	// How often the sources of searchable changelogs are polled for changes, polling is disabled if zero.
+	Interval time.Duration   `mapstructure:"interval"`
+	Webhooks *WebhooksConfig `mapstructure:"webhooks"`
+}
+
+// Secrets used to verify incoming webhooks, the webhook endpoint of a provider is disabled if it's secret is empty.
+type WebhooksConfig struct {
+	GithubSecret  string `mapstructure:"githubSecret"`
+	GitlabToken   string `mapstructure:"gitlabToken"`
+	ForgejoSecret string `mapstructure:"forgejoSecret"`
+}
+
+type EmailConfig struct {
+	// The sender address of all emails.
+	From string `mapstructure:"from"`
+	// Secret used to sign unsubscribe links, subscriptions are disabled if empty.
+	Secret string `mapstructure:"secret"`
+	// The url changelogs are served on, e.g. https://openchangelog.com.
+	// The links in emails prepend the subdomain of the changelog to its host, changelogs with a domain use https://<domain> instead.
+	BaseURL string      `mapstructure:"baseUrl"`
+	SMTP    *SMTPConfig `mapstructure:"smtp"`
+	// If no smtp server is configured, emails are written to this directory instead of being sent.
+	// Emails are only logged if both are empty.
+	Dir string `mapstructure:"dir"`
+}
+
+type PreviewConfig struct {
+	// Secret used to sign preview links of drafts and scheduled release notes, previews are disabled if empty.
+	Secret string `mapstructure:"secret"`
+}
+
+type SMTPConfig struct {
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+}
+
 type Config struct {
 	Addr      string           `mapstructure:"addr"`
 	SqliteURL string           `mapstructure:"sqliteUrl"`
 	Github    *GithubConfig    `mapstructure:"github"`
 	Gitlab    *GitlabConfig    `mapstructure:"gitlab"`
 	Forgejo   *ForgejoConfig   `mapstructure:"forgejo"`
+	Git       *GitConfig       `mapstructure:"git"`
+	URL       *URLConfig       `mapstructure:"url"`
+	S3        *S3Config        `mapstructure:"s3"`
 	Local     *LocalConfig     `mapstructure:"local"`
 	Page      *PageConfig      `mapstructure:"page"`
 	Cache     *CacheConfig     `mapstructure:"cache"`
@@ -138,12 +248,36 @@ type Config struct {
 	Admin     *AdminConfig     `mapstructure:"admin"`
 	Log       *LogConfig       `mapstructure:"log"`
 	Search    *SearchConfig    `mapstructure:"search"`
+	Refresh   *RefreshConfig   `mapstructure:"refresh"`
+	Email     *EmailConfig     `mapstructure:"email"`
+	Preview   *PreviewConfig   `mapstructure:"preview"`
 }
 
 func (c Config) HasGithubAuth() bool {
 	return c.Github != nil && c.Github.Auth != nil
 }
 
+// Returns true if workspaces can install the GitHub App to create sources,
+// requires db mode to store the installations.
+func (c Config) HasGithubApp() bool {
+	if !c.HasGithubAuth() || !c.IsDBMode() {
+		return false
+	}
+	a := c.Github.Auth
+	return a.AppID != 0 && a.AppPrivateKey != "" && a.AppClientID != "" && a.AppSecret != ""
+}
+
+// Returns true if end users can subscribe to changelogs by email,
+// requires db mode to store the subscribers.
+func (c Config) HasSubscriptions() bool {
+	return c.IsDBMode() && c.Email != nil && c.Email.Secret != ""
+}
+
+// Returns true if preview links of drafts and scheduled release notes can be created.
+func (c Config) HasPreviews() bool {
+	return c.Preview != nil && c.Preview.Secret != ""
+}
+
 // Returns true if Openchangelog was started in db mode (using sqlite to store changelog configs, multi tenancy)
 func (c Config) IsDBMode() bool {
 	return c.SqliteURL != ""
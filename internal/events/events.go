@@ -1,6 +1,7 @@
 package events
 
 import (
+	"github.com/jonashiltl/openchangelog/internal/parse"
 	"github.com/jonashiltl/openchangelog/internal/source"
 	"github.com/jonashiltl/openchangelog/internal/store"
 )
@@ -15,3 +16,16 @@ type ChangelogUpdated struct {
 	CL   store.Changelog // the updated changelog
 	Args store.UpdateChangelogArgs
 }
+
+// Fired once the publishedAt of a scheduled release note passes
+type ReleaseNotePublished struct {
+	CL     store.Changelog
+	Source source.Source
+	Note   parse.Meta
+}
+
+// Fired once a webhook of the changelog is created
+type WebhookCreated struct {
+	CL      store.Changelog
+	Webhook store.Webhook
+}
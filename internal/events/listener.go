@@ -2,7 +2,10 @@ package events
 
 import (
 	"context"
+	"errors"
+	"io/fs"
 	"log/slog"
+	"time"
 
 	"github.com/btvoidx/mint"
 	"github.com/jonashiltl/openchangelog/internal"
@@ -18,7 +21,7 @@ type offFunc func() <-chan struct{}
 
 type EventListener struct {
 	e        *mint.Emitter
-	parser   parse.Parser
+	parser   *parse.Cache
 	searcher search.Searcher
 	cache    xcache.Cache
 	cfg      config.Config
@@ -28,7 +31,7 @@ type EventListener struct {
 func NewListener(
 	cfg config.Config,
 	e *mint.Emitter,
-	parser parse.Parser,
+	parser *parse.Cache,
 	searcher search.Searcher,
 	cache xcache.Cache,
 ) *EventListener {
@@ -45,8 +48,9 @@ func NewListener(
 func (l *EventListener) Start() {
 	off1 := mint.On(l.e, l.OnSourceChanged)
 	off2 := mint.On(l.e, l.OnChangelogUpdated)
+	off3 := mint.On(l.e, l.OnReleaseNotePublished)
 	// save all off functions of mint to cleanup later
-	l.offs = append(l.offs, off1, off2)
+	l.offs = append(l.offs, off1, off2, off3)
 }
 
 // Stops listening to all events
@@ -58,6 +62,14 @@ func (l *EventListener) Close() {
 
 func (l *EventListener) OnSourceChanged(e SourceContentChanged) {
 	slog.Debug("source content changed event", slog.String("sid", e.Source.ID().String()))
+	l.parser.Evict(e.Source.ID())
+	if e.CL.Searchable {
+		go l.reindexSource(e.Source)
+	}
+}
+
+func (l *EventListener) OnReleaseNotePublished(e ReleaseNotePublished) {
+	slog.Debug("release note published event", slog.String("cid", e.CL.ID.String()), slog.String("id", e.Note.ID))
 	if e.CL.Searchable {
 		go l.reindexSource(e.Source)
 	}
@@ -65,19 +77,21 @@ func (l *EventListener) OnSourceChanged(e SourceContentChanged) {
 
 func (l *EventListener) OnChangelogUpdated(e ChangelogUpdated) {
 	slog.Debug("changelog updated event", slog.String("cid", e.CL.ID.String()))
-	if e.Args.Searchable != nil && *e.Args.Searchable {
-		souce, err := source.NewSourceFromStore(l.cfg, e.CL, l.cache)
-		if err == nil {
-			go l.reindexSource(souce)
-		} else {
-			slog.Error("failed to create source", xlog.ErrAttr(err))
-		}
-	} else if e.Args.Searchable != nil && !*e.Args.Searchable {
-		souce, err := source.NewSourceFromStore(l.cfg, e.CL, l.cache)
-		if err == nil {
-			go l.removeIndex(souce)
+	if e.Args.Searchable == nil {
+		return
+	}
+
+	// every source of a changelog is indexed with it's own id
+	sources, err := source.NewSourcesFromStore(l.cfg, e.CL, l.cache)
+	if err != nil {
+		slog.Error("failed to create source", xlog.ErrAttr(err))
+		return
+	}
+	for _, s := range sources {
+		if *e.Args.Searchable {
+			go l.reindexSource(s)
 		} else {
-			slog.Error("failed to create source", xlog.ErrAttr(err))
+			go l.removeIndex(s)
 		}
 	}
 }
@@ -90,19 +104,49 @@ func (l *EventListener) reindexSource(source source.Source) {
 	slog.Debug("reindexing content of source", slog.String("sid", source.ID().String()))
 	ctx := context.Background()
 	loaded, err := source.Load(ctx, internal.NoPagination())
+	if errors.Is(err, fs.ErrNotExist) {
+		// the file of a local source was deleted, none of it's release notes exist anymore
+		err = l.searcher.Prune(ctx, search.PruneArgs{SID: source.ID().String()})
+		if err != nil {
+			slog.Error("failed to remove deleted release notes from search index", xlog.ErrAttr(err))
+		}
+		return
+	}
 	if err != nil {
 		slog.Error("failed to load source content for search indexing", xlog.ErrAttr(err))
 		return
 	}
-	parsed := l.parser.Parse(ctx, loaded.Raw, internal.NoPagination())
+	parsed := l.parser.Parse(ctx, source.ID(), loaded.Raw, internal.NoPagination())
+	notes := published(parsed.ReleaseNotes, time.Now())
 	err = l.searcher.BatchIndex(ctx, search.BatchIndexArgs{
 		SID:          source.ID().String(),
-		ReleaseNotes: parsed.ReleaseNotes,
+		ReleaseNotes: notes,
 	})
 	if err != nil {
 		slog.Error("failed to index parsed release notes", xlog.ErrAttr(err))
 		return
 	}
+
+	// release notes that were deleted from the source are still indexed
+	err = l.searcher.Prune(ctx, search.PruneArgs{
+		SID:          source.ID().String(),
+		ReleaseNotes: notes,
+	})
+	if err != nil {
+		slog.Error("failed to remove deleted release notes from search index", xlog.ErrAttr(err))
+	}
+}
+
+// Returns the release notes which aren't drafts or scheduled to be published in the future,
+// scheduled release notes are indexed once a ReleaseNotePublished event is fired.
+func published(notes []parse.ParsedReleaseNote, now time.Time) []parse.ParsedReleaseNote {
+	res := make([]parse.ParsedReleaseNote, 0, len(notes))
+	for _, n := range notes {
+		if n.Meta.Published(now) {
+			res = append(res, n)
+		}
+	}
+	return res
 }
 
 func (l *EventListener) removeIndex(source source.Source) {
@@ -117,7 +161,7 @@ func (l *EventListener) removeIndex(source source.Source) {
 		slog.Error("failed to load source content for search indexing", xlog.ErrAttr(err))
 		return
 	}
-	parsed := l.parser.Parse(ctx, loaded.Raw, internal.NoPagination())
+	parsed := l.parser.Parse(ctx, source.ID(), loaded.Raw, internal.NoPagination())
 	err = l.searcher.BatchRemove(ctx, search.BatchRemoveArgs{
 		SID:          source.ID().String(),
 		ReleaseNotes: parsed.ReleaseNotes,
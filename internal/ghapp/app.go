@@ -0,0 +1,254 @@
+// Package ghapp implements the setup flow of the GitHub App,
+// which lets workspaces install the app on their repositories instead of supplying installation ids by hand.
+package ghapp
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bradleyfalzon/ghinstallation/v2"
+	"github.com/google/go-github/v62/github"
+	"github.com/jonashiltl/openchangelog/internal/config"
+	"github.com/jonashiltl/openchangelog/internal/errs"
+	"github.com/jonashiltl/openchangelog/internal/store"
+)
+
+const (
+	githubAPIURL = "https://api.github.com/"
+	githubWebURL = "https://github.com/"
+	// how long a workspace has to finish the installation
+	stateTTL = time.Hour
+)
+
+var errInvalidState = errs.NewBadRequest(errors.New("invalid or expired github installation state"))
+
+// Creates a new App, returns nil if the GitHub App isn't configured.
+// A nil App is disabled.
+func New(cfg config.Config) (*App, error) {
+	return newApp(cfg, githubAPIURL, githubWebURL)
+}
+
+func newApp(cfg config.Config, apiURL, webURL string) (*App, error) {
+	if !cfg.HasGithubApp() {
+		return nil, nil
+	}
+	auth := cfg.Github.Auth
+	atr, err := ghinstallation.NewAppsTransportKeyFromFile(http.DefaultTransport, auth.AppID, auth.AppPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	atr.BaseURL = strings.TrimSuffix(apiURL, "/")
+
+	return &App{
+		clientID:     auth.AppClientID,
+		clientSecret: auth.AppSecret,
+		atr:          atr,
+		apiURL:       apiURL,
+		webURL:       webURL,
+	}, nil
+}
+
+// The GitHub App of Openchangelog.
+// Workspaces install the app through the url returned by InstallURL, GitHub then redirects to the setup url
+// of the app with the installation id, which is verified with the OAuth code of the installing user.
+type App struct {
+	clientID     string
+	clientSecret string
+	atr          *ghinstallation.AppsTransport
+	apiURL       string
+	webURL       string
+}
+
+// A repository the app is installed on.
+type Repository struct {
+	Owner         string
+	Name          string
+	Private       bool
+	DefaultBranch string
+}
+
+// Returns true if the app is configured.
+func (a *App) Enabled() bool {
+	return a != nil
+}
+
+var errDisabled = errs.NewNotFound(errors.New("the github app is not configured"))
+
+// Returns the url installing the app, GitHub passes the state on to the setup url.
+func (a *App) InstallURL(ctx context.Context, state string) (string, error) {
+	if !a.Enabled() {
+		return "", errDisabled
+	}
+	app, _, err := a.client(a.atr).Apps.Get(ctx, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to get github app: %w", err)
+	}
+	return fmt.Sprintf("%s/installations/new?state=%s", app.GetHTMLURL(), url.QueryEscape(state)), nil
+}
+
+// Returns a signed state, identifying the workspace that installs the app.
+// redirect is the url the workspace is redirected to after the installation, it's optional.
+func (a *App) State(wID store.WorkspaceID, redirect string) (string, error) {
+	if !a.Enabled() {
+		return "", errDisabled
+	}
+	b, err := json.Marshal(state{
+		WorkspaceID: wID.String(),
+		Redirect:    redirect,
+		Expires:     time.Now().Add(stateTTL).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(b)
+	return payload + "." + a.sign(payload), nil
+}
+
+// Returns the workspace and redirect of a state created by State.
+func (a *App) ParseState(s string) (store.WorkspaceID, string, error) {
+	if !a.Enabled() {
+		return "", "", errDisabled
+	}
+	payload, sig, ok := strings.Cut(s, ".")
+	if !ok || !hmac.Equal([]byte(sig), []byte(a.sign(payload))) {
+		return "", "", errInvalidState
+	}
+	b, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return "", "", errInvalidState
+	}
+	var st state
+	err = json.Unmarshal(b, &st)
+	if err != nil || time.Now().Unix() > st.Expires {
+		return "", "", errInvalidState
+	}
+	return store.WorkspaceID(st.WorkspaceID), st.Redirect, nil
+}
+
+type state struct {
+	WorkspaceID string `json:"w"`
+	Redirect    string `json:"r,omitempty"`
+	Expires     int64  `json:"e"`
+}
+
+func (a *App) sign(payload string) string {
+	mac := hmac.New(sha256.New, []byte(a.clientSecret))
+	mac.Write([]byte("state:" + payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verifies that the user who authorized the app with code has access to the installation,
+// so a workspace can't claim the installation of somebody else by changing the installation id of the setup url.
+func (a *App) VerifyInstallation(ctx context.Context, code string, installationID int64) (store.GHInstallation, error) {
+	if !a.Enabled() {
+		return store.GHInstallation{}, errDisabled
+	}
+	if code == "" {
+		return store.GHInstallation{}, errs.NewBadRequest(errors.New("missing code, enable \"Request user authorization (OAuth) during installation\" for the github app"))
+	}
+	token, err := a.exchangeCode(ctx, code)
+	if err != nil {
+		return store.GHInstallation{}, err
+	}
+
+	client := a.client(http.DefaultTransport).WithAuthToken(token)
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		installations, resp, err := client.Apps.ListUserInstallations(ctx, opts)
+		if err != nil {
+			return store.GHInstallation{}, fmt.Errorf("failed to list installations of user: %w", err)
+		}
+		i := slices.IndexFunc(installations, func(i *github.Installation) bool {
+			return i.GetID() == installationID
+		})
+		if i >= 0 {
+			return store.GHInstallation{
+				ID:      installationID,
+				Account: installations[i].GetAccount().GetLogin(),
+			}, nil
+		}
+		if resp.NextPage == 0 {
+			return store.GHInstallation{}, errs.NewUnauthorized(errors.New("github installation not accessible by the user"))
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// Exchanges the OAuth code of a user for a user access token.
+func (a *App) exchangeCode(ctx context.Context, code string) (string, error) {
+	form := url.Values{
+		"client_id":     {a.clientID},
+		"client_secret": {a.clientSecret},
+		"code":          {code},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.webURL+"login/oauth/access_token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var res struct {
+		AccessToken string `json:"access_token"`
+		Error       string `json:"error"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&res)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode github token response: %w", err)
+	}
+	if res.AccessToken == "" {
+		return "", errs.NewUnauthorized(fmt.Errorf("failed to verify github user: %s", res.Error))
+	}
+	return res.AccessToken, nil
+}
+
+// Lists the repositories the installation has access to.
+func (a *App) ListRepos(ctx context.Context, installationID int64) ([]Repository, error) {
+	if !a.Enabled() {
+		return nil, errDisabled
+	}
+	client := a.client(ghinstallation.NewFromAppsTransport(a.atr, installationID))
+	opts := &github.ListOptions{PerPage: 100}
+	repos := make([]Repository, 0)
+	for {
+		res, resp, err := client.Apps.ListRepos(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list repositories of installation: %w", err)
+		}
+		for _, r := range res.Repositories {
+			repos = append(repos, Repository{
+				Owner:         r.GetOwner().GetLogin(),
+				Name:          r.GetName(),
+				Private:       r.GetPrivate(),
+				DefaultBranch: r.GetDefaultBranch(),
+			})
+		}
+		if resp.NextPage == 0 {
+			return repos, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func (a *App) client(tr http.RoundTripper) *github.Client {
+	c := github.NewClient(&http.Client{Transport: tr})
+	c.BaseURL, _ = url.Parse(a.apiURL)
+	return c
+}
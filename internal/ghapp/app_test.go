@@ -0,0 +1,182 @@
+package ghapp
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/internal/config"
+	"github.com/jonashiltl/openchangelog/internal/store"
+)
+
+func writePrivateKey(t *testing.T) string {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "app.pem")
+	b := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	err = os.WriteFile(path, b, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func newTestApp(t *testing.T) *App {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /app", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"html_url": "https://github.com/apps/openchangelog"})
+	})
+	mux.HandleFunc("POST /login/oauth/access_token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("code") != "valid" || r.Form.Get("client_secret") != "secret" {
+			json.NewEncoder(w).Encode(map[string]any{"error": "bad_verification_code"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"access_token": "user-token"})
+	})
+	mux.HandleFunc("GET /user/installations", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer user-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"total_count":   1,
+			"installations": []map[string]any{{"id": 42, "account": map[string]any{"login": "acme"}}},
+		})
+	})
+	mux.HandleFunc("POST /app/installations/42/access_tokens", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]any{"token": "installation-token", "expires_at": time.Now().Add(time.Hour)})
+	})
+	mux.HandleFunc("GET /installation/repositories", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token installation-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"total_count": 1,
+			"repositories": []map[string]any{{
+				"name":           "app",
+				"private":        true,
+				"default_branch": "main",
+				"owner":          map[string]any{"login": "acme"},
+			}},
+		})
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	app, err := newApp(config.Config{
+		SqliteURL: "file:test.db",
+		Github: &config.GithubConfig{
+			Auth: &config.GithubAuth{
+				AppID:         1,
+				AppClientID:   "client",
+				AppSecret:     "secret",
+				AppPrivateKey: writePrivateKey(t),
+			},
+		},
+	}, srv.URL+"/", srv.URL+"/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return app
+}
+
+func TestNewDisabled(t *testing.T) {
+	app, err := New(config.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if app.Enabled() {
+		t.Error("expected app without config to be disabled")
+	}
+	if _, err := app.State("ws_1", ""); err == nil {
+		t.Error("expected error for disabled app")
+	}
+}
+
+func TestState(t *testing.T) {
+	app := &App{clientSecret: "secret"}
+
+	s, err := app.State("ws_1", "https://example.com/done")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wID, redirect, err := app.ParseState(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wID != store.WorkspaceID("ws_1") || redirect != "https://example.com/done" {
+		t.Errorf("unexpected state %s %s", wID, redirect)
+	}
+
+	other, _ := app.State("ws_2", "")
+	payload, _, _ := strings.Cut(other, ".")
+	_, sig, _ := strings.Cut(s, ".")
+	if _, _, err := app.ParseState(payload + "." + sig); err == nil {
+		t.Error("expected tampered state to be rejected")
+	}
+	if _, _, err := (&App{clientSecret: "other"}).ParseState(s); err == nil {
+		t.Error("expected state signed with another secret to be rejected")
+	}
+}
+
+func TestInstallURL(t *testing.T) {
+	app := newTestApp(t)
+	u, err := app.InstallURL(context.Background(), "abc.def")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "https://github.com/apps/openchangelog/installations/new?state=abc.def"
+	if u != expected {
+		t.Errorf("expected %s but got %s", expected, u)
+	}
+}
+
+func TestVerifyInstallation(t *testing.T) {
+	app := newTestApp(t)
+	ctx := context.Background()
+
+	installation, err := app.VerifyInstallation(ctx, "valid", 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if installation.ID != 42 || installation.Account != "acme" {
+		t.Errorf("unexpected installation %+v", installation)
+	}
+
+	if _, err := app.VerifyInstallation(ctx, "valid", 43); err == nil {
+		t.Error("expected installation the user can't access to be rejected")
+	}
+	if _, err := app.VerifyInstallation(ctx, "invalid", 42); err == nil {
+		t.Error("expected invalid code to be rejected")
+	}
+	if _, err := app.VerifyInstallation(ctx, "", 42); err == nil {
+		t.Error("expected missing code to be rejected")
+	}
+}
+
+func TestListRepos(t *testing.T) {
+	app := newTestApp(t)
+	repos, err := app.ListRepos(context.Background(), 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Repository{Owner: "acme", Name: "app", Private: true, DefaultBranch: "main"}
+	if len(repos) != 1 || repos[0] != expected {
+		t.Errorf("expected %+v but got %+v", expected, repos)
+	}
+}
@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,6 +14,7 @@ import (
 	"github.com/jonashiltl/openchangelog/internal/errs"
 	"github.com/jonashiltl/openchangelog/internal/events"
 	"github.com/jonashiltl/openchangelog/internal/handler"
+	"github.com/jonashiltl/openchangelog/internal/load"
 	"github.com/jonashiltl/openchangelog/internal/store"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -45,8 +47,40 @@ func changelogToApiType(cl store.Changelog) apitypes.Changelog {
 		Searchable:    cl.Searchable,
 	}
 
-	if cl.GHSource.Valid {
+	switch {
+	case cl.GHSource.Valid:
 		c.Source = ghToApiType(cl.GHSource.ValueOrZero())
+	case cl.GLSource.Valid:
+		c.Source = glToApiType(cl.GLSource.ValueOrZero())
+	case cl.FJSource.Valid:
+		c.Source = fjToApiType(cl.FJSource.ValueOrZero())
+	case cl.LocalSource.Valid:
+		c.Source = localToApiType(cl.LocalSource.ValueOrZero())
+	case cl.GitSource.Valid:
+		c.Source = gitToApiType(cl.GitSource.ValueOrZero())
+	case cl.URLSource.Valid:
+		c.Source = urlToApiType(cl.URLSource.ValueOrZero())
+	case cl.S3Source.Valid:
+		c.Source = s3ToApiType(cl.S3Source.ValueOrZero())
+	}
+
+	for _, cs := range cl.Sources {
+		switch {
+		case cs.GHSource.Valid:
+			c.Sources = append(c.Sources, ghToApiType(cs.GHSource.ValueOrZero()))
+		case cs.GLSource.Valid:
+			c.Sources = append(c.Sources, glToApiType(cs.GLSource.ValueOrZero()))
+		case cs.FJSource.Valid:
+			c.Sources = append(c.Sources, fjToApiType(cs.FJSource.ValueOrZero()))
+		case cs.LocalSource.Valid:
+			c.Sources = append(c.Sources, localToApiType(cs.LocalSource.ValueOrZero()))
+		case cs.GitSource.Valid:
+			c.Sources = append(c.Sources, gitToApiType(cs.GitSource.ValueOrZero()))
+		case cs.URLSource.Valid:
+			c.Sources = append(c.Sources, urlToApiType(cs.URLSource.ValueOrZero()))
+		case cs.S3Source.Valid:
+			c.Sources = append(c.Sources, s3ToApiType(cs.S3Source.ValueOrZero()))
+		}
 	}
 	return c
 }
@@ -195,22 +229,157 @@ func setChangelogSource(e *env, w http.ResponseWriter, r *http.Request) error {
 		return errs.NewError(errs.ErrBadRequest, errors.New("missing sid path param"))
 	}
 
-	if store.IsGHID(sId) {
+	switch {
+	case store.IsGHID(sId):
 		ghID, err := store.ParseGHID(sId)
 		if err != nil {
 			return err
 		}
-		err = e.store.SetChangelogGHSource(r.Context(), t.WorkspaceID, cId, ghID)
+		return e.store.SetChangelogGHSource(r.Context(), t.WorkspaceID, cId, ghID)
+	case store.IsGLID(sId):
+		glID, err := store.ParseGLID(sId)
 		if err != nil {
 			return err
 		}
-	} else {
-		return errs.NewError(errs.ErrBadRequest, fmt.Errorf("invalid source id: %s", sId))
+		return e.store.SetChangelogGLSource(r.Context(), t.WorkspaceID, cId, glID)
+	case store.IsFJID(sId):
+		fjID, err := store.ParseFJID(sId)
+		if err != nil {
+			return err
+		}
+		return e.store.SetChangelogFJSource(r.Context(), t.WorkspaceID, cId, fjID)
+	case store.IsLocalID(sId):
+		lcID, err := store.ParseLocalID(sId)
+		if err != nil {
+			return err
+		}
+		return e.store.SetChangelogLocalSource(r.Context(), t.WorkspaceID, cId, lcID)
+	case store.IsGitID(sId):
+		gitID, err := store.ParseGitID(sId)
+		if err != nil {
+			return err
+		}
+		return e.store.SetChangelogGitSource(r.Context(), t.WorkspaceID, cId, gitID)
+	case store.IsURLID(sId):
+		uID, err := store.ParseURLID(sId)
+		if err != nil {
+			return err
+		}
+		return e.store.SetChangelogURLSource(r.Context(), t.WorkspaceID, cId, uID)
+	case store.IsS3ID(sId):
+		s3ID, err := store.ParseS3ID(sId)
+		if err != nil {
+			return err
+		}
+		return e.store.SetChangelogS3Source(r.Context(), t.WorkspaceID, cId, s3ID)
+	}
+	return errs.NewError(errs.ErrBadRequest, fmt.Errorf("invalid source id: %s", sId))
+}
+
+func setChangelogSources(e *env, _ http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+
+	cId, err := store.ParseCID(r.PathValue(changelog_id_param))
+	if err != nil {
+		return err
+	}
+
+	var req apitypes.SetChangelogSourcesBody
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return errs.NewBadRequest(err)
 	}
 
+	if len(req.SourceIDs) == 0 {
+		return errs.NewBadRequest(errors.New("at least one source id is required"))
+	}
+	for _, sId := range req.SourceIDs {
+		err = getWorkspaceSource(r.Context(), e, t.WorkspaceID, sId)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = e.store.SetChangelogSources(r.Context(), t.WorkspaceID, cId, req.SourceIDs)
+	if err != nil {
+		return err
+	}
+
+	cl, err := e.store.GetChangelog(r.Context(), t.WorkspaceID, cId)
+	if err != nil {
+		return err
+	}
+	args := store.UpdateChangelogArgs{}
+	if cl.Searchable {
+		// every source is indexed with it's own id, so the added sources need to be indexed
+		args.Searchable = &cl.Searchable
+	}
+	mint.Emit(e.e, events.ChangelogUpdated{
+		CL:   cl,
+		Args: args,
+	})
 	return nil
 }
 
+// Returns an error if the source doesn't exist in the workspace, so changelogs can't reference sources of other workspaces.
+func getWorkspaceSource(ctx context.Context, e *env, wID store.WorkspaceID, sId string) error {
+	switch {
+	case store.IsGHID(sId):
+		ghID, err := store.ParseGHID(sId)
+		if err != nil {
+			return err
+		}
+		_, err = e.store.GetGHSource(ctx, wID, ghID)
+		return err
+	case store.IsGLID(sId):
+		glID, err := store.ParseGLID(sId)
+		if err != nil {
+			return err
+		}
+		_, err = e.store.GetGLSource(ctx, wID, glID)
+		return err
+	case store.IsFJID(sId):
+		fjID, err := store.ParseFJID(sId)
+		if err != nil {
+			return err
+		}
+		_, err = e.store.GetFJSource(ctx, wID, fjID)
+		return err
+	case store.IsLocalID(sId):
+		lcID, err := store.ParseLocalID(sId)
+		if err != nil {
+			return err
+		}
+		_, err = e.store.GetLocalSource(ctx, wID, lcID)
+		return err
+	case store.IsGitID(sId):
+		gitID, err := store.ParseGitID(sId)
+		if err != nil {
+			return err
+		}
+		_, err = e.store.GetGitSource(ctx, wID, gitID)
+		return err
+	case store.IsURLID(sId):
+		uID, err := store.ParseURLID(sId)
+		if err != nil {
+			return err
+		}
+		_, err = e.store.GetURLSource(ctx, wID, uID)
+		return err
+	case store.IsS3ID(sId):
+		s3ID, err := store.ParseS3ID(sId)
+		if err != nil {
+			return err
+		}
+		_, err = e.store.GetS3Source(ctx, wID, s3ID)
+		return err
+	}
+	return errs.NewBadRequest(fmt.Errorf("invalid source id: %s", sId))
+}
+
 func deleteChangelogSource(e *env, _ http.ResponseWriter, r *http.Request) error {
 	t, err := bearerAuth(e, r)
 	if err != nil {
@@ -277,17 +446,83 @@ func getFullChangelog(e *env, w http.ResponseWriter, r *http.Request) error {
 				Description: a.Meta.Description,
 				PublishedAt: a.Meta.PublishedAt,
 				Tags:        a.Meta.Tags,
+				Version:     a.Meta.Version,
+				Slug:        a.Meta.Slug,
+				Authors:     a.Meta.Authors,
+				Image:       a.Meta.Image,
+				Draft:       a.Meta.Draft,
+				Pinned:      a.Meta.Pinned,
 				HTMLContent: string(content),
 			}
 		}
 		res.Articles = articles
 		res.HasMoreArticles = loaded.HasMore
+		handler.SetStaleHeader(w, e.loader.StaleSince(cl))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(res)
+}
+
+func getChangelogHealth(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+
+	cID, err := store.ParseCID(r.PathValue(changelog_id_param))
+	if err != nil {
+		return errs.NewBadRequest(err)
+	}
+
+	cl, err := e.store.GetChangelog(r.Context(), t.WorkspaceID, cID)
+	if err != nil {
+		return errs.NewBadRequest(err)
+	}
+
+	// the sources are loaded in the same order as they are returned by the api
+	c := changelogToApiType(cl)
+	sources := c.Sources
+	if len(sources) == 0 && c.Source != nil {
+		sources = []apitypes.Source{c.Source}
+	}
+
+	res := make([]apitypes.SourceHealth, 0, len(sources))
+	if len(sources) > 0 {
+		health, err := e.loader.CheckHealth(r.Context(), cl)
+		if err != nil {
+			return err
+		}
+		for i, h := range health {
+			res = append(res, sourceHealthToApiType(sources[i], h))
+		}
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	return json.NewEncoder(w).Encode(res)
 }
 
+func sourceHealthToApiType(s apitypes.Source, h load.SourceHealth) apitypes.SourceHealth {
+	res := apitypes.SourceHealth{
+		Source:       s,
+		Healthy:      h.Healthy(),
+		ReleaseNotes: h.ReleaseNotes,
+	}
+	if h.Err != nil {
+		res.Error = h.Err.Error()
+	}
+	if !h.StaleSince.IsZero() {
+		res.StaleSince = &h.StaleSince
+	}
+	for _, f := range h.Errors {
+		res.Files = append(res.Files, apitypes.FileError{
+			Path:  f.Path,
+			Error: f.Err.Error(),
+		})
+	}
+	return res
+}
+
 func listChangelogs(e *env, w http.ResponseWriter, r *http.Request) error {
 	t, err := bearerAuth(e, r)
 	if err != nil {
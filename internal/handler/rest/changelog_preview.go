@@ -0,0 +1,69 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/apitypes"
+	"github.com/jonashiltl/openchangelog/internal/errs"
+	"github.com/jonashiltl/openchangelog/internal/preview"
+	"github.com/jonashiltl/openchangelog/internal/store"
+)
+
+const release_note_id_param = "nid"
+
+// Creates a signed link to preview a release note of the changelog, including drafts and scheduled release notes.
+func createPreview(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+	if !e.cfg.HasPreviews() {
+		return errs.NewBadRequest(errors.New("previews are not enabled, configure 'preview.secret'"))
+	}
+
+	cID, err := store.ParseCID(r.PathValue(changelog_id_param))
+	if err != nil {
+		return errs.NewBadRequest(err)
+	}
+
+	var req apitypes.CreatePreviewBody
+	// the body is optional
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return errs.NewBadRequest(err)
+	}
+	ttl, err := preview.ParseTTL(req.ExpiresIn)
+	if err != nil {
+		return err
+	}
+
+	cl, err := e.store.GetChangelog(r.Context(), t.WorkspaceID, cID)
+	if err != nil {
+		return err
+	}
+
+	nID := r.PathValue(release_note_id_param)
+	// make sure the release note exists
+	_, err = e.loader.LoadPreview(r.Context(), cl, nID)
+	if err != nil {
+		return err
+	}
+
+	expiresAt := time.Now().Add(ttl).Truncate(time.Second)
+	res := apitypes.Preview{
+		ReleaseNoteID: nID,
+		Path:          preview.Path(e.cfg.Preview.Secret, cl.ID, nID, expiresAt),
+		ExpiresAt:     expiresAt,
+	}
+	if cl.Domain.String() != "" {
+		res.URL = fmt.Sprintf("https://%s%s", cl.Domain.String(), res.Path)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(res)
+}
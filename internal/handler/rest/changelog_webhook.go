@@ -0,0 +1,196 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/btvoidx/mint"
+	"github.com/jonashiltl/openchangelog/apitypes"
+	"github.com/jonashiltl/openchangelog/internal/errs"
+	"github.com/jonashiltl/openchangelog/internal/events"
+	"github.com/jonashiltl/openchangelog/internal/store"
+	"github.com/jonashiltl/openchangelog/internal/webhook"
+)
+
+const (
+	webhook_id_param = "whid"
+	// number of deliveries returned by the delivery log
+	delivery_log_size = 100
+)
+
+func webhookToApiType(wh store.Webhook) apitypes.Webhook {
+	return apitypes.Webhook{
+		ID:          wh.ID.String(),
+		WorkspaceID: wh.WorkspaceID.String(),
+		ChangelogID: wh.ChangelogID.String(),
+		URL:         wh.URL,
+		CreatedAt:   wh.CreatedAt,
+	}
+}
+
+func deliveryToApiType(d store.WebhookDelivery) apitypes.WebhookDelivery {
+	return apitypes.WebhookDelivery{
+		ID:            d.ID.String(),
+		WebhookID:     d.WebhookID.String(),
+		ReleaseNoteID: d.ReleaseNoteID,
+		Event:         d.Event,
+		Attempt:       d.Attempt,
+		StatusCode:    d.StatusCode,
+		Success:       d.Success(),
+		Error:         d.Error,
+		CreatedAt:     d.CreatedAt,
+	}
+}
+
+func createWebhook(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+
+	cID, err := store.ParseCID(r.PathValue(changelog_id_param))
+	if err != nil {
+		return err
+	}
+
+	var req apitypes.CreateWebhookBody
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return errs.NewBadRequest(err)
+	}
+
+	err = webhook.ValidateURL(req.URL)
+	if err != nil {
+		return err
+	}
+
+	// make sure the changelog belongs to the workspace
+	cl, err := e.store.GetChangelog(r.Context(), t.WorkspaceID, cID)
+	if err != nil {
+		return err
+	}
+
+	secret, err := webhook.NewSecret()
+	if err != nil {
+		return err
+	}
+
+	wh, err := e.store.CreateWebhook(r.Context(), store.Webhook{
+		ID:          store.NewWebhookID(),
+		WorkspaceID: t.WorkspaceID,
+		ChangelogID: cID,
+		URL:         req.URL,
+		Secret:      secret,
+	})
+	if err != nil {
+		return err
+	}
+	// the existing release notes are recorded, so they aren't delivered
+	mint.Emit(e.e, events.WebhookCreated{CL: cl, Webhook: wh})
+
+	res := webhookToApiType(wh)
+	// the secret is only returned once
+	res.Secret = wh.Secret
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(res)
+}
+
+func listWebhooks(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+
+	cID, err := store.ParseCID(r.PathValue(changelog_id_param))
+	if err != nil {
+		return err
+	}
+
+	whs, err := e.store.ListWebhooks(r.Context(), t.WorkspaceID, cID)
+	if err != nil {
+		return err
+	}
+
+	res := make([]apitypes.Webhook, len(whs))
+	for i, wh := range whs {
+		res[i] = webhookToApiType(wh)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(res)
+}
+
+// Returns the webhook of the request path, if it belongs to the changelog of the path.
+func getChangelogWebhook(e *env, r *http.Request, wID store.WorkspaceID) (store.Webhook, error) {
+	cID, err := store.ParseCID(r.PathValue(changelog_id_param))
+	if err != nil {
+		return store.Webhook{}, err
+	}
+
+	whID, err := store.ParseWebhookID(r.PathValue(webhook_id_param))
+	if err != nil {
+		return store.Webhook{}, err
+	}
+
+	wh, err := e.store.GetWebhook(r.Context(), wID, whID)
+	if err != nil {
+		return store.Webhook{}, err
+	}
+	if wh.ChangelogID != cID {
+		return store.Webhook{}, errs.NewNotFound(errors.New("webhook not found"))
+	}
+	return wh, nil
+}
+
+func getWebhook(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+
+	wh, err := getChangelogWebhook(e, r, t.WorkspaceID)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(webhookToApiType(wh))
+}
+
+func deleteWebhook(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+
+	wh, err := getChangelogWebhook(e, r, t.WorkspaceID)
+	if err != nil {
+		return err
+	}
+
+	return e.store.DeleteWebhook(r.Context(), t.WorkspaceID, wh.ID)
+}
+
+func listWebhookDeliveries(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+
+	wh, err := getChangelogWebhook(e, r, t.WorkspaceID)
+	if err != nil {
+		return err
+	}
+
+	deliveries, err := e.store.ListWebhookDeliveries(r.Context(), wh.ID, delivery_log_size)
+	if err != nil {
+		return err
+	}
+
+	res := make([]apitypes.WebhookDelivery, len(deliveries))
+	for i, d := range deliveries {
+		res[i] = deliveryToApiType(d)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(res)
+}
@@ -0,0 +1,206 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jonashiltl/openchangelog/apitypes"
+	"github.com/jonashiltl/openchangelog/internal/errs"
+	"github.com/jonashiltl/openchangelog/internal/ghapp"
+	"github.com/jonashiltl/openchangelog/internal/source"
+	"github.com/jonashiltl/openchangelog/internal/store"
+)
+
+const installation_id_param = "iid"
+
+func ghInstallationToApiType(i store.GHInstallation) apitypes.GHInstallation {
+	return apitypes.GHInstallation{
+		ID:        i.ID,
+		Account:   i.Account,
+		CreatedAt: i.CreatedAt,
+	}
+}
+
+func parseInstallationID(s string) (int64, error) {
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, errs.NewBadRequest(errors.New("invalid installation id"))
+	}
+	return id, nil
+}
+
+// Returns the url installing the GitHub App for the workspace.
+// The optional redirect query parameter is the url the workspace is redirected to after the installation.
+func getGHInstallURL(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+
+	redirect := r.URL.Query().Get("redirect")
+	if redirect != "" {
+		err = source.ValidateURL(redirect)
+		if err != nil {
+			return errs.NewBadRequest(err)
+		}
+	}
+
+	state, err := e.ghApp.State(t.WorkspaceID, redirect)
+	if err != nil {
+		return err
+	}
+	u, err := e.ghApp.InstallURL(r.Context(), state)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(apitypes.GHInstallURL{URL: u})
+}
+
+// The setup url of the GitHub App, GitHub redirects here after the app was installed.
+// Isn't authenticated by a bearer token, the workspace is identified by the signed state.
+func setupGHInstallation(e *env, w http.ResponseWriter, r *http.Request) error {
+	q := r.URL.Query()
+	wID, redirect, err := e.ghApp.ParseState(q.Get("state"))
+	if err != nil {
+		return err
+	}
+	installationID, err := parseInstallationID(q.Get("installation_id"))
+	if err != nil {
+		return err
+	}
+
+	installation, err := e.ghApp.VerifyInstallation(r.Context(), q.Get("code"), installationID)
+	if err != nil {
+		return err
+	}
+	installation.WorkspaceID = wID
+	installation, err = e.store.SaveGHInstallation(r.Context(), installation)
+	if err != nil {
+		return err
+	}
+
+	if redirect != "" {
+		u, err := url.Parse(redirect)
+		if err != nil {
+			return err
+		}
+		rq := u.Query()
+		rq.Set("installation_id", strconv.FormatInt(installation.ID, 10))
+		u.RawQuery = rq.Encode()
+		http.Redirect(w, r, u.String(), http.StatusFound)
+		return nil
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(ghInstallationToApiType(installation))
+}
+
+func listGHInstallations(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+
+	installations, err := e.store.ListGHInstallations(r.Context(), t.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	res := make([]apitypes.GHInstallation, len(installations))
+	for i, installation := range installations {
+		res[i] = ghInstallationToApiType(installation)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(res)
+}
+
+// Returns the installation of the workspace in the path.
+func workspaceGHInstallation(e *env, r *http.Request) (Token, store.GHInstallation, error) {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return Token{}, store.GHInstallation{}, err
+	}
+	id, err := parseInstallationID(r.PathValue(installation_id_param))
+	if err != nil {
+		return Token{}, store.GHInstallation{}, err
+	}
+	installation, err := e.store.GetGHInstallation(r.Context(), t.WorkspaceID, id)
+	if err != nil {
+		return Token{}, store.GHInstallation{}, err
+	}
+	return t, installation, nil
+}
+
+func listGHInstallationRepos(e *env, w http.ResponseWriter, r *http.Request) error {
+	_, installation, err := workspaceGHInstallation(e, r)
+	if err != nil {
+		return err
+	}
+
+	repos, err := e.ghApp.ListRepos(r.Context(), installation.ID)
+	if err != nil {
+		return err
+	}
+	res := make([]apitypes.GHRepository, len(repos))
+	for i, repo := range repos {
+		res[i] = apitypes.GHRepository{
+			Owner:         repo.Owner,
+			Name:          repo.Name,
+			Private:       repo.Private,
+			DefaultBranch: repo.DefaultBranch,
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(res)
+}
+
+func createGHInstallationSource(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, installation, err := workspaceGHInstallation(e, r)
+	if err != nil {
+		return err
+	}
+
+	var req apitypes.CreateGHInstallationSourceBody
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return err
+	}
+	err = validatePatterns(req.Include, req.Exclude)
+	if err != nil {
+		return err
+	}
+
+	repos, err := e.ghApp.ListRepos(r.Context(), installation.ID)
+	if err != nil {
+		return err
+	}
+	found := slices.ContainsFunc(repos, func(repo ghapp.Repository) bool {
+		return strings.EqualFold(repo.Owner, req.Owner) && strings.EqualFold(repo.Name, req.Repo)
+	})
+	if !found {
+		return errs.NewBadRequest(errors.New("the github app isn't installed on the repository"))
+	}
+
+	gh, err := saveGHSource(e, r, store.GHSource{
+		WorkspaceID:    t.WorkspaceID,
+		ID:             store.NewGHID(),
+		Owner:          req.Owner,
+		Repo:           req.Repo,
+		Path:           req.Path,
+		Ref:            req.Ref,
+		InstallationID: installation.ID,
+		Releases:       req.Releases,
+		Include:        req.Include,
+		Exclude:        req.Exclude,
+	})
+	if err != nil {
+		return err
+	}
+	return encodeGHSource(w, gh)
+}
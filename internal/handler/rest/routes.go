@@ -6,7 +6,9 @@ import (
 	"net/http"
 
 	"github.com/btvoidx/mint"
+	"github.com/jonashiltl/openchangelog/internal/config"
 	"github.com/jonashiltl/openchangelog/internal/errs"
+	"github.com/jonashiltl/openchangelog/internal/ghapp"
 	"github.com/jonashiltl/openchangelog/internal/load"
 	"github.com/jonashiltl/openchangelog/internal/parse"
 	"github.com/jonashiltl/openchangelog/internal/store"
@@ -30,31 +32,89 @@ func RegisterRestHandler(mux *http.ServeMux, e *env) {
 	mux.HandleFunc("GET /api/sources/gh/{id}", serveHTTP(e, getGHSource))
 	mux.HandleFunc("DELETE /api/sources/gh/{id}", serveHTTP(e, deleteGHSources))
 
+	// GL sources
+	mux.HandleFunc("POST /api/sources/gl", serveHTTP(e, createGLSource))
+	mux.HandleFunc("GET /api/sources/gl", serveHTTP(e, listGLSources))
+	mux.HandleFunc("GET /api/sources/gl/{id}", serveHTTP(e, getGLSource))
+	mux.HandleFunc("DELETE /api/sources/gl/{id}", serveHTTP(e, deleteGLSource))
+
+	// FJ sources
+	mux.HandleFunc("POST /api/sources/fj", serveHTTP(e, createFJSource))
+	mux.HandleFunc("GET /api/sources/fj", serveHTTP(e, listFJSources))
+	mux.HandleFunc("GET /api/sources/fj/{id}", serveHTTP(e, getFJSource))
+	mux.HandleFunc("DELETE /api/sources/fj/{id}", serveHTTP(e, deleteFJSource))
+
+	// Local sources
+	mux.HandleFunc("POST /api/sources/local", serveHTTP(e, createLocalSource))
+	mux.HandleFunc("GET /api/sources/local", serveHTTP(e, listLocalSources))
+	mux.HandleFunc("GET /api/sources/local/{id}", serveHTTP(e, getLocalSource))
+	mux.HandleFunc("DELETE /api/sources/local/{id}", serveHTTP(e, deleteLocalSource))
+
+	// Git sources
+	mux.HandleFunc("POST /api/sources/git", serveHTTP(e, createGitSource))
+	mux.HandleFunc("GET /api/sources/git", serveHTTP(e, listGitSources))
+	mux.HandleFunc("GET /api/sources/git/{id}", serveHTTP(e, getGitSource))
+	mux.HandleFunc("DELETE /api/sources/git/{id}", serveHTTP(e, deleteGitSource))
+	mux.HandleFunc("POST /api/sources/url", serveHTTP(e, createURLSource))
+	mux.HandleFunc("GET /api/sources/url", serveHTTP(e, listURLSources))
+	mux.HandleFunc("GET /api/sources/url/{id}", serveHTTP(e, getURLSource))
+	mux.HandleFunc("DELETE /api/sources/url/{id}", serveHTTP(e, deleteURLSource))
+	mux.HandleFunc("POST /api/sources/s3", serveHTTP(e, createS3Source))
+	mux.HandleFunc("GET /api/sources/s3", serveHTTP(e, listS3Sources))
+	mux.HandleFunc("GET /api/sources/s3/{id}", serveHTTP(e, getS3Source))
+	mux.HandleFunc("DELETE /api/sources/s3/{id}", serveHTTP(e, deleteS3Source))
+
+	// github app
+	mux.HandleFunc("GET /api/github/install", serveHTTP(e, getGHInstallURL))
+	mux.HandleFunc("GET /api/github/setup", serveHTTP(e, setupGHInstallation))
+	mux.HandleFunc("GET /api/github/installations", serveHTTP(e, listGHInstallations))
+	mux.HandleFunc("GET /api/github/installations/{iid}/repos", serveHTTP(e, listGHInstallationRepos))
+	mux.HandleFunc("POST /api/github/installations/{iid}/sources", serveHTTP(e, createGHInstallationSource))
+
 	// changelog
 	mux.HandleFunc("POST /api/changelogs", serveHTTP(e, createChangelog))
 	mux.HandleFunc("GET /api/changelogs", serveHTTP(e, listChangelogs))
 	mux.HandleFunc("GET /api/changelogs/{cid}", serveHTTP(e, getChangelog))
 	mux.HandleFunc("GET /api/changelogs/{cid}/full", serveHTTP(e, getFullChangelog))
+	mux.HandleFunc("GET /api/changelogs/{cid}/health", serveHTTP(e, getChangelogHealth))
 	mux.HandleFunc("PATCH /api/changelogs/{cid}", serveHTTP(e, updateChangelog))
 	mux.HandleFunc("DELETE /api/changelogs/{cid}", serveHTTP(e, deleteChangelog))
 	mux.HandleFunc("PUT /api/changelogs/{cid}/source/{sid}", serveHTTP(e, setChangelogSource))
+	mux.HandleFunc("PUT /api/changelogs/{cid}/sources", serveHTTP(e, setChangelogSources))
 	mux.HandleFunc("DELETE /api/changelogs/{cid}/source", serveHTTP(e, deleteChangelogSource))
+	mux.HandleFunc("POST /api/changelogs/{cid}/releases/{nid}/preview", serveHTTP(e, createPreview))
+
+	// changelog webhooks
+	mux.HandleFunc("POST /api/changelogs/{cid}/webhooks", serveHTTP(e, createWebhook))
+	mux.HandleFunc("GET /api/changelogs/{cid}/webhooks", serveHTTP(e, listWebhooks))
+	mux.HandleFunc("GET /api/changelogs/{cid}/webhooks/{whid}", serveHTTP(e, getWebhook))
+	mux.HandleFunc("DELETE /api/changelogs/{cid}/webhooks/{whid}", serveHTTP(e, deleteWebhook))
+	mux.HandleFunc("GET /api/changelogs/{cid}/webhooks/{whid}/deliveries", serveHTTP(e, listWebhookDeliveries))
+
+	// webhooks
+	mux.HandleFunc("POST /api/webhooks/github", serveHTTP(e, githubWebhook))
+	mux.HandleFunc("POST /api/webhooks/gitlab", serveHTTP(e, gitlabWebhook))
+	mux.HandleFunc("POST /api/webhooks/forgejo", serveHTTP(e, forgejoWebhook))
 }
 
-func NewEnv(store store.Store, loader *load.Loader, parser parse.Parser, e *mint.Emitter) *env {
+func NewEnv(cfg config.Config, store store.Store, loader *load.Loader, parser parse.Parser, e *mint.Emitter, ghApp *ghapp.App) *env {
 	return &env{
+		cfg:    cfg,
 		store:  store,
 		loader: loader,
 		parser: parser,
 		e:      e,
+		ghApp:  ghApp,
 	}
 }
 
 type env struct {
+	cfg    config.Config
 	store  store.Store
 	loader *load.Loader
 	parser parse.Parser
 	e      *mint.Emitter
+	ghApp  *ghapp.App
 }
 
 func serveHTTP(env *env, h func(e *env, w http.ResponseWriter, r *http.Request) error) http.HandlerFunc {
@@ -5,16 +5,21 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/guregu/null/v5"
 	"github.com/jonashiltl/openchangelog/apitypes"
 	"github.com/jonashiltl/openchangelog/internal"
+	"github.com/jonashiltl/openchangelog/internal/errs"
+	"github.com/jonashiltl/openchangelog/internal/source"
 	"github.com/jonashiltl/openchangelog/internal/store"
 	"github.com/jonashiltl/openchangelog/internal/xlog"
 )
 
 const (
-	ghsource_id_param = "id"
+	source_id_param = "id"
 )
 
 func ghToApiType(gh store.GHSource) apitypes.GHSource {
@@ -24,6 +29,11 @@ func ghToApiType(gh store.GHSource) apitypes.GHSource {
 		Owner:       gh.Owner,
 		Repo:        gh.Repo,
 		Path:        gh.Path,
+		Ref:         gh.Ref,
+		BaseURL:     gh.BaseURL,
+		Releases:    gh.Releases,
+		Include:     gh.Include,
+		Exclude:     gh.Exclude,
 	}
 }
 
@@ -33,27 +43,153 @@ func encodeGHSource(w http.ResponseWriter, gh store.GHSource) error {
 	return json.NewEncoder(w).Encode(g)
 }
 
+func glToApiType(gl store.GLSource) apitypes.GLSource {
+	return apitypes.GLSource{
+		ID:          gl.ID.String(),
+		WorkspaceID: gl.WorkspaceID.String(),
+		BaseURL:     gl.BaseURL,
+		Project:     gl.Project,
+		Path:        gl.Path,
+		Ref:         gl.Ref,
+		Releases:    gl.Releases,
+		Include:     gl.Include,
+		Exclude:     gl.Exclude,
+	}
+}
+
+func fjToApiType(fj store.FJSource) apitypes.FJSource {
+	return apitypes.FJSource{
+		ID:          fj.ID.String(),
+		WorkspaceID: fj.WorkspaceID.String(),
+		BaseURL:     fj.BaseURL,
+		Project:     fj.Project,
+		Path:        fj.Path,
+		Ref:         fj.Ref,
+		Releases:    fj.Releases,
+		Include:     fj.Include,
+		Exclude:     fj.Exclude,
+	}
+}
+
+func localToApiType(lc store.LocalSource) apitypes.LocalSource {
+	return apitypes.LocalSource{
+		ID:          lc.ID.String(),
+		WorkspaceID: lc.WorkspaceID.String(),
+		Path:        lc.Path,
+		Include:     lc.Include,
+		Exclude:     lc.Exclude,
+	}
+}
+
+func gitToApiType(git store.GitSource) apitypes.GitSource {
+	return apitypes.GitSource{
+		ID:                  git.ID.String(),
+		WorkspaceID:         git.WorkspaceID.String(),
+		URL:                 source.RedactGitRemote(git.URL),
+		Ref:                 git.Ref,
+		Path:                git.Path,
+		ConventionalCommits: git.ConventionalCommits,
+		Include:             git.Include,
+		Exclude:             git.Exclude,
+	}
+}
+
+func urlToApiType(u store.URLSource) apitypes.URLSource {
+	return apitypes.URLSource{
+		ID:          u.ID.String(),
+		WorkspaceID: u.WorkspaceID.String(),
+		URL:         source.RedactURL(u.URL),
+		Manifest:    u.Manifest,
+	}
+}
+
+// The credentials are never returned.
+func s3ToApiType(s3 store.S3Source) apitypes.S3Source {
+	return apitypes.S3Source{
+		ID:          s3.ID.String(),
+		WorkspaceID: s3.WorkspaceID.String(),
+		Endpoint:    s3.Endpoint,
+		Region:      s3.Region,
+		Bucket:      s3.Bucket,
+		Prefix:      s3.Prefix,
+	}
+}
+
+func encodeSource(w http.ResponseWriter, s apitypes.Source) error {
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(s)
+}
+
 func listSources(e *env, w http.ResponseWriter, r *http.Request) error {
 	t, err := bearerAuth(e, r)
 	if err != nil {
 		return err
 	}
 
-	// TODO: in future fetch other source types
 	gh, err := e.store.ListGHSources(r.Context(), t.WorkspaceID)
 	if err != nil {
 		return err
 	}
+	gl, err := e.store.ListGLSources(r.Context(), t.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	fj, err := e.store.ListFJSources(r.Context(), t.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	lc, err := e.store.ListLocalSources(r.Context(), t.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	git, err := e.store.ListGitSources(r.Context(), t.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	urls, err := e.store.ListURLSources(r.Context(), t.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	s3, err := e.store.ListS3Sources(r.Context(), t.WorkspaceID)
+	if err != nil {
+		return err
+	}
 
-	res := make([]apitypes.GHSource, len(gh))
-	for i, gh := range gh {
-		g := ghToApiType(gh)
-		res[i] = g
+	res := make([]apitypes.Source, 0, len(gh)+len(gl)+len(fj)+len(lc)+len(git)+len(urls)+len(s3))
+	for _, s := range gh {
+		res = append(res, ghToApiType(s))
+	}
+	for _, s := range gl {
+		res = append(res, glToApiType(s))
+	}
+	for _, s := range fj {
+		res = append(res, fjToApiType(s))
+	}
+	for _, s := range lc {
+		res = append(res, localToApiType(s))
+	}
+	for _, s := range git {
+		res = append(res, gitToApiType(s))
+	}
+	for _, s := range urls {
+		res = append(res, urlToApiType(s))
+	}
+	for _, s := range s3 {
+		res = append(res, s3ToApiType(s))
 	}
 	w.Header().Set("Content-Type", "application/json")
 	return json.NewEncoder(w).Encode(res)
 }
 
+// Loads a single release note of the changelog to check if the source is reachable.
+func testSourceConnection(e *env, r *http.Request, cl store.Changelog) error {
+	_, err := e.loader.LoadAndParseReleaseNotes(r.Context(), cl, internal.NewPagination(1, 1))
+	if err != nil {
+		slog.Debug("source connection test failed", xlog.ErrAttr(err))
+	}
+	return err
+}
+
 func createGHSource(e *env, w http.ResponseWriter, r *http.Request) error {
 	t, err := bearerAuth(e, r)
 	if err != nil {
@@ -64,19 +200,51 @@ func createGHSource(e *env, w http.ResponseWriter, r *http.Request) error {
 	if err != nil {
 		return err
 	}
+	err = validatePatterns(req.Include, req.Exclude)
+	if err != nil {
+		return err
+	}
+	err = source.ValidateGitHubBaseURL(e.cfg, req.BaseURL)
+	if err != nil {
+		return errs.NewBadRequest(err)
+	}
 
-	gh := store.GHSource{
+	// only installations made by the workspace through the setup flow are verified,
+	// so workspaces can't use the installations of others
+	if req.InstallationID != 0 {
+		if !e.ghApp.Enabled() {
+			return errs.NewBadRequest(errors.New("installationId requires the GitHub App setup to be configured"))
+		}
+		_, err = e.store.GetGHInstallation(r.Context(), t.WorkspaceID, req.InstallationID)
+		if err != nil {
+			return err
+		}
+	}
+
+	gh, err := saveGHSource(e, r, store.GHSource{
 		WorkspaceID:    t.WorkspaceID,
 		ID:             store.NewGHID(),
 		Owner:          req.Owner,
 		Repo:           req.Repo,
 		Path:           req.Path,
+		Ref:            req.Ref,
+		BaseURL:        req.BaseURL,
 		InstallationID: req.InstallationID,
+		Releases:       req.Releases,
+		Include:        req.Include,
+		Exclude:        req.Exclude,
+	})
+	if err != nil {
+		return err
 	}
+	return encodeGHSource(w, gh)
+}
 
+// Creates the source after testing that it can be loaded.
+func saveGHSource(e *env, r *http.Request, gh store.GHSource) (store.GHSource, error) {
 	// first check if the person actually has access to the repo,
 	// maybe someone tried adding a private github repo of somebody else
-	_, err = e.loader.LoadAndParseReleaseNotes(
+	_, err := e.loader.LoadAndParseReleaseNotes(
 		r.Context(),
 		store.Changelog{
 			GHSource: null.NewValue(gh, true),
@@ -85,15 +253,9 @@ func createGHSource(e *env, w http.ResponseWriter, r *http.Request) error {
 	)
 	if err != nil {
 		slog.Debug("source connection test failed", xlog.ErrAttr(err))
-		return errors.New("failed to test github source connection, looks like you don't have access to the repo")
-	}
-
-	gh, err = e.store.CreateGHSource(r.Context(), gh)
-	if err != nil {
-		return err
+		return store.GHSource{}, errors.New("failed to test github source connection, looks like you don't have access to the repo")
 	}
-
-	return encodeGHSource(w, gh)
+	return e.store.CreateGHSource(r.Context(), gh)
 }
 
 func getGHSource(e *env, w http.ResponseWriter, r *http.Request) error {
@@ -102,7 +264,7 @@ func getGHSource(e *env, w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
-	ghID, err := store.ParseGHID(r.PathValue(ghsource_id_param))
+	ghID, err := store.ParseGHID(r.PathValue(source_id_param))
 	if err != nil {
 		return err
 	}
@@ -139,7 +301,7 @@ func deleteGHSources(e *env, w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
-	ghID, err := store.ParseGHID(r.PathValue(ghsource_id_param))
+	ghID, err := store.ParseGHID(r.PathValue(source_id_param))
 	if err != nil {
 		return err
 	}
@@ -150,3 +312,587 @@ func deleteGHSources(e *env, w http.ResponseWriter, r *http.Request) error {
 	}
 	return nil
 }
+
+func createGLSource(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+	var req apitypes.CreateGLSourceBody
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return err
+	}
+	err = validatePatterns(req.Include, req.Exclude)
+	if err != nil {
+		return err
+	}
+
+	gl := store.GLSource{
+		ID:          store.NewGLID(),
+		WorkspaceID: t.WorkspaceID,
+		BaseURL:     req.BaseURL,
+		Project:     req.Project,
+		Path:        req.Path,
+		Ref:         req.Ref,
+		Token:       req.Token,
+		Releases:    req.Releases,
+		Include:     req.Include,
+		Exclude:     req.Exclude,
+	}
+
+	err = testSourceConnection(e, r, store.Changelog{GLSource: null.NewValue(gl, true)})
+	if err != nil {
+		return errors.New("failed to test gitlab source connection, looks like you don't have access to the project")
+	}
+
+	gl, err = e.store.CreateGLSource(r.Context(), gl)
+	if err != nil {
+		return err
+	}
+	return encodeSource(w, glToApiType(gl))
+}
+
+func getGLSource(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+
+	glID, err := store.ParseGLID(r.PathValue(source_id_param))
+	if err != nil {
+		return err
+	}
+
+	gl, err := e.store.GetGLSource(r.Context(), t.WorkspaceID, glID)
+	if err != nil {
+		return err
+	}
+	return encodeSource(w, glToApiType(gl))
+}
+
+func listGLSources(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+
+	sources, err := e.store.ListGLSources(r.Context(), t.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	res := make([]apitypes.GLSource, len(sources))
+	for i, gl := range sources {
+		res[i] = glToApiType(gl)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(res)
+}
+
+func deleteGLSource(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+
+	glID, err := store.ParseGLID(r.PathValue(source_id_param))
+	if err != nil {
+		return err
+	}
+	return e.store.DeleteGLSource(r.Context(), t.WorkspaceID, glID)
+}
+
+func createFJSource(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+	var req apitypes.CreateFJSourceBody
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return err
+	}
+	err = validatePatterns(req.Include, req.Exclude)
+	if err != nil {
+		return err
+	}
+
+	fj := store.FJSource{
+		ID:          store.NewFJID(),
+		WorkspaceID: t.WorkspaceID,
+		BaseURL:     req.BaseURL,
+		Project:     req.Project,
+		Path:        req.Path,
+		Ref:         req.Ref,
+		Token:       req.Token,
+		Releases:    req.Releases,
+		Include:     req.Include,
+		Exclude:     req.Exclude,
+	}
+
+	err = testSourceConnection(e, r, store.Changelog{FJSource: null.NewValue(fj, true)})
+	if err != nil {
+		return errors.New("failed to test forgejo source connection, looks like you don't have access to the repo")
+	}
+
+	fj, err = e.store.CreateFJSource(r.Context(), fj)
+	if err != nil {
+		return err
+	}
+	return encodeSource(w, fjToApiType(fj))
+}
+
+func getFJSource(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+
+	fjID, err := store.ParseFJID(r.PathValue(source_id_param))
+	if err != nil {
+		return err
+	}
+
+	fj, err := e.store.GetFJSource(r.Context(), t.WorkspaceID, fjID)
+	if err != nil {
+		return err
+	}
+	return encodeSource(w, fjToApiType(fj))
+}
+
+func listFJSources(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+
+	sources, err := e.store.ListFJSources(r.Context(), t.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	res := make([]apitypes.FJSource, len(sources))
+	for i, fj := range sources {
+		res[i] = fjToApiType(fj)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(res)
+}
+
+func deleteFJSource(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+
+	fjID, err := store.ParseFJID(r.PathValue(source_id_param))
+	if err != nil {
+		return err
+	}
+	return e.store.DeleteFJSource(r.Context(), t.WorkspaceID, fjID)
+}
+
+// Validates the glob patterns selecting the files of directory sources.
+func validatePatterns(include, exclude []string) error {
+	err := source.ValidatePatterns(slices.Concat(include, exclude))
+	if err != nil {
+		return errs.NewBadRequest(err)
+	}
+	return nil
+}
+
+// Local sources are confined to the files path of the server config,
+// otherwise any tenant could read arbitrary files of the host.
+func resolveLocalPath(e *env, path string) (string, error) {
+	if e.cfg.Local == nil || e.cfg.Local.FilesPath == "" {
+		return "", errs.NewError(errs.ErrBadRequest, errors.New("local sources are disabled, configure local.filesPath to enable them"))
+	}
+	// cleaning against the root strips any "../" that would escape the files path
+	return filepath.Join(e.cfg.Local.FilesPath, filepath.Clean("/"+path)), nil
+}
+
+func createLocalSource(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+	var req apitypes.CreateLocalSourceBody
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return err
+	}
+	err = validatePatterns(req.Include, req.Exclude)
+	if err != nil {
+		return err
+	}
+
+	path, err := resolveLocalPath(e, req.Path)
+	if err != nil {
+		return err
+	}
+
+	lc := store.LocalSource{
+		ID:          store.NewLocalID(),
+		WorkspaceID: t.WorkspaceID,
+		Path:        path,
+		Include:     req.Include,
+		Exclude:     req.Exclude,
+	}
+
+	err = testSourceConnection(e, r, store.Changelog{LocalSource: null.NewValue(lc, true)})
+	if err != nil {
+		return errs.NewError(errs.ErrBadRequest, errors.New("failed to read local source, make sure the path exists"))
+	}
+
+	lc, err = e.store.CreateLocalSource(r.Context(), lc)
+	if err != nil {
+		return err
+	}
+	return encodeSource(w, localToApiType(lc))
+}
+
+func getLocalSource(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+
+	lcID, err := store.ParseLocalID(r.PathValue(source_id_param))
+	if err != nil {
+		return err
+	}
+
+	lc, err := e.store.GetLocalSource(r.Context(), t.WorkspaceID, lcID)
+	if err != nil {
+		return err
+	}
+	return encodeSource(w, localToApiType(lc))
+}
+
+func listLocalSources(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+
+	sources, err := e.store.ListLocalSources(r.Context(), t.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	res := make([]apitypes.LocalSource, len(sources))
+	for i, lc := range sources {
+		res[i] = localToApiType(lc)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(res)
+}
+
+func deleteLocalSource(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+
+	lcID, err := store.ParseLocalID(r.PathValue(source_id_param))
+	if err != nil {
+		return err
+	}
+	return e.store.DeleteLocalSource(r.Context(), t.WorkspaceID, lcID)
+}
+
+// File remotes are confined to the files path of the server config, like local sources.
+// Ssh remotes are rejected, they would authenticate with the keys of the server,
+// credentials of private repositories are passed in https urls instead.
+func resolveGitRemote(e *env, remote string) (string, error) {
+	err := source.ValidateGitRemote(remote)
+	if err != nil {
+		return "", errs.NewBadRequest(err)
+	}
+	if !strings.HasPrefix(remote, "https://") && !strings.HasPrefix(remote, "http://") && !strings.HasPrefix(remote, "file://") {
+		return "", errs.NewBadRequest(errors.New("git remote must be an https or file url"))
+	}
+	if !strings.HasPrefix(remote, "file://") {
+		return remote, nil
+	}
+	path, err := resolveLocalPath(e, strings.TrimPrefix(remote, "file://"))
+	if err != nil {
+		return "", err
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	return "file://" + abs, nil
+}
+
+func createGitSource(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+	var req apitypes.CreateGitSourceBody
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return err
+	}
+
+	err = validatePatterns(req.Include, req.Exclude)
+	if err != nil {
+		return err
+	}
+
+	remote, err := resolveGitRemote(e, req.URL)
+	if err != nil {
+		return err
+	}
+
+	git := store.GitSource{
+		ID:                  store.NewGitID(),
+		WorkspaceID:         t.WorkspaceID,
+		URL:                 remote,
+		Ref:                 req.Ref,
+		Path:                req.Path,
+		ConventionalCommits: req.ConventionalCommits,
+		Include:             req.Include,
+		Exclude:             req.Exclude,
+	}
+
+	err = testSourceConnection(e, r, store.Changelog{GitSource: null.NewValue(git, true)})
+	if err != nil {
+		return errs.NewError(errs.ErrBadRequest, errors.New("failed to read git source, make sure the remote is reachable and the ref and path exist"))
+	}
+
+	git, err = e.store.CreateGitSource(r.Context(), git)
+	if err != nil {
+		return err
+	}
+	return encodeSource(w, gitToApiType(git))
+}
+
+func getGitSource(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+
+	gitID, err := store.ParseGitID(r.PathValue(source_id_param))
+	if err != nil {
+		return err
+	}
+
+	git, err := e.store.GetGitSource(r.Context(), t.WorkspaceID, gitID)
+	if err != nil {
+		return err
+	}
+	return encodeSource(w, gitToApiType(git))
+}
+
+func listGitSources(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+
+	sources, err := e.store.ListGitSources(r.Context(), t.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	res := make([]apitypes.GitSource, len(sources))
+	for i, git := range sources {
+		res[i] = gitToApiType(git)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(res)
+}
+
+func deleteGitSource(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+
+	gitID, err := store.ParseGitID(r.PathValue(source_id_param))
+	if err != nil {
+		return err
+	}
+	return e.store.DeleteGitSource(r.Context(), t.WorkspaceID, gitID)
+}
+
+func createURLSource(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+	var req apitypes.CreateURLSourceBody
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return err
+	}
+
+	err = source.ValidateURL(req.URL)
+	if err != nil {
+		return errs.NewBadRequest(err)
+	}
+
+	u := store.URLSource{
+		ID:          store.NewURLID(),
+		WorkspaceID: t.WorkspaceID,
+		URL:         req.URL,
+		Manifest:    req.Manifest,
+	}
+
+	err = testSourceConnection(e, r, store.Changelog{URLSource: null.NewValue(u, true)})
+	if errors.Is(err, source.ErrPrivateAddress) {
+		return errs.NewBadRequest(err)
+	}
+	if err != nil {
+		return errs.NewError(errs.ErrBadRequest, errors.New("failed to load url source, make sure the url is reachable"))
+	}
+
+	u, err = e.store.CreateURLSource(r.Context(), u)
+	if err != nil {
+		return err
+	}
+	return encodeSource(w, urlToApiType(u))
+}
+
+func getURLSource(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+
+	uID, err := store.ParseURLID(r.PathValue(source_id_param))
+	if err != nil {
+		return err
+	}
+
+	u, err := e.store.GetURLSource(r.Context(), t.WorkspaceID, uID)
+	if err != nil {
+		return err
+	}
+	return encodeSource(w, urlToApiType(u))
+}
+
+func listURLSources(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+
+	sources, err := e.store.ListURLSources(r.Context(), t.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	res := make([]apitypes.URLSource, len(sources))
+	for i, u := range sources {
+		res[i] = urlToApiType(u)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(res)
+}
+
+func deleteURLSource(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+
+	uID, err := store.ParseURLID(r.PathValue(source_id_param))
+	if err != nil {
+		return err
+	}
+	return e.store.DeleteURLSource(r.Context(), t.WorkspaceID, uID)
+}
+
+func createS3Source(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+	var req apitypes.CreateS3SourceBody
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return err
+	}
+
+	if req.Bucket == "" {
+		return errs.NewBadRequest(errors.New("bucket is required"))
+	}
+	if req.Endpoint != "" {
+		err = source.ValidateS3Endpoint(req.Endpoint)
+		if err != nil {
+			return errs.NewBadRequest(err)
+		}
+	}
+
+	s3 := store.S3Source{
+		ID:              store.NewS3ID(),
+		WorkspaceID:     t.WorkspaceID,
+		Endpoint:        req.Endpoint,
+		Region:          req.Region,
+		Bucket:          req.Bucket,
+		Prefix:          req.Prefix,
+		AccessKeyID:     req.AccessKeyID,
+		SecretAccessKey: req.SecretAccessKey,
+	}
+
+	err = testSourceConnection(e, r, store.Changelog{S3Source: null.NewValue(s3, true)})
+	if err != nil {
+		return errs.NewError(errs.ErrBadRequest, errors.New("failed to read s3 source, make sure the bucket exists and the credentials are valid"))
+	}
+
+	s3, err = e.store.CreateS3Source(r.Context(), s3)
+	if err != nil {
+		return err
+	}
+	return encodeSource(w, s3ToApiType(s3))
+}
+
+func getS3Source(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+
+	s3ID, err := store.ParseS3ID(r.PathValue(source_id_param))
+	if err != nil {
+		return err
+	}
+
+	s3, err := e.store.GetS3Source(r.Context(), t.WorkspaceID, s3ID)
+	if err != nil {
+		return err
+	}
+	return encodeSource(w, s3ToApiType(s3))
+}
+
+func listS3Sources(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+
+	sources, err := e.store.ListS3Sources(r.Context(), t.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	res := make([]apitypes.S3Source, len(sources))
+	for i, s3 := range sources {
+		res[i] = s3ToApiType(s3)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(res)
+}
+
+func deleteS3Source(e *env, w http.ResponseWriter, r *http.Request) error {
+	t, err := bearerAuth(e, r)
+	if err != nil {
+		return err
+	}
+
+	s3ID, err := store.ParseS3ID(r.PathValue(source_id_param))
+	if err != nil {
+		return err
+	}
+	return e.store.DeleteS3Source(r.Context(), t.WorkspaceID, s3ID)
+}
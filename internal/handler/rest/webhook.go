@@ -0,0 +1,256 @@
+package rest
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/jonashiltl/openchangelog/internal/errs"
+	"github.com/jonashiltl/openchangelog/internal/store"
+	"github.com/jonashiltl/openchangelog/internal/xlog"
+)
+
+// GitHub caps webhook payloads at 25MB
+const max_webhook_body_size = 25 << 20
+
+var errInvalidSignature = errs.NewError(errs.ErrUnauthorized, errors.New("invalid webhook signature"))
+
+func webhookSecrets(e *env) (github, gitlab, forgejo string) {
+	if e.cfg.Refresh == nil || e.cfg.Refresh.Webhooks == nil {
+		return "", "", ""
+	}
+	w := e.cfg.Refresh.Webhooks
+	return w.GithubSecret, w.GitlabToken, w.ForgejoSecret
+}
+
+func readWebhookBody(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, max_webhook_body_size))
+	if err != nil {
+		return nil, errs.NewError(errs.ErrBadRequest, err)
+	}
+	return body, nil
+}
+
+// Verifies a hex encoded HMAC-SHA256 signature of body.
+func validHMACSignature(secret string, body []byte, signature string) bool {
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// Returns true if the pushed ref of a webhook matches the ref of a source.
+// Sources without ref track the default branch, which isn't part of the payload, so they always match.
+func refMatches(sourceRef, pushedRef string) bool {
+	if sourceRef == "" || pushedRef == "" {
+		return true
+	}
+	return pushedRef == sourceRef || pushedRef == "refs/heads/"+sourceRef || pushedRef == "refs/tags/"+sourceRef
+}
+
+// Refreshes the sources of all changelogs with a source matching match.
+func refreshMatchingSources(e *env, r *http.Request, match func(cs store.ChangelogSource) bool) error {
+	cls, err := e.store.ListAllChangelogs(r.Context())
+	if err != nil {
+		return err
+	}
+
+	for _, cl := range matchingChangelogs(cls, match) {
+		err = e.loader.RefreshSource(r.Context(), cl, true)
+		if err != nil {
+			slog.Warn("failed to refresh source from webhook", slog.String("cid", cl.ID.String()), xlog.ErrAttr(err))
+		}
+	}
+	return nil
+}
+
+// Returns the changelogs with at least one source matching match,
+// changelogs combining multiple sources are matched by each of their sources.
+func matchingChangelogs(cls []store.Changelog, match func(cs store.ChangelogSource) bool) []store.Changelog {
+	matching := make([]store.Changelog, 0)
+	for _, cl := range cls {
+		if slices.ContainsFunc(changelogSources(cl), match) {
+			matching = append(matching, cl)
+		}
+	}
+	return matching
+}
+
+// Returns all sources of the changelog, the single source if it doesn't combine multiple sources.
+func changelogSources(cl store.Changelog) []store.ChangelogSource {
+	if len(cl.Sources) > 0 {
+		return cl.Sources
+	}
+	return []store.ChangelogSource{{
+		GHSource:    cl.GHSource,
+		GLSource:    cl.GLSource,
+		FJSource:    cl.FJSource,
+		LocalSource: cl.LocalSource,
+		GitSource:   cl.GitSource,
+		URLSource:   cl.URLSource,
+		S3Source:    cl.S3Source,
+	}}
+}
+
+func githubWebhook(e *env, w http.ResponseWriter, r *http.Request) error {
+	secret, _, _ := webhookSecrets(e)
+	if secret == "" {
+		return errs.NewError(errs.ErrNotFound, errors.New("github webhooks are not configured"))
+	}
+
+	body, err := readWebhookBody(w, r)
+	if err != nil {
+		return err
+	}
+
+	sig, found := strings.CutPrefix(r.Header.Get("X-Hub-Signature-256"), "sha256=")
+	if !found || !validHMACSignature(secret, body, sig) {
+		return errInvalidSignature
+	}
+
+	switch r.Header.Get("X-GitHub-Event") {
+	case "push":
+		return githubPush(e, r, body)
+	case "installation":
+		return githubInstallation(e, r, body)
+	}
+	// e.g. the ping event sent when creating the webhook
+	return nil
+}
+
+func githubPush(e *env, r *http.Request, body []byte) error {
+	var payload struct {
+		Ref        string `json:"ref"`
+		Repository struct {
+			FullName string `json:"full_name"`
+		} `json:"repository"`
+	}
+	err := json.Unmarshal(body, &payload)
+	if err != nil {
+		return errs.NewError(errs.ErrBadRequest, fmt.Errorf("invalid github push payload: %w", err))
+	}
+
+	return refreshMatchingSources(e, r, func(cs store.ChangelogSource) bool {
+		gh := cs.GHSource.V
+		return cs.GHSource.Valid &&
+			strings.EqualFold(fmt.Sprintf("%s/%s", gh.Owner, gh.Repo), payload.Repository.FullName) &&
+			refMatches(gh.Ref, payload.Ref)
+	})
+}
+
+// Detaches the sources of a deleted installation of the GitHub App,
+// the app needs to be configured to send its webhooks to the github webhook endpoint.
+func githubInstallation(e *env, r *http.Request, body []byte) error {
+	var payload struct {
+		Action       string `json:"action"`
+		Installation struct {
+			ID int64 `json:"id"`
+		} `json:"installation"`
+	}
+	err := json.Unmarshal(body, &payload)
+	if err != nil {
+		return errs.NewError(errs.ErrBadRequest, fmt.Errorf("invalid github installation payload: %w", err))
+	}
+	if payload.Action != "deleted" {
+		return nil
+	}
+	return e.store.DeleteGHInstallation(r.Context(), payload.Installation.ID)
+}
+
+func gitlabWebhook(e *env, w http.ResponseWriter, r *http.Request) error {
+	_, token, _ := webhookSecrets(e)
+	if token == "" {
+		return errs.NewError(errs.ErrNotFound, errors.New("gitlab webhooks are not configured"))
+	}
+
+	// gitlab sends the configured secret token as is
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get("X-Gitlab-Token")), []byte(token)) != 1 {
+		return errInvalidSignature
+	}
+
+	body, err := readWebhookBody(w, r)
+	if err != nil {
+		return err
+	}
+
+	if r.Header.Get("X-Gitlab-Event") != "Push Hook" {
+		return nil
+	}
+
+	var payload struct {
+		Ref     string `json:"ref"`
+		Project struct {
+			PathWithNamespace string `json:"path_with_namespace"`
+		} `json:"project"`
+	}
+	err = json.Unmarshal(body, &payload)
+	if err != nil {
+		return errs.NewError(errs.ErrBadRequest, fmt.Errorf("invalid gitlab push payload: %w", err))
+	}
+
+	return refreshMatchingSources(e, r, func(cs store.ChangelogSource) bool {
+		gl := cs.GLSource.V
+		return cs.GLSource.Valid &&
+			strings.EqualFold(gl.Project, payload.Project.PathWithNamespace) &&
+			refMatches(gl.Ref, payload.Ref)
+	})
+}
+
+func forgejoWebhook(e *env, w http.ResponseWriter, r *http.Request) error {
+	_, _, secret := webhookSecrets(e)
+	if secret == "" {
+		return errs.NewError(errs.ErrNotFound, errors.New("forgejo webhooks are not configured"))
+	}
+
+	body, err := readWebhookBody(w, r)
+	if err != nil {
+		return err
+	}
+
+	sig := r.Header.Get("X-Forgejo-Signature")
+	if sig == "" {
+		// older forgejo versions only send the gitea header
+		sig = r.Header.Get("X-Gitea-Signature")
+	}
+	if !validHMACSignature(secret, body, sig) {
+		return errInvalidSignature
+	}
+
+	event := r.Header.Get("X-Forgejo-Event")
+	if event == "" {
+		event = r.Header.Get("X-Gitea-Event")
+	}
+	if event != "push" {
+		return nil
+	}
+
+	var payload struct {
+		Ref        string `json:"ref"`
+		Repository struct {
+			FullName string `json:"full_name"`
+		} `json:"repository"`
+	}
+	err = json.Unmarshal(body, &payload)
+	if err != nil {
+		return errs.NewError(errs.ErrBadRequest, fmt.Errorf("invalid forgejo push payload: %w", err))
+	}
+
+	return refreshMatchingSources(e, r, func(cs store.ChangelogSource) bool {
+		fj := cs.FJSource.V
+		return cs.FJSource.Valid &&
+			strings.EqualFold(fj.Project, payload.Repository.FullName) &&
+			refMatches(fj.Ref, payload.Ref)
+	})
+}
@@ -0,0 +1,101 @@
+package rest
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"testing"
+
+	"github.com/guregu/null/v5"
+	"github.com/jonashiltl/openchangelog/internal/store"
+)
+
+func TestValidHMACSignature(t *testing.T) {
+	body := []byte(`{"ref":"refs/heads/main"}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+	valid := hex.EncodeToString(mac.Sum(nil))
+
+	tables := []struct {
+		name      string
+		secret    string
+		signature string
+		expected  bool
+	}{
+		{name: "valid signature", secret: "secret", signature: valid, expected: true},
+		{name: "wrong secret", secret: "other", signature: valid, expected: false},
+		{name: "empty signature", secret: "secret", signature: "", expected: false},
+		{name: "not hex encoded", secret: "secret", signature: "not-hex", expected: false},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			got := validHMACSignature(table.secret, body, table.signature)
+			if got != table.expected {
+				t.Errorf("expected %t but got %t", table.expected, got)
+			}
+		})
+	}
+}
+
+func TestRefMatches(t *testing.T) {
+	tables := []struct {
+		sourceRef string
+		pushedRef string
+		expected  bool
+	}{
+		{sourceRef: "", pushedRef: "refs/heads/main", expected: true},
+		{sourceRef: "main", pushedRef: "refs/heads/main", expected: true},
+		{sourceRef: "v1.0.0", pushedRef: "refs/tags/v1.0.0", expected: true},
+		{sourceRef: "main", pushedRef: "refs/heads/dev", expected: false},
+		{sourceRef: "main", pushedRef: "", expected: true},
+	}
+
+	for _, table := range tables {
+		got := refMatches(table.sourceRef, table.pushedRef)
+		if got != table.expected {
+			t.Errorf("expected refMatches(%q, %q) to be %t but got %t", table.sourceRef, table.pushedRef, table.expected, got)
+		}
+	}
+}
+
+func TestMatchingChangelogs(t *testing.T) {
+	gh := func(repo string) null.Value[store.GHSource] {
+		return null.ValueFrom(store.GHSource{Owner: "acme", Repo: repo})
+	}
+	single := store.Changelog{ID: "cl_single", GHSource: gh("app")}
+	// the first source of a combined changelog is also set on the changelog itself
+	combined := store.Changelog{
+		ID:       "cl_combined",
+		GLSource: null.ValueFrom(store.GLSource{Project: "acme/docs"}),
+		Sources: []store.ChangelogSource{
+			{GLSource: null.ValueFrom(store.GLSource{Project: "acme/docs"})},
+			{GHSource: gh("api")},
+		},
+	}
+	other := store.Changelog{ID: "cl_other", GHSource: gh("web")}
+	cls := []store.Changelog{single, combined, other}
+
+	tables := []struct {
+		repo     string
+		expected []store.ChangelogID
+	}{
+		{repo: "app", expected: []store.ChangelogID{"cl_single"}},
+		{repo: "api", expected: []store.ChangelogID{"cl_combined"}},
+		{repo: "cli", expected: []store.ChangelogID{}},
+	}
+
+	for _, table := range tables {
+		matching := matchingChangelogs(cls, func(cs store.ChangelogSource) bool {
+			return cs.GHSource.Valid && cs.GHSource.V.Repo == table.repo
+		})
+		got := make([]store.ChangelogID, len(matching))
+		for i, cl := range matching {
+			got[i] = cl.ID
+		}
+		if !slices.Equal(got, table.expected) {
+			t.Errorf("expected %v to match repo %s but got %v", table.expected, table.repo, got)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package rss
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/internal/handler"
+)
+
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Updated  string      `xml:"updated"`
+	Author   *atomAuthor `xml:"author,omitempty"`
+	Links    []atomLink  `xml:"link"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Links      []atomLink     `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Summary    string         `xml:"summary,omitempty"`
+	Authors    []atomAuthor   `xml:"author"`
+	Content    atomContent    `xml:"content"`
+	Categories []atomCategory `xml:"category"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+func atomHandler(e *env, w http.ResponseWriter, r *http.Request) error {
+	f, err := loadFeed(e, r)
+	if err != nil {
+		return err
+	}
+	handler.SetStaleHeader(w, e.loader.StaleSince(f.CL))
+
+	feed := toAtomFeed(f, handler.FeedToChangelogURL(r), handler.GetAtomFeedURL(r))
+
+	w.Header().Set("Content-Type", "application/atom+xml")
+	_, err = io.WriteString(w, xml.Header)
+	if err != nil {
+		return err
+	}
+	return xml.NewEncoder(w).Encode(feed)
+}
+
+// Converts the loaded changelog to an Atom 1.0 feed, consumes the content of the release notes.
+func toAtomFeed(loaded loadedFeed, link string, self string) atomFeed {
+	updated := feedCreatedAt(loaded)
+	if len(loaded.Notes) > 0 && loaded.Notes[0].Meta.PublishedAt.After(updated) {
+		updated = loaded.Notes[0].Meta.PublishedAt
+	}
+
+	feed := atomFeed{
+		ID:       link,
+		Title:    loaded.Title,
+		Subtitle: loaded.CL.Subtitle.V(),
+		Updated:  toAtomDate(updated),
+		Links: []atomLink{
+			{Href: link, Rel: "alternate", Type: "text/html"},
+			{Href: self, Rel: "self", Type: "application/atom+xml"},
+		},
+		Entries: make([]atomEntry, 0, len(loaded.Notes)),
+	}
+	// atom requires an author, either on the feed or on every entry
+	if loaded.CL.Title.V() != "" {
+		feed.Author = &atomAuthor{Name: loaded.CL.Title.V()}
+	}
+
+	for _, n := range loaded.Notes {
+		url := addFragment(link, n.Meta.ID)
+		entry := atomEntry{
+			ID:        url,
+			Title:     n.Meta.Title,
+			Links:     []atomLink{{Href: url, Rel: "alternate", Type: "text/html"}},
+			Published: toAtomDate(n.Meta.PublishedAt),
+			Updated:   toAtomDate(n.Meta.PublishedAt),
+			Summary:   n.Meta.Description,
+			Content: atomContent{
+				Type:  "html",
+				Value: readContent(n),
+			},
+		}
+		for _, a := range n.Meta.Authors {
+			entry.Authors = append(entry.Authors, atomAuthor{Name: a})
+		}
+		if n.Meta.Image != "" {
+			entry.Links = append(entry.Links, atomLink{Href: n.Meta.Image, Rel: "enclosure"})
+		}
+		for _, t := range n.Meta.Tags {
+			entry.Categories = append(entry.Categories, atomCategory{Term: t})
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+	return feed
+}
+
+func toAtomDate(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
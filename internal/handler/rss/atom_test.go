@@ -0,0 +1,75 @@
+package rss
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/apitypes"
+	"github.com/jonashiltl/openchangelog/internal/load"
+	"github.com/jonashiltl/openchangelog/internal/parse"
+	"github.com/jonashiltl/openchangelog/internal/store"
+)
+
+func testLoadedFeed() loadedFeed {
+	return loadedFeed{
+		Title: "Acme",
+		LoadedChangelog: load.LoadedChangelog{
+			CL: store.Changelog{
+				Title:    apitypes.NewString("Acme"),
+				Subtitle: apitypes.NewString("Latest updates"),
+			},
+			Notes: []parse.ParsedReleaseNote{
+				{
+					Meta: parse.Meta{
+						ID:          "v2",
+						Title:       "Version 2",
+						Description: "The second release",
+						PublishedAt: time.Date(2026, time.March, 20, 16, 24, 0, 0, time.UTC),
+						Tags:        []string{"Feature", "Fix"},
+					},
+					Content: strings.NewReader("<p>Second</p>"),
+				},
+				{
+					Meta: parse.Meta{
+						ID:          "v1",
+						Title:       "Version 1",
+						PublishedAt: time.Date(2026, time.January, 2, 8, 0, 0, 0, time.UTC),
+					},
+					Content: strings.NewReader("<p>First</p>"),
+				},
+			},
+		},
+	}
+}
+
+func TestToAtomFeed(t *testing.T) {
+	feed := toAtomFeed(testLoadedFeed(), "https://acme.com", "https://acme.com/feed.atom")
+
+	if feed.Title != "Acme" || feed.Subtitle != "Latest updates" {
+		t.Errorf("expected title and subtitle of changelog, got %q and %q", feed.Title, feed.Subtitle)
+	}
+	if feed.Updated != "2026-03-20T16:24:00Z" {
+		t.Errorf("expected feed to be updated at the newest release note, got %s", feed.Updated)
+	}
+	if feed.Author == nil || feed.Author.Name != "Acme" {
+		t.Errorf("expected feed author to be the changelog title")
+	}
+	if len(feed.Entries) != 2 {
+		t.Fatalf("expected 2 entries but got %d", len(feed.Entries))
+	}
+
+	entry := feed.Entries[0]
+	if entry.ID != "https://acme.com#v2" {
+		t.Errorf("expected entry id to be the release note url, got %s", entry.ID)
+	}
+	if entry.Content.Type != "html" || entry.Content.Value != "<p>Second</p>" {
+		t.Errorf("expected html content, got %+v", entry.Content)
+	}
+	if entry.Published != "2026-03-20T16:24:00Z" {
+		t.Errorf("expected RFC3339 published date, got %s", entry.Published)
+	}
+	if len(entry.Categories) != 2 || entry.Categories[0].Term != "Feature" {
+		t.Errorf("expected tags as categories, got %+v", entry.Categories)
+	}
+}
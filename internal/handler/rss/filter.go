@@ -0,0 +1,145 @@
+package rss
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/jonashiltl/openchangelog/internal/errs"
+	"github.com/jonashiltl/openchangelog/internal/handler"
+	"github.com/jonashiltl/openchangelog/internal/parse"
+	"github.com/jonashiltl/openchangelog/internal/search"
+	"github.com/jonashiltl/openchangelog/internal/source"
+	"github.com/jonashiltl/openchangelog/internal/store"
+)
+
+// Restricts the release notes of a feed, specified using the "tags" and "q" query params.
+type feedFilter struct {
+	// Release notes need to have at least one of the tags.
+	Tags []string
+	// Free-text query the release notes need to match.
+	Query string
+}
+
+// Parses the filter of the feed request.
+// Tags can be specified comma separated or by repeating the query param.
+func parseFeedFilter(q url.Values) feedFilter {
+	var f feedFilter
+	for _, v := range q[handler.TAGS_QUERY] {
+		for _, t := range strings.Split(v, ",") {
+			t = strings.TrimSpace(t)
+			if t != "" {
+				f.Tags = append(f.Tags, t)
+			}
+		}
+	}
+	f.Query = strings.TrimSpace(q.Get(handler.SEARCH_QUERY))
+	return f
+}
+
+func (f feedFilter) isEmpty() bool {
+	return len(f.Tags) == 0 && f.Query == ""
+}
+
+// Appends the filter to the title, so filtered feeds can be told apart in feed readers.
+func (f feedFilter) title(title string) string {
+	if f.isEmpty() {
+		return title
+	}
+
+	parts := make([]string, 0, len(f.Tags)+1)
+	parts = append(parts, f.Tags...)
+	if f.Query != "" {
+		parts = append(parts, fmt.Sprintf("%q", f.Query))
+	}
+
+	filter := strings.Join(parts, ", ")
+	if title == "" {
+		return filter
+	}
+	return fmt.Sprintf("%s - %s", title, filter)
+}
+
+// Returns the release notes matching the filter, keeps the order of notes.
+func (e *env) applyFilter(ctx context.Context, cl store.Changelog, notes []parse.ParsedReleaseNote, f feedFilter) ([]parse.ParsedReleaseNote, error) {
+	// the hits aren't filtered by the tags, so the search can return every release note of the feed
+	size := len(notes)
+	notes = filterByTags(notes, f.Tags)
+	if f.Query == "" || len(notes) == 0 {
+		return notes, nil
+	}
+
+	if !cl.Searchable {
+		return filterByText(notes, f.Query), nil
+	}
+
+	sids := source.NewIDsFromChangelog(cl)
+	if len(sids) == 0 {
+		return nil, errs.NewBadRequest(errors.New("changelog has no active source"))
+	}
+	args := search.SearchArgs{
+		Query: f.Query,
+		Size:  size,
+	}
+	for _, sid := range sids {
+		args.SIDs = append(args.SIDs, sid.String())
+	}
+
+	res, err := e.searcher.Search(ctx, args)
+	if err != nil {
+		return nil, errs.NewBadRequest(err)
+	}
+
+	hits := make(map[string]bool, len(res.Hits))
+	for _, h := range res.Hits {
+		hits[source.CombinedNoteID(cl, source.ID(h.SID), h.ID)] = true
+	}
+
+	filtered := make([]parse.ParsedReleaseNote, 0, len(hits))
+	for _, n := range notes {
+		if hits[n.Meta.ID] {
+			filtered = append(filtered, n)
+		}
+	}
+	return filtered, nil
+}
+
+// Returns the release notes which have at least one of the tags, tags are compared case insensitive.
+func filterByTags(notes []parse.ParsedReleaseNote, tags []string) []parse.ParsedReleaseNote {
+	if len(tags) == 0 {
+		return notes
+	}
+
+	filtered := make([]parse.ParsedReleaseNote, 0, len(notes))
+	for _, n := range notes {
+		if hasAnyTag(n.Meta.Tags, tags) {
+			filtered = append(filtered, n)
+		}
+	}
+	return filtered
+}
+
+func hasAnyTag(noteTags []string, tags []string) bool {
+	for _, nt := range noteTags {
+		for _, t := range tags {
+			if strings.EqualFold(nt, t) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Used if the changelog isn't searchable, matches the query against the title and description.
+func filterByText(notes []parse.ParsedReleaseNote, query string) []parse.ParsedReleaseNote {
+	query = strings.ToLower(query)
+	filtered := make([]parse.ParsedReleaseNote, 0, len(notes))
+	for _, n := range notes {
+		if strings.Contains(strings.ToLower(n.Meta.Title), query) || strings.Contains(strings.ToLower(n.Meta.Description), query) {
+			filtered = append(filtered, n)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,185 @@
+package rss
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"testing"
+
+	"github.com/guregu/null/v5"
+	"github.com/jonashiltl/openchangelog/internal/parse"
+	"github.com/jonashiltl/openchangelog/internal/search"
+	"github.com/jonashiltl/openchangelog/internal/store"
+)
+
+func TestParseFeedFilter(t *testing.T) {
+	tables := []struct {
+		query         string
+		expectedTags  []string
+		expectedQuery string
+	}{
+		{
+			query: "",
+		},
+		{
+			query:        "tags=Security",
+			expectedTags: []string{"Security"},
+		},
+		{
+			query:        "tags=Security,%20Fix,&tags=Feature",
+			expectedTags: []string{"Security", "Fix", "Feature"},
+		},
+		{
+			query:         "q=%20login%20&cid=cl_123",
+			expectedQuery: "login",
+		},
+	}
+
+	for _, table := range tables {
+		q, _ := url.ParseQuery(table.query)
+		f := parseFeedFilter(q)
+		if !slices.Equal(f.Tags, table.expectedTags) {
+			t.Errorf("expected tags %v but got %v", table.expectedTags, f.Tags)
+		}
+		if f.Query != table.expectedQuery {
+			t.Errorf("expected query %q but got %q", table.expectedQuery, f.Query)
+		}
+	}
+}
+
+func TestFeedFilterTitle(t *testing.T) {
+	tables := []struct {
+		filter   feedFilter
+		title    string
+		expected string
+	}{
+		{
+			filter:   feedFilter{},
+			title:    "Acme",
+			expected: "Acme",
+		},
+		{
+			filter:   feedFilter{Tags: []string{"Security", "Fix"}},
+			title:    "Acme",
+			expected: "Acme - Security, Fix",
+		},
+		{
+			filter:   feedFilter{Tags: []string{"Security"}, Query: "login"},
+			title:    "Acme",
+			expected: `Acme - Security, "login"`,
+		},
+		{
+			filter:   feedFilter{Query: "login"},
+			title:    "",
+			expected: `"login"`,
+		},
+	}
+
+	for _, table := range tables {
+		title := table.filter.title(table.title)
+		if title != table.expected {
+			t.Errorf("expected %s to equal %s", title, table.expected)
+		}
+	}
+}
+
+type fakeSearcher struct {
+	search.Searcher
+	hits []string
+	args search.SearchArgs
+}
+
+func (s *fakeSearcher) Search(ctx context.Context, args search.SearchArgs) (search.SearchResults, error) {
+	s.args = args
+	res := search.SearchResults{}
+	for _, id := range s.hits {
+		res.Hits = append(res.Hits, search.SearchResult{ID: id})
+	}
+	return res, nil
+}
+
+func TestApplyFilter(t *testing.T) {
+	notes := []parse.ParsedReleaseNote{
+		{Meta: parse.Meta{ID: "3", Title: "Login with SSO", Tags: []string{"Feature"}}},
+		{Meta: parse.Meta{ID: "2", Title: "Patch XSS", Tags: []string{"security", "Fix"}}},
+		{Meta: parse.Meta{ID: "1", Title: "Fix login redirect", Tags: []string{"Security"}}},
+	}
+
+	tables := []struct {
+		name        string
+		cl          store.Changelog
+		filter      feedFilter
+		hits        []string
+		expectedIDs []string
+	}{
+		{
+			name:        "no filter",
+			filter:      feedFilter{},
+			expectedIDs: []string{"3", "2", "1"},
+		},
+		{
+			name:        "tags are case insensitive",
+			filter:      feedFilter{Tags: []string{"Security"}},
+			expectedIDs: []string{"2", "1"},
+		},
+		{
+			name:        "any of the tags",
+			filter:      feedFilter{Tags: []string{"Feature", "Fix"}},
+			expectedIDs: []string{"3", "2"},
+		},
+		{
+			name:        "query without search",
+			filter:      feedFilter{Query: "LOGIN"},
+			expectedIDs: []string{"3", "1"},
+		},
+		{
+			name:        "query and tags without search",
+			filter:      feedFilter{Tags: []string{"security"}, Query: "login"},
+			expectedIDs: []string{"1"},
+		},
+		{
+			name: "query with search keeps feed order",
+			cl: store.Changelog{
+				Searchable:  true,
+				LocalSource: null.NewValue(store.LocalSource{Path: "release-notes"}, true),
+			},
+			filter:      feedFilter{Query: "redirect"},
+			hits:        []string{"1", "3"},
+			expectedIDs: []string{"3", "1"},
+		},
+		{
+			name: "query and tags with search",
+			cl: store.Changelog{
+				Searchable:  true,
+				LocalSource: null.NewValue(store.LocalSource{Path: "release-notes"}, true),
+			},
+			filter:      feedFilter{Tags: []string{"security"}, Query: "login"},
+			hits:        []string{"3", "1"},
+			expectedIDs: []string{"1"},
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			searcher := &fakeSearcher{hits: table.hits}
+			e := &env{searcher: searcher}
+
+			filtered, err := e.applyFilter(context.Background(), table.cl, slices.Clone(notes), table.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ids := make([]string, len(filtered))
+			for i, n := range filtered {
+				ids[i] = n.Meta.ID
+			}
+			if !slices.Equal(ids, table.expectedIDs) {
+				t.Errorf("expected %v but got %v", table.expectedIDs, ids)
+			}
+			// release notes without the tags can rank higher than the ones with them
+			if table.hits != nil && searcher.args.Size != len(notes) {
+				t.Errorf("expected the search to return up to %d hits but got size %d", len(notes), searcher.args.Size)
+			}
+		})
+	}
+}
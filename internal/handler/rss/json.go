@@ -0,0 +1,98 @@
+package rss
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/internal/handler"
+	"github.com/jonashiltl/openchangelog/internal/parse"
+)
+
+const jsonFeedVersion = "https://jsonfeed.org/version/1.1"
+
+type jsonFeed struct {
+	Version     string     `json:"version"`
+	Title       string     `json:"title"`
+	HomePageURL string     `json:"home_page_url"`
+	FeedURL     string     `json:"feed_url"`
+	Description string     `json:"description,omitempty"`
+	Items       []jsonItem `json:"items"`
+}
+
+type jsonItem struct {
+	ID            string       `json:"id"`
+	URL           string       `json:"url"`
+	Title         string       `json:"title,omitempty"`
+	Summary       string       `json:"summary,omitempty"`
+	ContentHTML   string       `json:"content_html"`
+	DatePublished string       `json:"date_published,omitempty"`
+	Tags          []string     `json:"tags,omitempty"`
+	Image         string       `json:"image,omitempty"`
+	Authors       []jsonAuthor `json:"authors,omitempty"`
+}
+
+type jsonAuthor struct {
+	Name string `json:"name"`
+}
+
+func jsonFeedHandler(e *env, w http.ResponseWriter, r *http.Request) error {
+	f, err := loadFeed(e, r)
+	if err != nil {
+		return err
+	}
+	handler.SetStaleHeader(w, e.loader.StaleSince(f.CL))
+
+	feed := toJSONFeed(f, handler.FeedToChangelogURL(r), handler.GetJSONFeedURL(r))
+
+	w.Header().Set("Content-Type", "application/feed+json")
+	return json.NewEncoder(w).Encode(feed)
+}
+
+// Converts the loaded changelog to a JSON Feed 1.1, consumes the content of the release notes.
+func toJSONFeed(loaded loadedFeed, link string, self string) jsonFeed {
+	feed := jsonFeed{
+		Version:     jsonFeedVersion,
+		Title:       loaded.Title,
+		HomePageURL: link,
+		FeedURL:     self,
+		Description: loaded.CL.Subtitle.V(),
+		Items:       make([]jsonItem, 0, len(loaded.Notes)),
+	}
+
+	for _, n := range loaded.Notes {
+		url := addFragment(link, n.Meta.ID)
+		item := jsonItem{
+			ID:          url,
+			URL:         url,
+			Title:       n.Meta.Title,
+			Summary:     n.Meta.Description,
+			ContentHTML: readContent(n),
+			Tags:        n.Meta.Tags,
+			Image:       n.Meta.Image,
+		}
+		for _, a := range n.Meta.Authors {
+			item.Authors = append(item.Authors, jsonAuthor{Name: a})
+		}
+		if !n.Meta.PublishedAt.IsZero() {
+			item.DatePublished = n.Meta.PublishedAt.UTC().Format(time.RFC3339)
+		}
+		feed.Items = append(feed.Items, item)
+	}
+	return feed
+}
+
+// Reads the html content of the release note.
+func readContent(n parse.ParsedReleaseNote) string {
+	if n.Content == nil {
+		return ""
+	}
+	buf := new(strings.Builder)
+	_, err := io.Copy(buf, n.Content)
+	if err != nil {
+		return ""
+	}
+	return buf.String()
+}
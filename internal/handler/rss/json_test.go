@@ -0,0 +1,33 @@
+package rss
+
+import (
+	"testing"
+)
+
+func TestToJSONFeed(t *testing.T) {
+	feed := toJSONFeed(testLoadedFeed(), "https://acme.com", "https://acme.com/feed.json")
+
+	if feed.Version != jsonFeedVersion {
+		t.Errorf("expected version %s, got %s", jsonFeedVersion, feed.Version)
+	}
+	if feed.HomePageURL != "https://acme.com" || feed.FeedURL != "https://acme.com/feed.json" {
+		t.Errorf("expected home page and feed url, got %q and %q", feed.HomePageURL, feed.FeedURL)
+	}
+	if len(feed.Items) != 2 {
+		t.Fatalf("expected 2 items but got %d", len(feed.Items))
+	}
+
+	item := feed.Items[1]
+	if item.ID != "https://acme.com#v1" || item.URL != item.ID {
+		t.Errorf("expected item id and url to be the release note url, got %q and %q", item.ID, item.URL)
+	}
+	if item.ContentHTML != "<p>First</p>" {
+		t.Errorf("expected html content, got %s", item.ContentHTML)
+	}
+	if item.DatePublished != "2026-01-02T08:00:00Z" {
+		t.Errorf("expected RFC3339 publish date, got %s", item.DatePublished)
+	}
+	if item.Tags != nil {
+		t.Errorf("expected no tags, got %v", item.Tags)
+	}
+}
@@ -9,25 +9,30 @@ import (
 	"github.com/jonashiltl/openchangelog/internal/errs"
 	"github.com/jonashiltl/openchangelog/internal/load"
 	"github.com/jonashiltl/openchangelog/internal/parse"
+	"github.com/jonashiltl/openchangelog/internal/search"
 	"github.com/jonashiltl/openchangelog/internal/xlog"
 )
 
 type env struct {
-	cfg    config.Config
-	loader *load.Loader
-	parser parse.Parser
+	cfg      config.Config
+	loader   *load.Loader
+	parser   parse.Parser
+	searcher search.Searcher
 }
 
-func NewEnv(cfg config.Config, loader *load.Loader, parser parse.Parser) *env {
+func NewEnv(cfg config.Config, loader *load.Loader, parser parse.Parser, searcher search.Searcher) *env {
 	return &env{
-		cfg:    cfg,
-		loader: loader,
-		parser: parser,
+		cfg:      cfg,
+		loader:   loader,
+		parser:   parser,
+		searcher: searcher,
 	}
 }
 
 func RegisterRSSHandler(mux *http.ServeMux, e *env) {
 	mux.HandleFunc("GET /feed", serveHTTP(e, feedHandler))
+	mux.HandleFunc("GET /feed.atom", serveHTTP(e, atomHandler))
+	mux.HandleFunc("GET /feed.json", serveHTTP(e, jsonFeedHandler))
 }
 
 func serveHTTP(env *env, h func(e *env, w http.ResponseWriter, r *http.Request) error) http.HandlerFunc {
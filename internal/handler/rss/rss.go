@@ -12,29 +12,66 @@ import (
 	"github.com/jonashiltl/openchangelog/internal"
 	"github.com/jonashiltl/openchangelog/internal/errs"
 	"github.com/jonashiltl/openchangelog/internal/handler"
+	"github.com/jonashiltl/openchangelog/internal/load"
 )
 
 //go:embed feed.tmpl
 var feedTemplate string
 
-func feedHandler(e *env, w http.ResponseWriter, r *http.Request) error {
+type loadedFeed struct {
+	load.LoadedChangelog
+	// The title of the feed, reflects the applied filter.
+	Title string
+}
+
+// Loads all published release notes of the feed matching the filter of the request,
+// protected changelogs require the password in the "authorize" query param.
+func loadFeed(e *env, r *http.Request) (loadedFeed, error) {
 	loaded, err := e.loader.LoadAndParse(r, internal.NoPagination())
 	if err != nil {
-		return err
+		return loadedFeed{}, err
 	}
 
 	if loaded.CL.Protected {
 		authorize := r.URL.Query().Get(handler.AUTHORIZE_QUERY)
 		if authorize == "" {
-			return errs.NewBadRequest(errors.New("can't load rss feed of protected changelog, specify \"authorize\" query param to subscribe"))
+			return loadedFeed{}, errs.NewBadRequest(errors.New("can't load feed of protected changelog, specify \"authorize\" query param to subscribe"))
 		}
 
 		err = handler.ValidatePassword(loaded.CL.PasswordHash, authorize)
 		if err != nil {
-			return errs.NewBadRequest(err)
+			return loadedFeed{}, errs.NewBadRequest(err)
 		}
 	}
 
+	filter := parseFeedFilter(r.URL.Query())
+	loaded.Notes, err = e.applyFilter(r.Context(), loaded.CL, loaded.Notes, filter)
+	if err != nil {
+		return loadedFeed{}, err
+	}
+
+	return loadedFeed{
+		LoadedChangelog: loaded,
+		Title:           filter.title(loaded.CL.Title.V()),
+	}, nil
+}
+
+// Returns the date the changelog was created, falls back to the newest release note.
+func feedCreatedAt(loaded loadedFeed) time.Time {
+	createdAt := loaded.CL.CreatedAt
+	if createdAt.IsZero() && len(loaded.Notes) > 0 {
+		createdAt = loaded.Notes[0].Meta.PublishedAt
+	}
+	return createdAt
+}
+
+func feedHandler(e *env, w http.ResponseWriter, r *http.Request) error {
+	f, err := loadFeed(e, r)
+	if err != nil {
+		return err
+	}
+	handler.SetStaleHeader(w, e.loader.StaleSince(f.CL))
+
 	tmpl, err := template.
 		New("feed").
 		Funcs(template.FuncMap{
@@ -49,16 +86,13 @@ func feedHandler(e *env, w http.ResponseWriter, r *http.Request) error {
 	w.Header().Set("Content-Type", "application/rss+xml")
 
 	link := handler.FeedToChangelogURL(r)
-	createdAt := loaded.CL.CreatedAt
-	if createdAt.IsZero() && len(loaded.Notes) > 0 {
-		createdAt = loaded.Notes[0].Meta.PublishedAt
-	}
 
 	args := map[string]any{
-		"CL":        loaded.CL,
-		"Articles":  loaded.Notes,
-		"HasMore":   loaded.HasMore,
-		"CreatedAt": createdAt,
+		"Title":     f.Title,
+		"CL":        f.CL,
+		"Articles":  f.Notes,
+		"HasMore":   f.HasMore,
+		"CreatedAt": feedCreatedAt(f),
 		"Link":      strings.ReplaceAll(link, "&", "&amp;"), // & is reserved in xml
 	}
 	return tmpl.Execute(w, args)
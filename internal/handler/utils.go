@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 )
@@ -14,10 +15,37 @@ const (
 	WS_ID_QUERY     = "wid"
 	CL_ID_QUERY     = "cid"
 	AUTHORIZE_QUERY = "authorize"
+	TAGS_QUERY      = "tags"
+	SEARCH_QUERY    = "q"
 )
 
+// Set if the response contains stale release notes, because a source of the changelog fails to load.
+// Contains the time the source started failing as http date.
+const STALE_HEADER = "X-Openchangelog-Stale-Since"
+
+// Sets the STALE_HEADER if since is not zero.
+func SetStaleHeader(w http.ResponseWriter, since time.Time) {
+	if !since.IsZero() {
+		w.Header().Set(STALE_HEADER, since.UTC().Format(http.TimeFormat))
+	}
+}
+
 // Turns the changelog request into the feed url of the changelog
 func GetFeedURL(r *http.Request) string {
+	return feedURL(r, "feed")
+}
+
+// Turns the changelog request into the atom feed url of the changelog
+func GetAtomFeedURL(r *http.Request) string {
+	return feedURL(r, "feed.atom")
+}
+
+// Turns the changelog request into the json feed url of the changelog
+func GetJSONFeedURL(r *http.Request) string {
+	return feedURL(r, "feed.json")
+}
+
+func feedURL(r *http.Request, path string) string {
 	rq := r.URL.Query()
 	// only copy the query params we want, don't want page or page-size
 	q := url.Values{}
@@ -30,12 +58,19 @@ func GetFeedURL(r *http.Request) string {
 	if len(rq.Get(AUTHORIZE_QUERY)) > 0 {
 		q.Add(AUTHORIZE_QUERY, rq.Get(AUTHORIZE_QUERY))
 	}
+	// keep the filter of filtered feeds
+	for _, t := range rq[TAGS_QUERY] {
+		q.Add(TAGS_QUERY, t)
+	}
+	if len(rq.Get(SEARCH_QUERY)) > 0 {
+		q.Add(SEARCH_QUERY, rq.Get(SEARCH_QUERY))
+	}
 
 	newURL := &url.URL{
 		Scheme:   r.URL.Scheme,
 		Host:     r.URL.Host,
 		RawQuery: q.Encode(),
-		Path:     "feed",
+		Path:     path,
 	}
 
 	if newURL.Host == "" {
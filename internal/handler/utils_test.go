@@ -3,8 +3,10 @@ package handler
 import (
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 )
 
 func TestFeedToChangelogURL(t *testing.T) {
@@ -89,6 +91,24 @@ func TestGetFeedURL(t *testing.T) {
 	}
 }
 
+func TestGetAtomAndJSONFeedURL(t *testing.T) {
+	u, _ := url.Parse("/?page-size=5&cid=cl_123")
+	r := &http.Request{
+		URL:  u,
+		Host: "tenant.openchangelog.com",
+	}
+
+	expected := "https://tenant.openchangelog.com/feed.atom?cid=cl_123"
+	if atomURL := GetAtomFeedURL(r); atomURL != expected {
+		t.Fatalf("expected %s to equal %s", atomURL, expected)
+	}
+
+	expected = "https://tenant.openchangelog.com/feed.json?cid=cl_123"
+	if jsonURL := GetJSONFeedURL(r); jsonURL != expected {
+		t.Fatalf("expected %s to equal %s", jsonURL, expected)
+	}
+}
+
 func TestGetFullURL(t *testing.T) {
 	tables := []struct {
 		requestURL string
@@ -209,3 +229,17 @@ func TestValidatePassword(t *testing.T) {
 		}
 	}
 }
+
+func TestSetStaleHeader(t *testing.T) {
+	w := httptest.NewRecorder()
+	SetStaleHeader(w, time.Time{})
+	if _, ok := w.Header()[STALE_HEADER]; ok {
+		t.Errorf("expected no stale header for fresh release notes")
+	}
+
+	since := time.Date(2025, 3, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+	SetStaleHeader(w, since)
+	if got := w.Header().Get(STALE_HEADER); got != "Sat, 01 Mar 2025 11:00:00 GMT" {
+		t.Errorf("expected stale header to be the http date of since, got %q", got)
+	}
+}
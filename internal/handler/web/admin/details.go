@@ -5,6 +5,7 @@ import (
 
 	"github.com/jonashiltl/openchangelog/internal/handler"
 	adminviews "github.com/jonashiltl/openchangelog/internal/handler/web/admin/views"
+	"github.com/jonashiltl/openchangelog/internal/load"
 	"github.com/jonashiltl/openchangelog/internal/store"
 	"golang.org/x/sync/errgroup"
 )
@@ -39,8 +40,26 @@ func details(e *env, w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
+	// loads the release notes of every changelog to report the files that failed to be loaded
+	health := make([][]load.SourceHealth, len(cls))
+	var hg errgroup.Group
+	for i, cl := range cls {
+		hg.Go(func() error {
+			// changelogs without a source have no health
+			health[i], _ = e.loader.CheckHealth(r.Context(), cl)
+			return nil
+		})
+	}
+	hg.Wait()
+
+	byID := make(map[store.ChangelogID][]load.SourceHealth, len(cls))
+	for i, cl := range cls {
+		byID[cl.ID] = health[i]
+	}
+
 	return adminviews.WorkspaceDetails(adminviews.WorkspaceDetailsArgs{
 		Workspace:  ws,
 		Changelogs: cls,
+		Health:     byID,
 	}).Render(r.Context(), w)
 }
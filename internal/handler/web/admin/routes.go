@@ -7,6 +7,7 @@ import (
 
 	"github.com/jonashiltl/openchangelog/internal/config"
 	"github.com/jonashiltl/openchangelog/internal/errs"
+	"github.com/jonashiltl/openchangelog/internal/load"
 	"github.com/jonashiltl/openchangelog/internal/store"
 )
 
@@ -20,16 +21,18 @@ func RegisterAdminHandler(mux *http.ServeMux, e *env) {
 	mux.HandleFunc("GET /admin/{wid}", serveHTTP(e, details))
 }
 
-func NewEnv(cfg config.Config, st store.Store) *env {
+func NewEnv(cfg config.Config, st store.Store, loader *load.Loader) *env {
 	return &env{
-		cfg: cfg,
-		st:  st,
+		cfg:    cfg,
+		st:     st,
+		loader: loader,
 	}
 }
 
 type env struct {
-	cfg config.Config
-	st  store.Store
+	cfg    config.Config
+	st     store.Store
+	loader *load.Loader
 }
 
 func serveHTTP(env *env, h func(e *env, w http.ResponseWriter, r *http.Request) error) func(http.ResponseWriter, *http.Request) {
@@ -1,69 +1,59 @@
 package web
 
 import (
-	"fmt"
 	"log/slog"
 	"net/http"
 
 	"github.com/jonashiltl/openchangelog/components"
-	"github.com/jonashiltl/openchangelog/internal"
-	"github.com/jonashiltl/openchangelog/internal/errs"
 	"github.com/jonashiltl/openchangelog/internal/handler"
 	"github.com/jonashiltl/openchangelog/internal/handler/web/static"
 	"github.com/jonashiltl/openchangelog/internal/handler/web/views"
-	"github.com/jonashiltl/openchangelog/internal/parse"
 )
 
 func details(e *env, w http.ResponseWriter, r *http.Request) error {
 	noteID := r.PathValue("nid")
-	loaded, err := e.loader.LoadAndParse(r, internal.NoPagination())
+	cl, err := e.loader.GetChangelog(r)
 	if err != nil {
 		return err
 	}
 
-	if loaded.CL.Protected {
-		err = ensurePasswordProvided(r, loaded.CL.PasswordHash)
+	if cl.Protected {
+		err = ensurePasswordProvided(r, cl.PasswordHash)
 		if err != nil {
 			slog.InfoContext(
 				r.Context(),
 				"blocked access to changelog details",
-				slog.String("changelog", loaded.CL.ID.String()),
+				slog.String("changelog", cl.ID.String()),
 				slog.String("release", noteID),
 			)
 			return views.PasswordProtection(views.PasswordProtectionArgs{
 				CSS: static.BaseCSS,
 				ThemeArgs: components.ThemeArgs{
-					ColorScheme: loaded.CL.ColorScheme.ToApiTypes(),
+					ColorScheme: cl.ColorScheme.ToApiTypes(),
 				},
 				FooterArgs: components.FooterArgs{
-					HidePoweredBy: loaded.CL.HidePoweredBy,
+					HidePoweredBy: cl.HidePoweredBy,
 				},
 			}).Render(r.Context(), w)
 		}
 	}
 
-	setCacheControlHeader(r, w, loaded.CL.Protected)
-
-	for i, note := range loaded.Notes {
-		if note.Meta.ID == noteID {
-			var prev, next parse.ParsedReleaseNote
-			if i > 0 {
-				prev = loaded.Notes[i-1]
-			}
-			if i < len(loaded.Notes)-1 {
-				next = loaded.Notes[i+1]
-			}
-
-			return e.render.RenderDetails(r.Context(), w, RenderDetailsArgs{
-				CL:          loaded.CL,
-				ReleaseNote: note,
-				FeedURL:     handler.GetFeedURL(r),
-				Prev:        prev,
-				Next:        next,
-				HasMetaKey:  requestFromMac(r.Header),
-			})
-		}
+	loaded, err := e.loader.LoadReleaseNote(r.Context(), cl, noteID)
+	if err != nil {
+		return err
 	}
 
-	return errs.NewNotFound(fmt.Errorf("release note %s not found", noteID))
+	setCacheControlHeader(r, w, cl.Protected, e.loader.NextPublish(cl.ID))
+	handler.SetStaleHeader(w, e.loader.StaleSince(cl))
+
+	return e.render.RenderDetails(r.Context(), w, RenderDetailsArgs{
+		CL:          loaded.CL,
+		ReleaseNote: loaded.Note,
+		FeedURL:     handler.GetFeedURL(r),
+		AtomFeedURL: handler.GetAtomFeedURL(r),
+		JSONFeedURL: handler.GetJSONFeedURL(r),
+		Prev:        loaded.Prev,
+		Next:        loaded.Next,
+		HasMetaKey:  requestFromMac(r.Header),
+	})
 }
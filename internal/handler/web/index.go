@@ -3,9 +3,11 @@ package web
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/jonashiltl/openchangelog/components"
 	"github.com/jonashiltl/openchangelog/internal"
@@ -17,6 +19,9 @@ import (
 	"github.com/jonashiltl/openchangelog/internal/load"
 )
 
+// How long pages of a changelog can be cached by clients.
+const defaultMaxAge = 5 * time.Minute
+
 func index(e *env, w http.ResponseWriter, r *http.Request) error {
 	q := r.URL.Query()
 	page, pageSize := handler.ParsePagination(q)
@@ -49,11 +54,12 @@ func index(e *env, w http.ResponseWriter, r *http.Request) error {
 		}
 	}
 
+	handler.SetStaleHeader(w, e.loader.StaleSince(loaded.CL))
 	if _, ok := q["articles"]; ok {
 		return handleArticles(e, w, r.Context(), loaded, page, pageSize)
 	}
 
-	setCacheControlHeader(r, w, loaded.CL.Protected)
+	setCacheControlHeader(r, w, loaded.CL.Protected, e.loader.NextPublish(loaded.CL.ID))
 	return renderChangelog(e, w, r, loaded, isWidget)
 }
 
@@ -90,16 +96,28 @@ func handleArticles(
 	}
 }
 
-func setCacheControlHeader(r *http.Request, w http.ResponseWriter, isProtected bool) {
+// Sets the Cache-Control header, the max-age never outlives nextPublish,
+// so the next scheduled release note shows up once it's published.
+func setCacheControlHeader(r *http.Request, w http.ResponseWriter, isProtected bool, nextPublish time.Time) {
 	if strings.Contains(r.Host, "localhost") {
 		return
 	}
 
+	maxAge := cacheMaxAge(nextPublish, time.Now())
 	if isProtected {
-		w.Header().Set("Cache-Control", "private,max-age=300")
+		w.Header().Set("Cache-Control", fmt.Sprintf("private,max-age=%d", maxAge))
 	} else {
-		w.Header().Set("Cache-Control", "public,max-age=300")
+		w.Header().Set("Cache-Control", fmt.Sprintf("public,max-age=%d", maxAge))
+	}
+}
+
+// Returns the max-age in seconds, capped to the time until nextPublish if it's set.
+func cacheMaxAge(nextPublish time.Time, now time.Time) int {
+	maxAge := defaultMaxAge
+	if !nextPublish.IsZero() {
+		maxAge = min(maxAge, nextPublish.Sub(now))
 	}
+	return int(max(maxAge, 0).Seconds())
 }
 
 func renderChangelog(
@@ -111,6 +129,8 @@ func renderChangelog(
 ) error {
 	args := RenderChangelogArgs{
 		FeedURL:      handler.GetFeedURL(r),
+		AtomFeedURL:  handler.GetAtomFeedURL(r),
+		JSONFeedURL:  handler.GetJSONFeedURL(r),
 		CurrentURL:   handler.GetFullURL(r),
 		CL:           loaded.CL,
 		ReleaseNotes: loaded.Notes,
@@ -0,0 +1,45 @@
+package web
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCacheMaxAge(t *testing.T) {
+	now := time.Now()
+
+	tables := []struct {
+		name        string
+		nextPublish time.Time
+		expected    int
+	}{
+		{
+			name:     "nothing scheduled",
+			expected: 300,
+		},
+		{
+			name:        "scheduled after default max age",
+			nextPublish: now.Add(time.Hour),
+			expected:    300,
+		},
+		{
+			name:        "scheduled before default max age",
+			nextPublish: now.Add(time.Minute),
+			expected:    60,
+		},
+		{
+			name:        "scheduled in the past",
+			nextPublish: now.Add(-time.Minute),
+			expected:    0,
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			got := cacheMaxAge(table.nextPublish, now)
+			if got != table.expected {
+				t.Errorf("expected max-age %d but got %d", table.expected, got)
+			}
+		})
+	}
+}
@@ -43,9 +43,12 @@ func passwordSubmit(e *env, w http.ResponseWriter, r *http.Request) error {
 	// the hashed password does not add any actual security, but we do it for
 	// obfuscation purposes
 	setProtectedCookie(r, w, loaded.CL.PasswordHash)
+	handler.SetStaleHeader(w, e.loader.StaleSince(loaded.CL))
 
 	return e.render.RenderChangelog(r.Context(), w, RenderChangelogArgs{
 		FeedURL:      handler.GetFeedURL(r),
+		AtomFeedURL:  handler.GetAtomFeedURL(r),
+		JSONFeedURL:  handler.GetJSONFeedURL(r),
 		CurrentURL:   handler.GetFullURL(r),
 		CL:           loaded.CL,
 		ReleaseNotes: loaded.Notes,
@@ -0,0 +1,44 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/internal/errs"
+	"github.com/jonashiltl/openchangelog/internal/preview"
+)
+
+// Renders a release note opened with a signed preview link, drafts and scheduled release notes included.
+// The link grants access to protected changelogs, previews aren't cached, indexed or tracked by analytics.
+func previewDetails(e *env, w http.ResponseWriter, r *http.Request) error {
+	if !e.cfg.HasPreviews() {
+		return errs.NewNotFound(errors.New("previews are not enabled"))
+	}
+
+	noteID := r.PathValue("nid")
+	cl, err := e.loader.GetChangelog(r)
+	if err != nil {
+		return err
+	}
+
+	err = preview.Verify(e.cfg.Preview.Secret, cl.ID, noteID, r.URL.Query(), time.Now())
+	if err != nil {
+		return err
+	}
+
+	loaded, err := e.loader.LoadPreview(r.Context(), cl, noteID)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Cache-Control", "private,no-store")
+	w.Header().Set("X-Robots-Tag", "noindex")
+
+	return e.render.RenderDetails(r.Context(), w, RenderDetailsArgs{
+		CL:          loaded.CL,
+		ReleaseNote: loaded.Note,
+		HasMetaKey:  requestFromMac(r.Header),
+		Preview:     true,
+	})
+}
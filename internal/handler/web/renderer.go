@@ -27,6 +27,8 @@ type RenderChangelogArgs struct {
 	HasMore      bool
 	CurrentURL   string
 	FeedURL      string
+	AtomFeedURL  string
+	JSONFeedURL  string
 	HasMetaKey   bool
 }
 
@@ -45,7 +47,11 @@ type RenderDetailsArgs struct {
 	Prev        parse.ParsedReleaseNote
 	Next        parse.ParsedReleaseNote
 	FeedURL     string
+	AtomFeedURL string
+	JSONFeedURL string
 	HasMetaKey  bool
+	// Renders the release note with a preview banner and without search and feeds.
+	Preview bool
 }
 
 func NewRenderer(cfg config.Config) Renderer {
@@ -79,6 +85,7 @@ func (r *renderer) RenderChangelog(ctx context.Context, w io.Writer, args Render
 			Active:     true,
 			HasMetaKey: args.HasMetaKey,
 		},
+		ShowSubscribe: r.cfg.HasSubscriptions(),
 		ChangelogContainerArgs: components.ChangelogContainerArgs{
 			CurrentURL:     args.CurrentURL,
 			HasMoreArticle: args.HasMore,
@@ -87,6 +94,12 @@ func (r *renderer) RenderChangelog(ctx context.Context, w io.Writer, args Render
 			Title:       args.CL.Title.V(),
 			Description: args.CL.Subtitle.V(),
 			CSS:         r.css,
+			FeedLinks: components.FeedLinksArgs{
+				Title:   args.CL.Title.V(),
+				RSSURL:  args.FeedURL,
+				AtomURL: args.AtomFeedURL,
+				JSONURL: args.JSONFeedURL,
+			},
 		},
 		ThemeArgs: components.ThemeArgs{
 			ColorScheme: args.CL.ColorScheme.ToApiTypes(),
@@ -136,12 +149,17 @@ func (r *renderer) RenderDetails(ctx context.Context, w io.Writer, args RenderDe
 	articles := parsedArticlesToComponentArticles([]parse.ParsedReleaseNote{
 		args.ReleaseNote, args.Prev, args.Next,
 	})
+	var banner *components.PreviewBannerArgs
+	if args.Preview {
+		banner = &components.PreviewBannerArgs{Draft: args.ReleaseNote.Meta.Draft}
+	}
 	return views.Details(views.DetailsArgs{
 		RSSArgs: components.RSSArgs{
 			FeedURL: args.FeedURL,
 		},
-		ShowSearchButton: args.CL.Searchable,
-		HideRssIcon:      args.CL.HideRssIcon,
+		ShowSearchButton: args.CL.Searchable && !args.Preview,
+		HideRssIcon:      args.CL.HideRssIcon || args.Preview,
+		Preview:          banner,
 		SearchButtonArgs: components.SearchButtonArgs{
 			Active:     true,
 			HasMetaKey: args.HasMetaKey,
@@ -150,6 +168,13 @@ func (r *renderer) RenderDetails(ctx context.Context, w io.Writer, args RenderDe
 			Title:       args.CL.Title.V(),
 			Description: args.CL.Subtitle.V(),
 			CSS:         r.css,
+			FeedLinks: components.FeedLinksArgs{
+				Title:   args.CL.Title.V(),
+				RSSURL:  args.FeedURL,
+				AtomURL: args.AtomFeedURL,
+				JSONURL: args.JSONFeedURL,
+			},
+			NoIndex: args.Preview,
 		},
 		HeaderArgs: components.HeaderArgs{
 			Title:    args.CL.Title,
@@ -184,6 +209,11 @@ func parsedArticlesToComponentArticles(parsed []parse.ParsedReleaseNote) []compo
 			Description: a.Meta.Description,
 			PublishedAt: a.Meta.PublishedAt,
 			Tags:        a.Meta.Tags,
+			Version:     a.Meta.Version,
+			Authors:     a.Meta.Authors,
+			Image:       a.Meta.Image,
+			Draft:       a.Meta.Draft,
+			Pinned:      a.Meta.Pinned,
 		}
 		if a.Content != nil {
 			buf := new(strings.Builder)
@@ -15,6 +15,7 @@ import (
 	"github.com/jonashiltl/openchangelog/internal/parse"
 	"github.com/jonashiltl/openchangelog/internal/search"
 	"github.com/jonashiltl/openchangelog/internal/store"
+	"github.com/jonashiltl/openchangelog/internal/subscribe"
 	"github.com/jonashiltl/openchangelog/internal/xlog"
 	"golang.org/x/exp/slog"
 )
@@ -22,9 +23,14 @@ import (
 func RegisterWebHandler(mux *http.ServeMux, e *env) {
 	mux.HandleFunc("GET /", serveHTTP(e, index))
 	mux.HandleFunc("GET /release/{nid}", serveHTTP(e, details))
+	mux.HandleFunc("GET /preview/{nid}", serveHTTP(e, previewDetails))
 	mux.HandleFunc("POST /password", serveHTTP(e, passwordSubmit))
 	mux.HandleFunc("POST /search", serveHTTP(e, searchSubmit))
 	mux.HandleFunc("GET /search/tags", serveHTTP(e, searchTags))
+	mux.HandleFunc("POST /subscribe", serveHTTP(e, subscribeSubmit))
+	mux.HandleFunc("GET /subscribe/confirm", serveHTTP(e, subscribeConfirm))
+	mux.HandleFunc("GET /unsubscribe", serveHTTP(e, unsubscribe))
+	mux.HandleFunc("POST /unsubscribe", serveHTTP(e, unsubscribeSubmit))
 	mux.Handle("DELETE /remove-me", serveHTTP(e, func(e *env, w http.ResponseWriter, r *http.Request) error { return nil }))
 }
 
@@ -34,23 +40,26 @@ func NewEnv(
 	parser parse.Parser,
 	render Renderer,
 	searcher search.Searcher,
+	subscriptions *subscribe.Service,
 ) *env {
 	return &env{
-		cfg:      cfg,
-		loader:   loader,
-		parser:   parser,
-		render:   render,
-		searcher: searcher,
+		cfg:           cfg,
+		loader:        loader,
+		parser:        parser,
+		render:        render,
+		searcher:      searcher,
+		subscriptions: subscriptions,
 	}
 }
 
 type env struct {
-	cfg      config.Config
-	render   Renderer
-	emitter  analytics.Emitter
-	loader   *load.Loader
-	parser   parse.Parser
-	searcher search.Searcher
+	cfg           config.Config
+	render        Renderer
+	emitter       analytics.Emitter
+	loader        *load.Loader
+	parser        parse.Parser
+	searcher      search.Searcher
+	subscriptions *subscribe.Service
 }
 
 // Returns the analytics emitter of the changelog.
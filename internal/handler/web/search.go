@@ -3,6 +3,7 @@ package web
 import (
 	"errors"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/jonashiltl/openchangelog/components"
@@ -33,8 +34,8 @@ func searchSubmit(e *env, w http.ResponseWriter, r *http.Request) error {
 		}
 	}
 
-	sid := source.NewIDFromChangelog(cl)
-	if sid == "" {
+	sids := source.NewIDsFromChangelog(cl)
+	if len(sids) == 0 {
 		return errs.NewBadRequest(errors.New("changelog has no active source"))
 	}
 
@@ -53,13 +54,16 @@ func searchSubmit(e *env, w http.ResponseWriter, r *http.Request) error {
 	}
 
 	res, err := e.searcher.Search(r.Context(), search.SearchArgs{
-		SID:   sid.String(),
+		SIDs:  sidStrings(sids),
 		Query: q,
 		Tags:  tags,
 	})
 	if err != nil {
 		return errs.NewBadRequest(err)
 	}
+	for i, hit := range res.Hits {
+		res.Hits[i].ID = source.CombinedNoteID(cl, source.ID(hit.SID), hit.ID)
+	}
 
 	return components.SearchResults(components.SearchResultsArgs{
 		Query:  q,
@@ -84,11 +88,26 @@ func searchTags(e *env, w http.ResponseWriter, r *http.Request) error {
 		}
 	}
 
-	sid := source.NewIDFromChangelog(cl)
-	if sid == "" {
+	sids := source.NewIDsFromChangelog(cl)
+	if len(sids) == 0 {
 		return errs.NewBadRequest(errors.New("changelog has no active source"))
 	}
 
-	tags := e.searcher.GetAllTags(r.Context(), sid.String())
+	var tags []string
+	for _, sid := range sids {
+		for _, t := range e.searcher.GetAllTags(r.Context(), sid.String()) {
+			if !slices.Contains(tags, t) {
+				tags = append(tags, t)
+			}
+		}
+	}
 	return components.TagSelectors(tags).Render(r.Context(), w)
 }
+
+func sidStrings(sids []source.ID) []string {
+	res := make([]string, len(sids))
+	for i, sid := range sids {
+		res[i] = sid.String()
+	}
+	return res
+}
@@ -0,0 +1,106 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/jonashiltl/openchangelog/components"
+	"github.com/jonashiltl/openchangelog/internal/errs"
+	"github.com/jonashiltl/openchangelog/internal/handler/web/static"
+	"github.com/jonashiltl/openchangelog/internal/handler/web/views"
+	"github.com/jonashiltl/openchangelog/internal/store"
+)
+
+func subscribeSubmit(e *env, w http.ResponseWriter, r *http.Request) error {
+	err := r.ParseForm()
+	if err != nil {
+		return errs.NewBadRequest(err)
+	}
+
+	cl, err := e.loader.GetChangelog(r)
+	if err != nil {
+		return err
+	}
+
+	if cl.Protected {
+		err = ensurePasswordProvided(r, cl.PasswordHash)
+		if err != nil {
+			return errs.NewUnauthorized(err)
+		}
+	}
+
+	err = e.subscriptions.Subscribe(r.Context(), cl, r.FormValue("email"))
+	if err != nil {
+		return err
+	}
+
+	return components.SubscribeSuccess().Render(r.Context(), w)
+}
+
+func subscribeConfirm(e *env, w http.ResponseWriter, r *http.Request) error {
+	cl, err := e.loader.GetChangelog(r)
+	if err != nil {
+		return err
+	}
+
+	_, err = e.subscriptions.Confirm(r.Context(), r.URL.Query().Get("token"))
+	if err != nil {
+		return err
+	}
+
+	return renderSubscription(w, r, cl, views.SubscriptionArgs{
+		Title:   "Subscription confirmed",
+		Message: "You will receive an email when new release notes are published.",
+	})
+}
+
+// Asks for confirmation before unsubscribing, since email clients might prefetch links.
+func unsubscribe(e *env, w http.ResponseWriter, r *http.Request) error {
+	cl, err := e.loader.GetChangelog(r)
+	if err != nil {
+		return err
+	}
+
+	q := r.URL.Query()
+	return renderSubscription(w, r, cl, views.SubscriptionArgs{
+		Title:          "Unsubscribe",
+		Message:        "You will no longer receive emails about new release notes.",
+		UnsubscribeSub: q.Get("sub"),
+		UnsubscribeSig: q.Get("sig"),
+	})
+}
+
+// Deletes the subscription, also handles one-click unsubscribes of email clients (RFC 8058),
+// which post to the url of the List-Unsubscribe header.
+func unsubscribeSubmit(e *env, w http.ResponseWriter, r *http.Request) error {
+	err := r.ParseForm()
+	if err != nil {
+		return errs.NewBadRequest(err)
+	}
+
+	cl, err := e.loader.GetChangelog(r)
+	if err != nil {
+		return err
+	}
+
+	// form values take precedence over the query params of the one-click url
+	err = e.subscriptions.Unsubscribe(r.Context(), r.FormValue("sub"), r.FormValue("sig"))
+	if err != nil {
+		return err
+	}
+
+	return renderSubscription(w, r, cl, views.SubscriptionArgs{
+		Title:   "Unsubscribed",
+		Message: "You will no longer receive emails about new release notes.",
+	})
+}
+
+func renderSubscription(w http.ResponseWriter, r *http.Request, cl store.Changelog, args views.SubscriptionArgs) error {
+	args.CSS = static.BaseCSS
+	args.ThemeArgs = components.ThemeArgs{
+		ColorScheme: cl.ColorScheme.ToApiTypes(),
+	}
+	args.FooterArgs = components.FooterArgs{
+		HidePoweredBy: cl.HidePoweredBy,
+	}
+	return views.Subscription(args).Render(r.Context(), w)
+}
@@ -3,8 +3,12 @@ package load
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io/fs"
 	"log/slog"
 	"net/http"
+	"slices"
+	"sync"
 	"time"
 
 	mint "github.com/btvoidx/mint/context"
@@ -25,31 +29,75 @@ type LoadedChangelog struct {
 	HasMore bool
 }
 
+type LoadedReleaseNote struct {
+	CL   store.Changelog
+	Note parse.ParsedReleaseNote
+	// The previous (newer) release note, zero value if Note is the first one.
+	Prev parse.ParsedReleaseNote
+	// The next (older) release note, zero value if Note is the last one.
+	Next parse.ParsedReleaseNote
+}
+
 // Creates a new Loader.
 func NewLoader(
 	cfg config.Config,
 	store store.Store,
 	cache xcache.Cache,
-	parser parse.Parser,
+	parser *parse.Cache,
 	e *mint.Emitter,
 ) *Loader {
+	var stale *staleStore
+	if cfg.Cache != nil && cfg.Cache.StaleIfError {
+		stale = newStaleStore(cache)
+	}
 	return &Loader{
-		cfg:    cfg,
-		store:  store,
-		cache:  cache,
-		parser: parser,
-		e:      e,
+		cfg:       cfg,
+		store:     store,
+		cache:     cache,
+		parser:    parser,
+		e:         e,
+		publisher: newPublisher(e),
+		stale:     stale,
 	}
 }
 
 // The loader combines the source and parse package.
 // It first loads the raw release notes using the source package and then parses it using the parse package.
 type Loader struct {
-	cfg    config.Config
-	store  store.Store
-	cache  xcache.Cache
-	parser parse.Parser
-	e      *mint.Emitter
+	cfg       config.Config
+	store     store.Store
+	cache     xcache.Cache
+	parser    *parse.Cache
+	e         *mint.Emitter
+	publisher *publisher
+	// nil if stale-if-error is disabled
+	stale *staleStore
+}
+
+// Stops emitting ReleaseNotePublished events for scheduled release notes
+// and retrying failed sources.
+func (l *Loader) Close() {
+	l.publisher.close()
+	l.stale.close()
+}
+
+// Returns since when stale release notes are served for the changelog because one of it's sources fails to load,
+// zero if all sources were loaded successfully the last time.
+func (l *Loader) StaleSince(cl store.Changelog) time.Time {
+	var since time.Time
+	for _, sid := range source.NewIDsFromChangelog(cl) {
+		s := l.stale.since(sid)
+		if !s.IsZero() && (since.IsZero() || s.Before(since)) {
+			since = s
+		}
+	}
+	return since
+}
+
+// Returns when the next scheduled release note of the changelog is published,
+// zero if no loaded release note is scheduled.
+func (l *Loader) NextPublish(cid store.ChangelogID) time.Time {
+	return l.publisher.next(cid)
 }
 
 // Returns the changelog of the request.
@@ -66,7 +114,7 @@ func (l *Loader) GetChangelog(r *http.Request) (store.Changelog, error) {
 }
 
 // Loads the changelog and parses it's release notes for the specified http request.
-// Release notes scheduled to be published in the future are excluded from the result.
+// Drafts and release notes scheduled to be published in the future are excluded from the result.
 func (l *Loader) LoadAndParse(r *http.Request, page internal.Pagination) (LoadedChangelog, error) {
 	cl, err := l.GetChangelog(r)
 	if err != nil {
@@ -82,13 +130,13 @@ func (l *Loader) LoadAndParse(r *http.Request, page internal.Pagination) (Loaded
 	return loaded, nil
 }
 
-// Filters out release notes with a publishedAt date in the future,
-// so they stay hidden until that date/time arrives.
+// Filters out drafts and release notes with a publishedAt date in the future,
+// so they stay hidden until they are published. Both can still be viewed with a preview link.
 func removeUnpublished(notes []parse.ParsedReleaseNote) []parse.ParsedReleaseNote {
 	now := time.Now()
 	published := notes[:0]
 	for _, n := range notes {
-		if !n.Meta.PublishedAt.After(now) {
+		if n.Meta.Published(now) {
 			published = append(published, n)
 		}
 	}
@@ -96,36 +144,386 @@ func removeUnpublished(notes []parse.ParsedReleaseNote) []parse.ParsedReleaseNot
 }
 
 // Loads and parses the release notes for the specified changelog.
+// Remembers the loaded release notes scheduled to be published in the future,
+// to emit a ReleaseNotePublished event once they are published.
 func (l *Loader) LoadAndParseReleaseNotes(ctx context.Context, cl store.Changelog, page internal.Pagination) (LoadedChangelog, error) {
-	s, err := source.NewSourceFromStore(l.cfg, cl, l.cache)
+	sources, err := source.NewSourcesFromStore(l.cfg, cl, l.cache)
+	if err != nil {
+		return LoadedChangelog{}, err
+	}
+
+	if len(sources) > 1 {
+		return l.loadCombined(ctx, cl, sources, page)
+	}
+
+	parsed, err := l.loadAndParse(ctx, cl, sources[0], page)
 	if err != nil {
 		return LoadedChangelog{}, err
 	}
+	return LoadedChangelog{
+		CL:      cl,
+		Notes:   parsed.ReleaseNotes,
+		HasMore: parsed.HasMore,
+	}, nil
+}
+
+// Loads the release notes of all sources concurrently and merges them by their publishedAt, newest and pinned first.
+// The ids of the release notes are prefixed with their source, to avoid collisions between sources.
+// All release notes of every source are loaded, so pinned release notes are found on any page.
+func (l *Loader) loadCombined(ctx context.Context, cl store.Changelog, sources []source.Source, page internal.Pagination) (LoadedChangelog, error) {
+	results := make([]parse.ParseResult, len(sources))
+	loadErrs := make([]error, len(sources))
+	var wg sync.WaitGroup
+	for i, s := range sources {
+		wg.Add(1)
+		go func(i int, s source.Source) {
+			defer wg.Done()
+			results[i], loadErrs[i] = l.loadAndParse(ctx, cl, s, internal.NoPagination())
+		}(i, s)
+	}
+	wg.Wait()
+
+	if err := errors.Join(loadErrs...); err != nil {
+		return LoadedChangelog{}, err
+	}
+
+	var notes []parse.ParsedReleaseNote
+	hasMore := false
+	for i, res := range results {
+		for _, n := range res.ReleaseNotes {
+			n.Meta.ID = source.CombinedNoteID(cl, sources[i].ID(), n.Meta.ID)
+			notes = append(notes, n)
+		}
+		hasMore = hasMore || res.HasMore
+	}
+	// stable, so release notes published at the same time keep the order of the sources
+	slices.SortStableFunc(notes, func(a, b parse.ParsedReleaseNote) int {
+		if c := parse.ComparePinned(a, b); c != 0 {
+			return c
+		}
+		return b.Meta.PublishedAt.Compare(a.Meta.PublishedAt)
+	})
+
+	if page.IsDefined() {
+		start := min(page.StartIdx(), len(notes))
+		end := min(start+page.PageSize(), len(notes))
+		hasMore = hasMore || end < len(notes)
+		notes = notes[start:end]
+	}
+
+	return LoadedChangelog{
+		CL:      cl,
+		Notes:   notes,
+		HasMore: hasMore,
+	}, nil
+}
 
-	if s != nil {
-		loaded, err := s.Load(ctx, page)
+// Loads and parses the release notes of a single source of the changelog.
+// The source is always loaded completely and paginated after sorting,
+// otherwise pinned release notes of later pages would never be displayed first.
+func (l *Loader) loadAndParse(ctx context.Context, cl store.Changelog, s source.Source, page internal.Pagination) (parse.ParseResult, error) {
+	loaded, err := l.load(ctx, cl, s, internal.NoPagination())
+	if err != nil {
+		return parse.ParseResult{}, err
+	}
+	parsed := l.parser.Parse(ctx, s.ID(), loaded.Raw, internal.NoPagination())
+	l.publisher.track(cl, s, parsed.ReleaseNotes, true)
+
+	failed := slices.Concat(loaded.Errors, parsed.Errors)
+	logFileErrors(s.ID(), failed)
+
+	notes := parsed.ReleaseNotes
+	// keep-a-changelog files can't pin releases, so pinned release notes only need to be moved up in directories
+	slices.SortStableFunc(notes, parse.ComparePinned)
+	hasMore := false
+	if page.IsDefined() {
+		start := min(page.StartIdx(), len(notes))
+		end := min(start+max(page.PageSize(), 0), len(notes))
+		hasMore = end < len(notes)
+		notes = notes[start:end]
+	}
+	return parse.ParseResult{
+		ReleaseNotes: notes,
+		HasMore:      hasMore,
+		Errors:       failed,
+	}, nil
+}
+
+// Logs the files of the source that failed to be loaded or parsed.
+// They are left out of the changelog, so visitors only see the release notes that could be loaded.
+func logFileErrors(sid source.ID, failed []source.FileError) {
+	for _, f := range failed {
+		slog.Warn("failed to load release note",
+			slog.String("sid", sid.String()),
+			slog.String("path", f.Path),
+			xlog.ErrAttr(f.Err),
+		)
+	}
+}
+
+// Reports which release notes of a source failed to be loaded.
+type SourceHealth struct {
+	Source source.ID
+	// Set if the source couldn't be loaded at all.
+	Err error
+	// The files that failed to be loaded or parsed.
+	Errors []source.FileError
+	// The number of release notes that were loaded successfully.
+	ReleaseNotes int
+	// Set if the source fails to load and the last successfully loaded release notes are served instead.
+	StaleSince time.Time
+}
+
+func (h SourceHealth) Healthy() bool {
+	return h.Err == nil && len(h.Errors) == 0 && h.StaleSince.IsZero()
+}
+
+// Loads and parses all release notes of every source of the changelog
+// and reports the sources and files that failed to be loaded, in the order of the sources.
+func (l *Loader) CheckHealth(ctx context.Context, cl store.Changelog) ([]SourceHealth, error) {
+	sources, err := source.NewSourcesFromStore(l.cfg, cl, l.cache)
+	if err != nil {
+		return nil, err
+	}
+
+	health := make([]SourceHealth, len(sources))
+	var wg sync.WaitGroup
+	for i, s := range sources {
+		wg.Add(1)
+		go func(i int, s source.Source) {
+			defer wg.Done()
+			parsed, err := l.loadAndParse(ctx, cl, s, internal.NoPagination())
+			health[i] = SourceHealth{
+				Source:       s.ID(),
+				Err:          err,
+				Errors:       parsed.Errors,
+				ReleaseNotes: len(parsed.ReleaseNotes),
+				StaleSince:   l.stale.since(s.ID()),
+			}
+		}(i, s)
+	}
+	wg.Wait()
+	return health, nil
+}
+
+// Loads and parses a single release note of the changelog, together with it's neighbours.
+// Uses the index of the source to only load the required release notes,
+// falls back to loading all release notes if the index doesn't exist yet or is outdated.
+// Drafts and release notes scheduled to be published in the future can't be loaded, see LoadPreview.
+func (l *Loader) LoadReleaseNote(ctx context.Context, cl store.Changelog, id string) (LoadedReleaseNote, error) {
+	sources, err := source.NewSourcesFromStore(l.cfg, cl, l.cache)
+	if err != nil {
+		return LoadedReleaseNote{}, err
+	}
+
+	if len(sources) > 1 {
+		// the neighbours can be part of any source, so all release notes are loaded
+		loaded, err := l.loadCombined(ctx, cl, sources, internal.NoPagination())
 		if err != nil {
-			return LoadedChangelog{}, err
-		}
-		// emit event if release notes have changed
-		if loaded.HasChanged() {
-			err = mint.Emit(l.e, ctx, events.SourceContentChanged{
-				CL:     cl,
-				Source: s,
-			})
+			return LoadedReleaseNote{}, err
+		}
+		return findReleaseNote(cl, removeUnpublished(loaded.Notes), id)
+	}
+
+	s := sources[0]
+	if idx, ok := l.parser.GetIndex(s.ID()); ok {
+		loaded, found, err := l.loadFromIndex(ctx, cl, s, idx, id)
+		if err != nil {
+			return LoadedReleaseNote{}, err
+		}
+		if found {
+			return loaded, nil
+		}
+	}
+
+	loaded, err := l.load(ctx, cl, s, internal.NoPagination())
+	if err != nil {
+		return LoadedReleaseNote{}, err
+	}
+	parsed, _ := l.parser.ParseIndexed(ctx, s.ID(), loaded.Raw)
+	l.publisher.track(cl, s, parsed.ReleaseNotes, true)
+	logFileErrors(s.ID(), slices.Concat(loaded.Errors, parsed.Errors))
+	return findReleaseNote(cl, removeUnpublished(parsed.ReleaseNotes), id)
+}
+
+// Loads a single release note of the changelog, including drafts and release notes scheduled to be published in the future.
+// Used to render previews, so the neighbours aren't loaded.
+func (l *Loader) LoadPreview(ctx context.Context, cl store.Changelog, id string) (LoadedReleaseNote, error) {
+	loaded, err := l.LoadAndParseReleaseNotes(ctx, cl, internal.NoPagination())
+	if err != nil {
+		return LoadedReleaseNote{}, err
+	}
+	for _, note := range loaded.Notes {
+		if note.Meta.ID == id {
+			return LoadedReleaseNote{CL: cl, Note: note}, nil
+		}
+	}
+	return LoadedReleaseNote{}, errs.NewNotFound(fmt.Errorf("release note %s not found", id))
+}
+
+// Returns the release note with id together with it's neighbours, notes must be sorted newest first.
+func findReleaseNote(cl store.Changelog, notes []parse.ParsedReleaseNote, id string) (LoadedReleaseNote, error) {
+	for i, note := range notes {
+		if note.Meta.ID != id {
+			continue
+		}
+		res := LoadedReleaseNote{CL: cl, Note: note}
+		if i > 0 {
+			res.Prev = notes[i-1]
+		}
+		if i < len(notes)-1 {
+			res.Next = notes[i+1]
+		}
+		return res, nil
+	}
+
+	return LoadedReleaseNote{}, errs.NewNotFound(fmt.Errorf("release note %s not found", id))
+}
+
+// Loads the release note with id and it's neighbours using the index.
+// Returns false if the index doesn't contain the release note or is outdated.
+func (l *Loader) loadFromIndex(ctx context.Context, cl store.Changelog, s source.Source, idx parse.Index, id string) (LoadedReleaseNote, bool, error) {
+	cur, prev, next, ok := idx.Find(id, time.Now())
+	if !ok {
+		return LoadedReleaseNote{}, false, nil
+	}
+
+	entries := []*parse.IndexEntry{prev, &cur, next}
+	notes := make([]parse.ParsedReleaseNote, len(entries))
+	found := make([]bool, len(entries))
+	loadErrs := make([]error, len(entries))
+
+	var wg sync.WaitGroup
+	for i, e := range entries {
+		if e == nil {
+			found[i] = true
+			continue
+		}
+		wg.Add(1)
+		go func(i int, e parse.IndexEntry) {
+			defer wg.Done()
+			notes[i], found[i], loadErrs[i] = l.loadEntry(ctx, cl, s, idx, e)
+		}(i, *e)
+	}
+	wg.Wait()
+
+	for i := range entries {
+		if loadErrs[i] != nil {
+			return LoadedReleaseNote{}, false, loadErrs[i]
+		}
+		if !found[i] {
+			return LoadedReleaseNote{}, false, nil
+		}
+	}
+
+	return LoadedReleaseNote{
+		CL:   cl,
+		Prev: notes[0],
+		Note: notes[1],
+		Next: notes[2],
+	}, true, nil
+}
+
+// Loads and parses only the release note of the index entry.
+// Returns false if the loaded release note doesn't match the entry.
+func (l *Loader) loadEntry(ctx context.Context, cl store.Changelog, s source.Source, idx parse.Index, e parse.IndexEntry) (parse.ParsedReleaseNote, bool, error) {
+	// single files are loaded completely and paginated by the keep-a-changelog parser,
+	// files of a directory are paginated by the source.
+	loadPage, parsePage := idx.Page(e), internal.NoPagination()
+	if idx.SingleFile {
+		loadPage, parsePage = internal.NoPagination(), idx.Page(e)
+	}
+
+	loaded, err := l.load(ctx, cl, s, loadPage)
+	if err != nil {
+		return parse.ParsedReleaseNote{}, false, err
+	}
+	if len(loaded.Raw) != 1 {
+		return parse.ParsedReleaseNote{}, false, nil
+	}
+
+	parsed := l.parser.Parse(ctx, s.ID(), loaded.Raw, parsePage)
+	for _, note := range parsed.ReleaseNotes {
+		if note.Meta.ID == e.ID {
+			return note, true, nil
+		}
+	}
+	return parse.ParsedReleaseNote{}, false, nil
+}
+
+// Loads the raw release notes of the source and emits a SourceContentChanged event if they have changed.
+// If stale-if-error is enabled and the source fails to load, the last successfully loaded release notes are returned
+// and the source is retried in the background.
+func (l *Loader) load(ctx context.Context, cl store.Changelog, s source.Source, page internal.Pagination) (source.LoadResult, error) {
+	loaded, err := l.loadLive(ctx, cl, s, page)
+	if err == nil || errors.Is(err, fs.ErrNotExist) || errors.Is(err, context.Canceled) {
+		// deleted sources aren't served stale
+		return loaded, err
+	}
+
+	stale, ok := l.stale.fail(s.ID(), page)
+	if !ok {
+		return source.LoadResult{}, err
+	}
+	slog.Warn("failed to load source, serving stale release notes", slog.String("sid", s.ID().String()), xlog.ErrAttr(err))
+	l.stale.retry(s.ID(), func() error {
+		_, err := l.loadLive(context.Background(), cl, s, internal.NoPagination())
+		return err
+	})
+	return stale, nil
+}
+
+func (l *Loader) loadLive(ctx context.Context, cl store.Changelog, s source.Source, page internal.Pagination) (source.LoadResult, error) {
+	loaded, err := s.Load(ctx, page)
+	if err != nil {
+		return source.LoadResult{}, err
+	}
+	loaded = l.stale.set(s.ID(), page, loaded)
+	if loaded.HasChanged() {
+		err = mint.Emit(l.e, ctx, events.SourceContentChanged{
+			CL:     cl,
+			Source: s,
+		})
+		if err != nil {
+			slog.Debug("failed to emit source changed event", xlog.ErrAttr(err))
+		}
+	}
+	return loaded, nil
+}
+
+// Loads the sources of the changelog and emits a SourceContentChanged event for each source whose content changed.
+// If force is true, the source is loaded bypassing the cache and the event is always emitted.
+func (l *Loader) RefreshSource(ctx context.Context, cl store.Changelog, force bool) error {
+	cache := l.cache
+	if force {
+		cache = nil
+	}
+
+	sources, err := source.NewSourcesFromStore(l.cfg, cl, cache)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range sources {
+		if !force {
+			loaded, err := s.Load(ctx, internal.NoPagination())
 			if err != nil {
-				slog.Debug("failed to emit source changed event", xlog.ErrAttr(err))
+				return err
+			}
+			if !loaded.HasChanged() {
+				continue
 			}
 		}
-		parsed := l.parser.Parse(ctx, loaded.Raw, page)
-		return LoadedChangelog{
-			CL:      cl,
-			Notes:   parsed.ReleaseNotes,
-			HasMore: loaded.HasMore || parsed.HasMore,
-		}, nil
-	}
 
-	return LoadedChangelog{CL: cl}, nil
+		err = mint.Emit(l.e, ctx, events.SourceContentChanged{
+			CL:     cl,
+			Source: s,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (l *Loader) fromHost(ctx context.Context, host string) (store.Changelog, error) {
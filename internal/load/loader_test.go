@@ -1,10 +1,23 @@
 package load
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
 	"testing"
 	"time"
 
+	mint "github.com/btvoidx/mint/context"
+	"github.com/guregu/null/v5"
+	"github.com/jonashiltl/openchangelog/internal"
+	"github.com/jonashiltl/openchangelog/internal/config"
+	"github.com/jonashiltl/openchangelog/internal/events"
 	"github.com/jonashiltl/openchangelog/internal/parse"
+	"github.com/jonashiltl/openchangelog/internal/source"
+	"github.com/jonashiltl/openchangelog/internal/store"
+	"github.com/jonashiltl/openchangelog/internal/xcache"
 )
 
 func TestRemoveUnpublished(t *testing.T) {
@@ -24,6 +37,15 @@ func TestRemoveUnpublished(t *testing.T) {
 			},
 			expected: []string{"past", "no-date"},
 		},
+		{
+			name: "drops drafts",
+			notes: []parse.ParsedReleaseNote{
+				{Meta: parse.Meta{ID: "draft", PublishedAt: now.Add(-time.Hour), Draft: true}},
+				{Meta: parse.Meta{ID: "past", PublishedAt: now.Add(-time.Hour)}},
+				{Meta: parse.Meta{ID: "unreleased", Draft: true}},
+			},
+			expected: []string{"past"},
+		},
 		{
 			name:     "empty input",
 			notes:    []parse.ParsedReleaseNote{},
@@ -53,3 +75,428 @@ func TestRemoveUnpublished(t *testing.T) {
 		})
 	}
 }
+
+func copyTestNote(t *testing.T, dir string, file string) {
+	b, err := os.ReadFile(filepath.Join("../../.testdata", file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(dir, file), b, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadReleaseNote(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []string{"v0.0.1-commonmark.md", "v0.0.2-open-source.md", "v0.0.5-beta.md"} {
+		copyTestNote(t, dir, f)
+	}
+
+	cfg := config.Config{Local: &config.LocalConfig{FilesPath: dir}}
+	st := store.NewConfigStore(cfg)
+	cache := xcache.NewMemoryCache()
+	parsed := parse.NewCache(parse.NewParser(parse.CreateGoldmark()), cache)
+	l := NewLoader(cfg, st, cache, parsed, new(mint.Emitter))
+
+	ctx := context.Background()
+	cl, err := st.GetChangelog(ctx, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	all, err := l.LoadAndParseReleaseNotes(ctx, cl, internal.NoPagination())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all.Notes) != 3 {
+		t.Fatalf("expected 3 release notes but got %d", len(all.Notes))
+	}
+
+	assertNote := func(t *testing.T, notes []parse.ParsedReleaseNote, i int) {
+		loaded, err := l.LoadReleaseNote(ctx, cl, notes[i].Meta.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if loaded.Note.Meta.ID != notes[i].Meta.ID {
+			t.Errorf("expected note %s but got %s", notes[i].Meta.ID, loaded.Note.Meta.ID)
+		}
+		var expectedPrev, expectedNext string
+		if i > 0 {
+			expectedPrev = notes[i-1].Meta.ID
+		}
+		if i < len(notes)-1 {
+			expectedNext = notes[i+1].Meta.ID
+		}
+		if loaded.Prev.Meta.ID != expectedPrev {
+			t.Errorf("expected prev %q but got %q", expectedPrev, loaded.Prev.Meta.ID)
+		}
+		if loaded.Next.Meta.ID != expectedNext {
+			t.Errorf("expected next %q but got %q", expectedNext, loaded.Next.Meta.ID)
+		}
+	}
+
+	t.Run("builds index", func(t *testing.T) {
+		assertNote(t, all.Notes, 1)
+		s, err := source.NewSourceFromStore(cfg, cl, cache)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := parsed.GetIndex(s.ID()); !ok {
+			t.Errorf("expected index to be built")
+		}
+	})
+
+	t.Run("uses index", func(t *testing.T) {
+		for i := range all.Notes {
+			assertNote(t, all.Notes, i)
+		}
+	})
+
+	t.Run("outdated index", func(t *testing.T) {
+		copyTestNote(t, dir, "v0.0.6-rss.md")
+		updated, err := l.LoadAndParseReleaseNotes(ctx, cl, internal.NoPagination())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range updated.Notes {
+			assertNote(t, updated.Notes, i)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := l.LoadReleaseNote(ctx, cl, "unknown")
+		if err == nil {
+			t.Errorf("expected error for unknown release note")
+		}
+	})
+}
+
+func TestLoadPreview(t *testing.T) {
+	dir := t.TempDir()
+	copyTestNote(t, dir, "v0.0.1-commonmark.md")
+	future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	files := map[string]string{
+		"draft.md":     "---\ntitle: Draft\nslug: draft\ndraft: true\n---\nContent",
+		"scheduled.md": "---\ntitle: Scheduled\nslug: scheduled\npublishedAt: " + future + "\n---\nContent",
+	}
+	for name, content := range files {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg := config.Config{Local: &config.LocalConfig{FilesPath: dir}}
+	st := store.NewConfigStore(cfg)
+	cache := xcache.NewMemoryCache()
+	parsed := parse.NewCache(parse.NewParser(parse.CreateGoldmark()), cache)
+	l := NewLoader(cfg, st, cache, parsed, new(mint.Emitter))
+	defer l.Close()
+
+	ctx := context.Background()
+	cl, err := st.GetChangelog(ctx, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []string{"draft", "scheduled"} {
+		t.Run(id, func(t *testing.T) {
+			_, err := l.LoadReleaseNote(ctx, cl, id)
+			if err == nil {
+				t.Errorf("expected %s to be hidden", id)
+			}
+
+			loaded, err := l.LoadPreview(ctx, cl, id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if loaded.Note.Meta.ID != id {
+				t.Errorf("expected note %s but got %s", id, loaded.Note.Meta.ID)
+			}
+		})
+	}
+}
+
+func TestNextPublish(t *testing.T) {
+	dir := t.TempDir()
+	copyTestNote(t, dir, "v0.0.1-commonmark.md")
+	publishedAt := time.Now().Add(500 * time.Millisecond).Truncate(time.Second).Add(time.Second)
+	err := os.WriteFile(
+		filepath.Join(dir, "scheduled.md"),
+		[]byte("---\ntitle: scheduled\npublishedAt: "+publishedAt.Format(time.RFC3339)+"\n---\nscheduled"),
+		0644,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.Config{Local: &config.LocalConfig{FilesPath: dir}}
+	st := store.NewConfigStore(cfg)
+	cache := xcache.NewMemoryCache()
+	e := new(mint.Emitter)
+	l := NewLoader(cfg, st, cache, parse.NewCache(parse.NewParser(parse.CreateGoldmark()), cache), e)
+	defer l.Close()
+
+	published := make(chan events.ReleaseNotePublished, 1)
+	off := mint.On(e, func(_ context.Context, e events.ReleaseNotePublished) {
+		published <- e
+	})
+	defer off()
+
+	ctx := context.Background()
+	cl, err := st.GetChangelog(ctx, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if next := l.NextPublish(cl.ID); !next.IsZero() {
+		t.Errorf("expected no next publish before loading, got %s", next)
+	}
+
+	_, err = l.LoadAndParseReleaseNotes(ctx, cl, internal.NoPagination())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next := l.NextPublish(cl.ID); !next.Equal(publishedAt) {
+		t.Errorf("expected next publish %s, got %s", publishedAt, next)
+	}
+
+	select {
+	case e := <-published:
+		if e.Note.Title != "scheduled" {
+			t.Errorf("expected scheduled release note to be published, got %s", e.Note.Title)
+		}
+		if time.Now().Before(publishedAt) {
+			t.Errorf("expected event after %s", publishedAt)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for release note published event")
+	}
+
+	if next := l.NextPublish(cl.ID); !next.IsZero() {
+		t.Errorf("expected no next publish after publishing, got %s", next)
+	}
+}
+
+// Writes a release note with title, published on the day of January 2024.
+// Local sources are sorted by file name, so the day is used as prefix.
+func writeDatedNote(t *testing.T, dir, title string, day int) {
+	publishedAt := time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC)
+	err := os.WriteFile(
+		filepath.Join(dir, fmt.Sprintf("%02d-%s.md", day, title)),
+		[]byte("---\ntitle: "+title+"\npublishedAt: "+publishedAt.Format(time.RFC3339)+"\n---\n"+title),
+		0644,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadCombined(t *testing.T) {
+	dirA, dirB := t.TempDir(), t.TempDir()
+	writeDatedNote(t, dirA, "a1", 5)
+	writeDatedNote(t, dirA, "a2", 3)
+	writeDatedNote(t, dirA, "a3", 1)
+	writeDatedNote(t, dirB, "b1", 4)
+	writeDatedNote(t, dirB, "b2", 2)
+	// same publishedAt as a3, the ids collide without a prefix
+	writeDatedNote(t, dirB, "b3", 1)
+
+	cfg := config.Config{}
+	cache := xcache.NewMemoryCache()
+	l := NewLoader(cfg, store.NewConfigStore(cfg), cache, parse.NewCache(parse.NewParser(parse.CreateGoldmark()), cache), new(mint.Emitter))
+	defer l.Close()
+
+	cl := store.Changelog{
+		Sources: []store.ChangelogSource{
+			{LocalSource: null.NewValue(store.LocalSource{Path: dirA}, true)},
+			{LocalSource: null.NewValue(store.LocalSource{Path: dirB}, true)},
+		},
+	}
+	ctx := context.Background()
+
+	tables := []struct {
+		name     string
+		page     internal.Pagination
+		expected []string
+		hasMore  bool
+	}{
+		{
+			name:     "all release notes",
+			page:     internal.NoPagination(),
+			expected: []string{"a1", "b1", "a2", "b2", "a3", "b3"},
+		},
+		{
+			name:     "first page",
+			page:     internal.NewPagination(2, 1),
+			expected: []string{"a1", "b1"},
+			hasMore:  true,
+		},
+		{
+			name:     "second page",
+			page:     internal.NewPagination(2, 2),
+			expected: []string{"a2", "b2"},
+			hasMore:  true,
+		},
+		{
+			name:     "last page",
+			page:     internal.NewPagination(4, 2),
+			expected: []string{"a3", "b3"},
+		},
+		{
+			name:     "page out of range",
+			page:     internal.NewPagination(4, 3),
+			expected: []string{},
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			loaded, err := l.LoadAndParseReleaseNotes(ctx, cl, table.page)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if loaded.HasMore != table.hasMore {
+				t.Errorf("expected hasMore %t but got %t", table.hasMore, loaded.HasMore)
+			}
+			if len(loaded.Notes) != len(table.expected) {
+				t.Fatalf("expected %d release notes but got %d", len(table.expected), len(loaded.Notes))
+			}
+			for i, n := range loaded.Notes {
+				if n.Meta.Title != table.expected[i] {
+					t.Errorf("expected note at index %d to be %q but got %q", i, table.expected[i], n.Meta.Title)
+				}
+			}
+		})
+	}
+
+	t.Run("prefixed ids", func(t *testing.T) {
+		all, err := l.LoadAndParseReleaseNotes(ctx, cl, internal.NoPagination())
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := make(map[string]bool)
+		for _, n := range all.Notes {
+			if ids[n.Meta.ID] {
+				t.Errorf("expected unique ids, got %s twice", n.Meta.ID)
+			}
+			ids[n.Meta.ID] = true
+		}
+		sid := source.NewLocalID(dirB)
+		if !source.IsCombinedNoteOf(sid, all.Notes[5].Meta.ID) {
+			t.Errorf("expected id %s to be prefixed with source %s", all.Notes[5].Meta.ID, sid)
+		}
+	})
+
+	t.Run("load release note", func(t *testing.T) {
+		all, err := l.LoadAndParseReleaseNotes(ctx, cl, internal.NoPagination())
+		if err != nil {
+			t.Fatal(err)
+		}
+		loaded, err := l.LoadReleaseNote(ctx, cl, all.Notes[3].Meta.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if loaded.Note.Meta.Title != "b2" || loaded.Prev.Meta.Title != "a2" || loaded.Next.Meta.Title != "a3" {
+			t.Errorf("expected b2 between a2 and a3, got %s between %s and %s", loaded.Note.Meta.Title, loaded.Prev.Meta.Title, loaded.Next.Meta.Title)
+		}
+	})
+}
+
+func TestCheckHealth(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []string{"v0.0.1-commonmark.md", "v0.0.2-open-source.md"} {
+		copyTestNote(t, dir, f)
+	}
+	err := os.WriteFile(filepath.Join(dir, "v0.0.3-invalid.md"), []byte("---\npublishedAt: not a date\n---\ninvalid"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Symlink(filepath.Join(dir, "missing.md"), filepath.Join(dir, "v0.0.4-missing.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.Config{Local: &config.LocalConfig{FilesPath: dir}}
+	st := store.NewConfigStore(cfg)
+	cache := xcache.NewMemoryCache()
+	l := NewLoader(cfg, st, cache, parse.NewCache(parse.NewParser(parse.CreateGoldmark()), cache), new(mint.Emitter))
+
+	ctx := context.Background()
+	cl, err := st.GetChangelog(ctx, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := l.LoadAndParseReleaseNotes(ctx, cl, internal.NoPagination())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Notes) != 2 {
+		t.Fatalf("expected 2 release notes but got %d", len(loaded.Notes))
+	}
+	for _, n := range loaded.Notes {
+		if n.Content == nil {
+			t.Errorf("expected failed release notes to be left out")
+		}
+	}
+
+	health, err := l.CheckHealth(ctx, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(health) != 1 {
+		t.Fatalf("expected health of 1 source but got %d", len(health))
+	}
+	h := health[0]
+	if h.Healthy() {
+		t.Errorf("expected source to be unhealthy")
+	}
+	if h.ReleaseNotes != 2 {
+		t.Errorf("expected 2 release notes but got %d", h.ReleaseNotes)
+	}
+	failed := make([]string, len(h.Errors))
+	for i, f := range h.Errors {
+		failed[i] = f.Path
+	}
+	slices.Sort(failed)
+	if !slices.Equal(failed, []string{"v0.0.3-invalid.md", "v0.0.4-missing.md"}) {
+		t.Errorf("expected the invalid and missing file to fail, got %v", failed)
+	}
+}
+
+func TestLoadPinnedOnLaterPage(t *testing.T) {
+	dir := t.TempDir()
+	writeDatedNote(t, dir, "v3", 3)
+	writeDatedNote(t, dir, "v2", 2)
+	err := os.WriteFile(filepath.Join(dir, "01-v1.md"), []byte("---\ntitle: v1\npublishedAt: 2024-01-01T00:00:00Z\npinned: true\n---\nv1"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.Config{}
+	cache := xcache.NewMemoryCache()
+	l := NewLoader(cfg, store.NewConfigStore(cfg), cache, parse.NewCache(parse.NewParser(parse.CreateGoldmark()), cache), new(mint.Emitter))
+	defer l.Close()
+	cl := store.Changelog{
+		Sources: []store.ChangelogSource{{LocalSource: null.NewValue(store.LocalSource{Path: dir}, true)}},
+	}
+
+	var titles []string
+	for page := 1; page <= 2; page++ {
+		loaded, err := l.LoadAndParseReleaseNotes(context.Background(), cl, internal.NewPagination(2, page))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if loaded.HasMore != (page == 1) {
+			t.Errorf("expected hasMore %t on page %d", page == 1, page)
+		}
+		for _, n := range loaded.Notes {
+			titles = append(titles, n.Meta.Title)
+		}
+	}
+	if !slices.Equal(titles, []string{"v1", "v3", "v2"}) {
+		t.Errorf("expected the pinned release note first, got %v", titles)
+	}
+}
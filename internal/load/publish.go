@@ -0,0 +1,174 @@
+package load
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	mint "github.com/btvoidx/mint/context"
+	"github.com/jonashiltl/openchangelog/internal/events"
+	"github.com/jonashiltl/openchangelog/internal/parse"
+	"github.com/jonashiltl/openchangelog/internal/source"
+	"github.com/jonashiltl/openchangelog/internal/store"
+	"github.com/jonashiltl/openchangelog/internal/xlog"
+)
+
+// The publisher remembers the release notes scheduled to be published in the future
+// and emits a ReleaseNotePublished event once their publishedAt passes.
+// Only release notes which were loaded at least once are known.
+type publisher struct {
+	e  *mint.Emitter
+	mu sync.Mutex
+	// scheduled release notes keyed by changelog and source
+	scheduled map[scheduleKey]*scheduledNotes
+	closed    bool
+}
+
+// Changelogs combining multiple sources have scheduled release notes per source.
+type scheduleKey struct {
+	cid store.ChangelogID
+	sid source.ID
+}
+
+type scheduledNotes struct {
+	cl     store.Changelog
+	source source.Source
+	notes  map[string]parse.Meta
+	timer  *time.Timer
+}
+
+func newPublisher(e *mint.Emitter) *publisher {
+	return &publisher{
+		e:         e,
+		scheduled: make(map[scheduleKey]*scheduledNotes),
+	}
+}
+
+// Remembers the scheduled release notes of the source of the changelog.
+// If complete is true, notes contains all release notes of the source and replaces the known ones,
+// otherwise they are added to the known ones.
+func (p *publisher) track(cl store.Changelog, s source.Source, notes []parse.ParsedReleaseNote, complete bool) {
+	now := time.Now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return
+	}
+
+	key := scheduleKey{cid: cl.ID, sid: s.ID()}
+	sn, ok := p.scheduled[key]
+	if !ok || complete {
+		if ok {
+			sn.timer.Stop()
+		}
+		sn = &scheduledNotes{notes: make(map[string]parse.Meta)}
+	}
+	sn.cl = cl
+	sn.source = s
+
+	for _, n := range notes {
+		// drafts are only published once the draft flag is removed
+		if !n.Meta.Draft && n.Meta.PublishedAt.After(now) {
+			sn.notes[n.Meta.ID] = n.Meta
+		}
+	}
+
+	if len(sn.notes) == 0 {
+		if sn.timer != nil {
+			sn.timer.Stop()
+		}
+		delete(p.scheduled, key)
+		return
+	}
+	p.scheduled[key] = sn
+	p.schedule(key, sn)
+}
+
+// Starts the timer of the source for it's next scheduled release note.
+// Must be called with the lock held.
+func (p *publisher) schedule(key scheduleKey, sn *scheduledNotes) {
+	if sn.timer != nil {
+		sn.timer.Stop()
+	}
+	sn.timer = time.AfterFunc(time.Until(nextPublish(sn.notes)), func() {
+		p.publish(key, sn)
+	})
+}
+
+// Emits a ReleaseNotePublished event for every release note of the source whose publishedAt passed.
+func (p *publisher) publish(key scheduleKey, sn *scheduledNotes) {
+	now := time.Now()
+	p.mu.Lock()
+	if p.closed || p.scheduled[key] != sn {
+		// replaced by a complete load in the meantime
+		p.mu.Unlock()
+		return
+	}
+
+	var due []parse.Meta
+	for id, meta := range sn.notes {
+		if !meta.PublishedAt.After(now) {
+			due = append(due, meta)
+			delete(sn.notes, id)
+		}
+	}
+	if len(sn.notes) == 0 {
+		delete(p.scheduled, key)
+	} else {
+		p.schedule(key, sn)
+	}
+	cl, s := sn.cl, sn.source
+	p.mu.Unlock()
+
+	for _, meta := range due {
+		meta.ID = source.CombinedNoteID(cl, s.ID(), meta.ID)
+		slog.Debug("release note published", slog.String("cid", key.cid.String()), slog.String("id", meta.ID))
+		err := mint.Emit(p.e, context.Background(), events.ReleaseNotePublished{
+			CL:     cl,
+			Source: s,
+			Note:   meta,
+		})
+		if err != nil {
+			slog.Debug("failed to emit release note published event", xlog.ErrAttr(err))
+		}
+	}
+}
+
+// Returns the publishedAt of the next scheduled release note of the changelog,
+// zero if no release note is known to be scheduled.
+func (p *publisher) next(cid store.ChangelogID) time.Time {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var next time.Time
+	for key, sn := range p.scheduled {
+		if key.cid != cid {
+			continue
+		}
+		if n := nextPublish(sn.notes); next.IsZero() || n.Before(next) {
+			next = n
+		}
+	}
+	return next
+}
+
+// Stops all timers, no more events are emitted afterwards.
+func (p *publisher) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closed = true
+	for _, sn := range p.scheduled {
+		sn.timer.Stop()
+	}
+}
+
+// Returns the earliest publishedAt of the release notes.
+func nextPublish(notes map[string]parse.Meta) time.Time {
+	var next time.Time
+	for _, meta := range notes {
+		if next.IsZero() || meta.PublishedAt.Before(next) {
+			next = meta.PublishedAt
+		}
+	}
+	return next
+}
@@ -0,0 +1,103 @@
+package load
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/internal/store"
+	"github.com/jonashiltl/openchangelog/internal/xlog"
+	"golang.org/x/sync/errgroup"
+)
+
+// Number of changelogs refreshed at the same time.
+const refreshConcurrency = 4
+
+// The scheduler periodically refreshes the sources of all searchable changelogs,
+// so changes are picked up independent of incoming traffic.
+type Scheduler struct {
+	loader   *Loader
+	interval time.Duration
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// Creates a new scheduler, returns nil if polling is disabled in the config.
+func NewScheduler(loader *Loader) *Scheduler {
+	if loader.cfg.Refresh == nil || loader.cfg.Refresh.Interval <= 0 {
+		return nil
+	}
+	return &Scheduler{
+		loader:   loader,
+		interval: loader.cfg.Refresh.Interval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Starts polling in the background.
+func (s *Scheduler) Start() {
+	if s == nil {
+		return
+	}
+	slog.Info("polling sources for changes", slog.Duration("interval", s.interval))
+
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.refreshAll()
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stops polling and waits for the running refresh to finish.
+func (s *Scheduler) Close() {
+	if s == nil {
+		return
+	}
+	close(s.stop)
+	<-s.done
+}
+
+// Refreshes the searchable changelogs with a bounded number of workers.
+// Every changelog gets it's own timeout, so a slow source doesn't cancel the refresh of the others.
+func (s *Scheduler) refreshAll() {
+	ctx, cancel := context.WithTimeout(context.Background(), s.interval)
+	cls, err := s.loader.store.ListAllChangelogs(ctx)
+	cancel()
+	if err != nil {
+		slog.Error("failed to list changelogs for refresh", xlog.ErrAttr(err))
+		return
+	}
+
+	var eg errgroup.Group
+	eg.SetLimit(refreshConcurrency)
+	for _, cl := range cls {
+		if !cl.Searchable {
+			continue
+		}
+		eg.Go(func() error {
+			s.refresh(cl)
+			return nil
+		})
+	}
+	eg.Wait()
+}
+
+func (s *Scheduler) refresh(cl store.Changelog) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.interval)
+	defer cancel()
+
+	err := s.loader.RefreshSource(ctx, cl, false)
+	if err != nil {
+		slog.Warn("failed to refresh source", slog.String("cid", cl.ID.String()), xlog.ErrAttr(err))
+	}
+}
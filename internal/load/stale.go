@@ -0,0 +1,235 @@
+package load
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/internal"
+	"github.com/jonashiltl/openchangelog/internal/source"
+	"github.com/jonashiltl/openchangelog/internal/xcache"
+	"github.com/jonashiltl/openchangelog/internal/xlog"
+)
+
+const (
+	retryMinBackoff = time.Second
+	retryMaxBackoff = 5 * time.Minute
+)
+
+// Stores the last successful load of every source in the cache,
+// to serve it if the source fails to load, e.g. if GitHub rate limits the requests.
+// Failing sources are retried in the background with an exponential backoff.
+// Only one unpaginated result is stored per source, pages are sliced from it.
+type staleStore struct {
+	cache xcache.Cache
+	mu    sync.Mutex
+	// the sources stored by this instance, true if all release notes of the source are stored
+	stored map[source.ID]bool
+	// the time the failing sources started failing
+	failing map[source.ID]time.Time
+	// the sources that are retried in the background
+	retrying   map[source.ID]struct{}
+	done       chan struct{}
+	closed     bool
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+type storedNote struct {
+	Path    string
+	Content []byte
+}
+
+// HasMore is true if only the first release notes of the source are stored.
+type storedResult struct {
+	Raw     []storedNote
+	HasMore bool
+}
+
+// Returns nil if cache is nil, a nil staleStore stores nothing.
+func newStaleStore(cache xcache.Cache) *staleStore {
+	if cache == nil {
+		return nil
+	}
+	return &staleStore{
+		cache:      cache,
+		stored:     make(map[source.ID]bool),
+		failing:    make(map[source.ID]time.Time),
+		retrying:   make(map[source.ID]struct{}),
+		done:       make(chan struct{}),
+		minBackoff: retryMinBackoff,
+		maxBackoff: retryMaxBackoff,
+	}
+}
+
+func staleKey(sid source.ID) string {
+	return fmt.Sprintf("stale/v2/%s", sid)
+}
+
+// Stores the successfully loaded result of the source, if it changed or wasn't stored yet.
+// The first page is only stored until all release notes of the source were loaded, other pages are never stored.
+// Consumes the readers of loaded, the returned result contains new readers of the same content.
+func (s *staleStore) set(sid source.ID, page internal.Pagination, loaded source.LoadResult) source.LoadResult {
+	if s == nil {
+		return loaded
+	}
+
+	s.mu.Lock()
+	delete(s.failing, sid)
+	complete, ok := s.stored[sid]
+	s.mu.Unlock()
+
+	switch {
+	case page.IsDefined() && page.StartIdx() != 0:
+		return loaded
+	case page.IsDefined() && ok && !loaded.HasChanged():
+		return loaded
+	case !page.IsDefined() && complete && !loaded.HasChanged():
+		return loaded
+	}
+
+	stored := storedResult{
+		Raw:     make([]storedNote, len(loaded.Raw)),
+		HasMore: loaded.HasMore,
+	}
+	for i, raw := range loaded.Raw {
+		b, err := io.ReadAll(raw.Content)
+		if err != nil {
+			// keep the previous result, the note can't be read again
+			slog.Warn("failed to read raw release note", slog.String("sid", sid.String()), xlog.ErrAttr(err))
+			return loaded
+		}
+		stored.Raw[i] = storedNote{Path: raw.Path, Content: b}
+		loaded.Raw[i].Content = bytes.NewReader(b)
+	}
+
+	b, err := json.Marshal(stored)
+	if err != nil {
+		slog.Warn("failed to encode stale release notes", slog.String("sid", sid.String()), xlog.ErrAttr(err))
+		return loaded
+	}
+	// the whole result is replaced, so the cache is written outside of the lock
+	s.cache.Set(staleKey(sid), b)
+
+	s.mu.Lock()
+	s.stored[sid] = !stored.HasMore
+	s.mu.Unlock()
+	return loaded
+}
+
+// Returns the page of the last successful load of the source and marks the source as failing.
+// Returns false if the release notes of the page weren't stored.
+func (s *staleStore) fail(sid source.ID, page internal.Pagination) (source.LoadResult, bool) {
+	if s == nil {
+		return source.LoadResult{}, false
+	}
+
+	stored, ok := s.get(sid)
+	if !ok {
+		return source.LoadResult{}, false
+	}
+	start, end := 0, len(stored.Raw)
+	if page.IsDefined() {
+		start = min(page.StartIdx(), len(stored.Raw))
+		end = start + page.PageSize()
+	}
+	if stored.HasMore && (!page.IsDefined() || end > len(stored.Raw)) {
+		// the page goes beyond the stored release notes
+		return source.LoadResult{}, false
+	}
+	end = min(end, len(stored.Raw))
+	hasMore := stored.HasMore || end < len(stored.Raw)
+
+	s.mu.Lock()
+	if _, failing := s.failing[sid]; !failing {
+		s.failing[sid] = time.Now()
+	}
+	s.mu.Unlock()
+
+	loaded := source.LoadResult{
+		Raw:     make([]source.RawReleaseNote, 0, end-start),
+		HasMore: hasMore,
+	}
+	for _, n := range stored.Raw[start:end] {
+		loaded.Raw = append(loaded.Raw, source.RawReleaseNote{
+			Path:    n.Path,
+			Content: bytes.NewReader(n.Content),
+		})
+	}
+	return loaded, true
+}
+
+// Returns since when stale release notes of the source are served, zero if the source didn't fail.
+func (s *staleStore) since(sid source.ID) time.Time {
+	if s == nil {
+		return time.Time{}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.failing[sid]
+}
+
+// Calls load in the background with an exponential backoff until it succeeds or the store is closed.
+// Does nothing if the source is already retried.
+func (s *staleStore) retry(sid source.ID, load func() error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.retrying[sid]; ok || s.closed {
+		return
+	}
+	s.retrying[sid] = struct{}{}
+
+	go func() {
+		defer func() {
+			s.mu.Lock()
+			delete(s.retrying, sid)
+			s.mu.Unlock()
+		}()
+
+		backoff := s.minBackoff
+		for {
+			select {
+			case <-s.done:
+				return
+			case <-time.After(backoff):
+			}
+			err := load()
+			if err == nil {
+				return
+			}
+			slog.Debug("failed to retry loading source", slog.String("sid", sid.String()), xlog.ErrAttr(err))
+			backoff = min(2*backoff, s.maxBackoff)
+		}
+	}()
+}
+
+// Stops retrying the failing sources.
+func (s *staleStore) close() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.done)
+	}
+}
+
+func (s *staleStore) get(sid source.ID) (storedResult, bool) {
+	b, ok := s.cache.Get(staleKey(sid))
+	if !ok {
+		return storedResult{}, false
+	}
+	var stored storedResult
+	err := json.Unmarshal(b, &stored)
+	if err != nil {
+		slog.Warn("failed to decode stale release notes", slog.String("sid", sid.String()), xlog.ErrAttr(err))
+		return storedResult{}, false
+	}
+	return stored, true
+}
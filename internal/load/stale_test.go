@@ -0,0 +1,207 @@
+package load
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	mint "github.com/btvoidx/mint/context"
+	"github.com/jonashiltl/openchangelog/internal"
+	"github.com/jonashiltl/openchangelog/internal/config"
+	"github.com/jonashiltl/openchangelog/internal/parse"
+	"github.com/jonashiltl/openchangelog/internal/source"
+	"github.com/jonashiltl/openchangelog/internal/store"
+	"github.com/jonashiltl/openchangelog/internal/xcache"
+)
+
+const staleTestNote = `---
+title: v1
+publishedAt: 2025-01-01T00:00:00Z
+---
+first release`
+
+func newStaleTestLoader(t *testing.T, url string, staleIfError bool) (*Loader, store.Changelog) {
+	cfg := config.Config{
+		URL:   &config.URLConfig{URL: url},
+		Cache: &config.CacheConfig{Type: config.Memory, StaleIfError: staleIfError},
+	}
+	st := store.NewConfigStore(cfg)
+	cache := xcache.NewMemoryCache()
+	l := NewLoader(cfg, st, cache, parse.NewCache(parse.NewParser(parse.CreateGoldmark()), cache), new(mint.Emitter))
+	t.Cleanup(l.Close)
+	if l.stale != nil {
+		l.stale.minBackoff = 10 * time.Millisecond
+		l.stale.maxBackoff = 20 * time.Millisecond
+	}
+
+	cl, err := st.GetChangelog(context.Background(), "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l, cl
+}
+
+func newFlakyServer(t *testing.T, failing *atomic.Bool, requests *atomic.Int32) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if failing.Load() {
+			http.Error(w, "rate limited", http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(staleTestNote))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestStaleIfError(t *testing.T) {
+	var failing atomic.Bool
+	var requests atomic.Int32
+	srv := newFlakyServer(t, &failing, &requests)
+	l, cl := newStaleTestLoader(t, srv.URL, true)
+	ctx := context.Background()
+
+	loaded, err := l.LoadAndParseReleaseNotes(ctx, cl, internal.NoPagination())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Notes) != 1 {
+		t.Fatalf("expected 1 release note but got %d", len(loaded.Notes))
+	}
+	if !l.StaleSince(cl).IsZero() {
+		t.Errorf("expected release notes not to be stale")
+	}
+
+	failing.Store(true)
+	loaded, err = l.LoadAndParseReleaseNotes(ctx, cl, internal.NoPagination())
+	if err != nil {
+		t.Fatalf("expected stale release notes to be served, got %v", err)
+	}
+	if len(loaded.Notes) != 1 || loaded.Notes[0].Meta.Title != "v1" {
+		t.Fatalf("expected the last successfully loaded release note, got %+v", loaded.Notes)
+	}
+	since := l.StaleSince(cl)
+	if since.IsZero() {
+		t.Fatalf("expected release notes to be stale")
+	}
+
+	health, err := l.CheckHealth(ctx, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if health[0].Healthy() || !health[0].StaleSince.Equal(since) {
+		t.Errorf("expected source to be unhealthy and stale since %s, got %+v", since, health[0])
+	}
+
+	// the source is retried in the background
+	before := requests.Load()
+	time.Sleep(100 * time.Millisecond)
+	if requests.Load() <= before {
+		t.Errorf("expected the source to be retried")
+	}
+
+	failing.Store(false)
+	deadline := time.Now().Add(5 * time.Second)
+	for !l.StaleSince(cl).IsZero() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the source to recover")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// retrying stops once the source loaded successfully
+	time.Sleep(50 * time.Millisecond)
+	before = requests.Load()
+	time.Sleep(100 * time.Millisecond)
+	if requests.Load() != before {
+		t.Errorf("expected retrying to stop after the source recovered")
+	}
+}
+
+func TestStaleIfErrorNotLoadedBefore(t *testing.T) {
+	var failing atomic.Bool
+	var requests atomic.Int32
+	failing.Store(true)
+	srv := newFlakyServer(t, &failing, &requests)
+	l, cl := newStaleTestLoader(t, srv.URL, true)
+
+	_, err := l.LoadAndParseReleaseNotes(context.Background(), cl, internal.NoPagination())
+	if err == nil {
+		t.Errorf("expected error if the source was never loaded successfully")
+	}
+}
+
+func TestStaleIfErrorDisabled(t *testing.T) {
+	var failing atomic.Bool
+	var requests atomic.Int32
+	srv := newFlakyServer(t, &failing, &requests)
+	l, cl := newStaleTestLoader(t, srv.URL, false)
+	ctx := context.Background()
+
+	_, err := l.LoadAndParseReleaseNotes(ctx, cl, internal.NoPagination())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	failing.Store(true)
+	_, err = l.LoadAndParseReleaseNotes(ctx, cl, internal.NoPagination())
+	if err == nil {
+		t.Errorf("expected error if stale-if-error is disabled")
+	}
+}
+
+func TestStaleIfErrorPages(t *testing.T) {
+	s := newStaleStore(xcache.NewMemoryCache())
+	sid := source.ID("pages")
+	raw := func(contents ...string) source.LoadResult {
+		res := source.LoadResult{}
+		for _, c := range contents {
+			res.Raw = append(res.Raw, source.RawReleaseNote{Content: strings.NewReader(c)})
+		}
+		return res
+	}
+	contents := func(res source.LoadResult) string {
+		var all []string
+		for _, n := range res.Raw {
+			b, _ := io.ReadAll(n.Content)
+			all = append(all, string(b))
+		}
+		return strings.Join(all, ",")
+	}
+
+	first := raw("v3", "v2")
+	first.HasMore = true
+	s.set(sid, internal.NewPagination(2, 1), first)
+	s.set(sid, internal.NewPagination(2, 2), raw("v1"))
+	if _, ok := s.fail(sid, internal.NewPagination(2, 2)); ok {
+		t.Error("expected only the first page to be stored")
+	}
+	if _, ok := s.fail(sid, internal.NoPagination()); ok {
+		t.Error("expected the first page not to be served as all release notes")
+	}
+	if res, ok := s.fail(sid, internal.NewPagination(2, 1)); !ok || !res.HasMore || contents(res) != "v3,v2" {
+		t.Errorf("expected the stored first page, got %+v", res)
+	}
+
+	s.set(sid, internal.NoPagination(), raw("v3", "v2", "v1"))
+	tables := []struct {
+		page     internal.Pagination
+		expected string
+		hasMore  bool
+	}{
+		{page: internal.NoPagination(), expected: "v3,v2,v1"},
+		{page: internal.NewPagination(2, 1), expected: "v3,v2", hasMore: true},
+		{page: internal.NewPagination(2, 2), expected: "v1"},
+	}
+	for _, table := range tables {
+		res, ok := s.fail(sid, table.page)
+		if !ok || contents(res) != table.expected || res.HasMore != table.hasMore {
+			t.Errorf("expected %s with hasMore %t, got %+v", table.expected, table.hasMore, res)
+		}
+	}
+}
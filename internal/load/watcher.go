@@ -0,0 +1,207 @@
+package load
+
+import (
+	"context"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	mint "github.com/btvoidx/mint/context"
+	"github.com/fsnotify/fsnotify"
+	"github.com/jonashiltl/openchangelog/internal/events"
+	"github.com/jonashiltl/openchangelog/internal/source"
+	"github.com/jonashiltl/openchangelog/internal/store"
+	"github.com/jonashiltl/openchangelog/internal/xlog"
+)
+
+// Editors often write a file in multiple steps, events in this window are handled together.
+const watchDebounce = 100 * time.Millisecond
+
+// The watcher watches the files path of local sources and emits a SourceContentChanged event
+// for every local source whose files are created, modified or deleted.
+type Watcher struct {
+	loader *Loader
+	root   string
+	fsw    *fsnotify.Watcher
+	done   chan struct{}
+
+	mu      sync.Mutex
+	changed map[string]struct{}
+	timer   *time.Timer
+}
+
+// Creates a new watcher, returns nil if local sources are disabled in the config.
+func NewWatcher(loader *Loader) *Watcher {
+	if loader.cfg.Local == nil || loader.cfg.Local.FilesPath == "" {
+		return nil
+	}
+	return &Watcher{
+		loader:  loader,
+		root:    loader.cfg.Local.FilesPath,
+		done:    make(chan struct{}),
+		changed: make(map[string]struct{}),
+	}
+}
+
+// Starts watching the files path in the background.
+func (w *Watcher) Start() {
+	if w == nil {
+		return
+	}
+
+	root, err := filepath.Abs(w.root)
+	if err != nil {
+		slog.Error("failed to resolve files path", xlog.ErrAttr(err))
+		return
+	}
+	w.root = root
+
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		slog.Error("failed to create files path watcher", xlog.ErrAttr(err))
+		return
+	}
+	w.fsw = fsw
+
+	// fsnotify doesn't watch recursively, each directory is added on it's own
+	if err := w.addDirs(root); err != nil {
+		slog.Error("failed to watch files path", slog.String("path", root), xlog.ErrAttr(err))
+	}
+	slog.Info("watching files path for changes", slog.String("path", root))
+
+	go w.run()
+}
+
+// Stops watching, changes that weren't handled yet are discarded.
+func (w *Watcher) Close() {
+	if w == nil || w.fsw == nil {
+		return
+	}
+	w.fsw.Close()
+	<-w.done
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+}
+
+func (w *Watcher) run() {
+	defer close(w.done)
+	for {
+		select {
+		case event, ok := <-w.fsw.Events:
+			if !ok {
+				return
+			}
+			w.handle(event)
+		case err, ok := <-w.fsw.Errors:
+			if !ok {
+				return
+			}
+			slog.Warn("files path watcher error", xlog.ErrAttr(err))
+		}
+	}
+}
+
+func (w *Watcher) handle(event fsnotify.Event) {
+	if event.Has(fsnotify.Chmod) && !event.Has(fsnotify.Write) {
+		return
+	}
+
+	if event.Has(fsnotify.Create) {
+		if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+			if err := w.addDirs(event.Name); err != nil {
+				slog.Warn("failed to watch directory", slog.String("path", event.Name), xlog.ErrAttr(err))
+			}
+		}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.changed[event.Name] = struct{}{}
+	if w.timer == nil {
+		w.timer = time.AfterFunc(watchDebounce, w.flush)
+	}
+}
+
+func (w *Watcher) addDirs(root string) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return w.fsw.Add(path)
+		}
+		return nil
+	})
+}
+
+// Emits a SourceContentChanged event for every local source containing one of the changed paths.
+func (w *Watcher) flush() {
+	w.mu.Lock()
+	changed := w.changed
+	w.changed = make(map[string]struct{})
+	w.timer = nil
+	w.mu.Unlock()
+
+	if len(changed) == 0 {
+		return
+	}
+
+	ctx := context.Background()
+	cls, err := w.loader.store.ListAllChangelogs(ctx)
+	if err != nil {
+		slog.Error("failed to list changelogs for changed files", xlog.ErrAttr(err))
+		return
+	}
+
+	for _, cl := range cls {
+		for _, lc := range localSources(cl) {
+			if !containsAny(lc, changed) {
+				continue
+			}
+
+			s := source.NewLocalSourceFromStore(lc, w.loader.cache)
+			slog.Debug("local source files changed", slog.String("sid", s.ID().String()))
+			err := mint.Emit(w.loader.e, ctx, events.SourceContentChanged{
+				CL:     cl,
+				Source: s,
+			})
+			if err != nil {
+				slog.Warn("failed to emit source content changed event", slog.String("cid", cl.ID.String()), xlog.ErrAttr(err))
+			}
+		}
+	}
+}
+
+// Returns the local sources of the changelog.
+func localSources(cl store.Changelog) []store.LocalSource {
+	if len(cl.Sources) == 0 {
+		if cl.LocalSource.Valid {
+			return []store.LocalSource{cl.LocalSource.V}
+		}
+		return nil
+	}
+
+	res := make([]store.LocalSource, 0)
+	for _, cs := range cl.Sources {
+		if cs.LocalSource.Valid {
+			res = append(res, cs.LocalSource.V)
+		}
+	}
+	return res
+}
+
+func containsAny(lc store.LocalSource, paths map[string]struct{}) bool {
+	for p := range paths {
+		if source.LocalSourceContains(lc, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package load
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	mint "github.com/btvoidx/mint/context"
+	"github.com/jonashiltl/openchangelog/internal/config"
+	"github.com/jonashiltl/openchangelog/internal/events"
+	"github.com/jonashiltl/openchangelog/internal/parse"
+	"github.com/jonashiltl/openchangelog/internal/source"
+	"github.com/jonashiltl/openchangelog/internal/store"
+	"github.com/jonashiltl/openchangelog/internal/xcache"
+)
+
+func TestWatcherDisabled(t *testing.T) {
+	cfg := config.Config{}
+	l := NewLoader(cfg, store.NewConfigStore(cfg), nil, nil, new(mint.Emitter))
+	w := NewWatcher(l)
+	if w != nil {
+		t.Fatal("expected no watcher without files path")
+	}
+	// nil watchers are safe to use
+	w.Start()
+	w.Close()
+}
+
+func TestWatcher(t *testing.T) {
+	dir := t.TempDir()
+	copyTestNote(t, dir, "v0.0.1-commonmark.md")
+
+	cfg := config.Config{Local: &config.LocalConfig{FilesPath: dir}}
+	cache := xcache.NewMemoryCache()
+	e := new(mint.Emitter)
+	l := NewLoader(cfg, store.NewConfigStore(cfg), cache, parse.NewCache(parse.NewParser(parse.CreateGoldmark()), cache), e)
+	defer l.Close()
+
+	changed := make(chan events.SourceContentChanged, 10)
+	off := mint.On(e, func(_ context.Context, e events.SourceContentChanged) {
+		changed <- e
+	})
+	defer off()
+
+	w := NewWatcher(l)
+	w.Start()
+	defer w.Close()
+
+	expectChanged := func(t *testing.T) {
+		select {
+		case e := <-changed:
+			if e.Source.ID() != source.NewLocalID(dir) {
+				t.Errorf("expected source %s but got %s", source.NewLocalID(dir), e.Source.ID())
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for source content changed event")
+		}
+		// drain events of the same change that weren't debounced
+		time.Sleep(2 * watchDebounce)
+		for len(changed) > 0 {
+			<-changed
+		}
+	}
+
+	t.Run("create", func(t *testing.T) {
+		copyTestNote(t, dir, "v0.0.2-open-source.md")
+		expectChanged(t)
+	})
+
+	t.Run("modify", func(t *testing.T) {
+		err := os.WriteFile(filepath.Join(dir, "v0.0.2-open-source.md"), []byte("---\ntitle: modified\n---\nmodified"), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectChanged(t)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err := os.Remove(filepath.Join(dir, "v0.0.2-open-source.md"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectChanged(t)
+	})
+
+	t.Run("ignores other files", func(t *testing.T) {
+		err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a release note"), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case e := <-changed:
+			t.Errorf("expected no event, got one for %s", e.Source.ID())
+		case <-time.After(5 * watchDebounce):
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rs/xid"
+)
+
+// Creates a sender which writes each email as .eml file to dir, used for local development.
+func NewFileSender(dir string) Sender {
+	return &fileSender{
+		dir: dir,
+	}
+}
+
+type fileSender struct {
+	dir string
+}
+
+func (s *fileSender) Send(ctx context.Context, msg Message) error {
+	err := os.MkdirAll(s.dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	b, err := msg.Bytes()
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", time.Now().Format("20060102-150405"), xid.New().String())
+	return os.WriteFile(filepath.Join(s.dir, name), b, 0644)
+}
+
+// Creates a sender which only logs emails, used if no email delivery is configured.
+func NewLogSender() Sender {
+	return logSender{}
+}
+
+type logSender struct{}
+
+func (logSender) Send(ctx context.Context, msg Message) error {
+	slog.InfoContext(
+		ctx,
+		"email not delivered, no smtp server configured",
+		slog.String("to", msg.To),
+		slog.String("subject", msg.Subject),
+		slog.String("text", msg.Text),
+	)
+	return nil
+}
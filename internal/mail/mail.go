@@ -0,0 +1,116 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/internal/config"
+)
+
+type Message struct {
+	From    string
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+	// Additional headers like List-Unsubscribe.
+	Headers map[string]string
+}
+
+// A sender delivers emails, either through SMTP or a local stand-in.
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// Creates the sender of the email config.
+// Uses SMTP if configured, else writes emails to the configured directory or logs them.
+func NewSender(cfg config.Config) Sender {
+	if cfg.Email == nil {
+		return NewLogSender()
+	}
+	if cfg.Email.SMTP != nil && cfg.Email.SMTP.Host != "" {
+		return NewSMTPSender(*cfg.Email.SMTP)
+	}
+	if cfg.Email.Dir != "" {
+		return NewFileSender(cfg.Email.Dir)
+	}
+	slog.Warn("no smtp server configured, emails are only logged")
+	return NewLogSender()
+}
+
+// Encodes the message in the MIME format, with a text and html alternative.
+func (m Message) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	headers := map[string]string{
+		"From":         m.From,
+		"To":           m.To,
+		"Subject":      mime.QEncoding.Encode("utf-8", m.Subject),
+		"Date":         time.Now().Format(time.RFC1123Z),
+		"MIME-Version": "1.0",
+		"Content-Type": fmt.Sprintf("multipart/alternative; boundary=%s", mw.Boundary()),
+	}
+	for k, v := range m.Headers {
+		headers[k] = v
+	}
+
+	keys := make([]string, 0, len(headers))
+	for k := range headers {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		fmt.Fprintf(&buf, "%s: %s\r\n", k, sanitizeHeader(headers[k]))
+	}
+	buf.WriteString("\r\n")
+
+	parts := []struct {
+		contentType string
+		body        string
+	}{
+		{"text/plain; charset=utf-8", m.Text},
+		{"text/html; charset=utf-8", m.HTML},
+	}
+	for _, p := range parts {
+		if p.body == "" {
+			continue
+		}
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {p.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		_, err = qp.Write([]byte(p.body))
+		if err != nil {
+			return nil, err
+		}
+		err = qp.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := mw.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Removes line breaks to prevent header injection.
+func sanitizeHeader(v string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(v)
+}
@@ -0,0 +1,44 @@
+package mail
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMessageBytes(t *testing.T) {
+	msg := Message{
+		From:    "changelog@example.com",
+		To:      "jane@example.com\r\nBcc: attacker@example.com",
+		Subject: "Release v1",
+		Text:    "plain content",
+		HTML:    "<p>html content</p>",
+		Headers: map[string]string{
+			"List-Unsubscribe": "<https://example.com/unsubscribe>",
+		},
+	}
+
+	b, err := msg.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw := string(b)
+
+	if strings.Contains(raw, "\r\nBcc:") {
+		t.Error("expected line breaks in headers to be removed")
+	}
+
+	expected := []string{
+		"To: jane@example.comBcc: attacker@example.com\r\n",
+		"List-Unsubscribe: <https://example.com/unsubscribe>\r\n",
+		"Content-Type: multipart/alternative",
+		"text/plain; charset=utf-8",
+		"plain content",
+		"text/html; charset=utf-8",
+		"<p>html content</p>",
+	}
+	for _, e := range expected {
+		if !strings.Contains(raw, e) {
+			t.Errorf("expected message to contain %q", e)
+		}
+	}
+}
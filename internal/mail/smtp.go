@@ -0,0 +1,47 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"net/smtp"
+
+	"github.com/jonashiltl/openchangelog/internal/config"
+)
+
+// Creates a sender which delivers emails through the smtp server.
+func NewSMTPSender(cfg config.SMTPConfig) Sender {
+	port := cfg.Port
+	if port == 0 {
+		port = 587
+	}
+	s := &smtpSender{
+		addr: fmt.Sprintf("%s:%d", cfg.Host, port),
+	}
+	if cfg.Username != "" {
+		s.auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	return s
+}
+
+type smtpSender struct {
+	addr string
+	auth smtp.Auth
+}
+
+func (s *smtpSender) Send(ctx context.Context, msg Message) error {
+	from, err := mail.ParseAddress(msg.From)
+	if err != nil {
+		return err
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return err
+	}
+
+	b, err := msg.Bytes()
+	if err != nil {
+		return err
+	}
+	return smtp.SendMail(s.addr, s.auth, from.Address, []string{to.Address}, b)
+}
@@ -0,0 +1,301 @@
+package parse
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"sync"
+
+	"github.com/jonashiltl/openchangelog/internal"
+	"github.com/jonashiltl/openchangelog/internal/source"
+	"github.com/jonashiltl/openchangelog/internal/xcache"
+	"github.com/jonashiltl/openchangelog/internal/xlog"
+)
+
+// Creates a new cache of parsed release notes in front of parser.
+// If cache is nil, release notes are parsed on every call.
+func NewCache(parser Parser, cache xcache.Cache) *Cache {
+	return &Cache{
+		parser: parser,
+		cache:  cache,
+	}
+}
+
+// Caches parsed release notes keyed by the source id and the hash of the raw release note content,
+// so unchanged release notes don't need to be converted to HTML again.
+// All entries of a source are stored in a single cache entry, which allows evicting them at once.
+// Sources with a single file only keep the latest parsed file, which is paginated after reading it from the cache.
+type Cache struct {
+	parser Parser
+	cache  xcache.Cache
+	mu     sync.Mutex
+}
+
+type cachedNote struct {
+	Meta Meta
+	HTML []byte
+}
+
+type cachedResult struct {
+	Notes   []cachedNote
+	HasMore bool
+}
+
+// maps the content hash to the parsed result
+type cacheEntry map[string]cachedResult
+
+// The parsed result of a source with a single file, only the latest content is kept.
+type cachedFile struct {
+	Key    string
+	Result cachedResult
+}
+
+// Increased when Meta or the ids change, so release notes and indexes cached before are parsed again.
+const cacheVersion = 5
+
+func cacheKey(sid source.ID) string {
+	return fmt.Sprintf("parsed/v%d/%s", cacheVersion, sid)
+}
+
+func fileKey(sid source.ID) string {
+	return fmt.Sprintf("parsed-file/v%d/%s", cacheVersion, sid)
+}
+
+// Same as Parser.Parse, but returns cached release notes if the raw content didn't change.
+func (c *Cache) Parse(ctx context.Context, sid source.ID, raw []source.RawReleaseNote, kPage internal.Pagination) ParseResult {
+	if c.cache == nil || (kPage.IsDefined() && kPage.PageSize() < 1) {
+		return c.parser.Parse(ctx, raw, kPage)
+	}
+
+	if len(raw) == 1 {
+		// pagination is only applied by the keep-a-changelog parser, which is only used for single files
+		b, err := io.ReadAll(raw[0].Content)
+		if err != nil {
+			return ParseResult{
+				Errors: []source.FileError{{Path: raw[0].Path, Err: err}},
+			}
+		}
+		key := contentHash(b)
+		if raw[0].Path != "" {
+			// the metadata can be derived from the path
+			key = fmt.Sprintf("%s/%s", key, raw[0].Path)
+		}
+		file, ok := c.getFile(sid)
+		if !ok || file.Key != key {
+			// the whole file is parsed once, each page is sliced from the cached result
+			parsed := c.parser.parseOne(source.RawReleaseNote{Content: bytes.NewReader(b), Path: raw[0].Path}, internal.NoPagination())
+			if len(parsed.Errors) > 0 {
+				// not cached, so the error is reported until the file is fixed
+				return parsed
+			}
+			file = cachedFile{Key: key, Result: toCachedResult(parsed)}
+			c.setFile(sid, file)
+		}
+		return file.Result.paginate(kPage).toParseResult()
+	}
+
+	parsed, failed := c.parseFiles(sid, raw)
+	result := make([]ParsedReleaseNote, 0, len(raw))
+	for _, n := range parsed {
+		if n != nil {
+			result = append(result, *n)
+		}
+	}
+
+	slices.SortFunc(result, sortArticleDesc)
+	return ParseResult{
+		ReleaseNotes: result,
+		HasMore:      false,
+		Errors:       failed,
+	}
+}
+
+// Parses each raw release note using the og parser, returns the cached result if the content didn't change.
+// The result has the same order as raw, release notes that failed to be parsed are nil and their errors returned.
+func (c *Cache) parseFiles(sid source.ID, raw []source.RawReleaseNote) ([]*ParsedReleaseNote, []source.FileError) {
+	contents := make([][]byte, len(raw))
+	hashes := make([]string, len(raw))
+	parseErrs := make([]error, len(raw))
+	for i, r := range raw {
+		b, err := io.ReadAll(r.Content)
+		if err != nil {
+			parseErrs[i] = err
+			continue
+		}
+		contents[i] = b
+		hashes[i] = contentHash(b)
+	}
+
+	entry := cacheEntry{}
+	if c.cache != nil {
+		entry = c.get(sid)
+	}
+
+	// parse all release notes that aren't cached yet
+	var wg sync.WaitGroup
+	missed := make([]*cachedResult, len(raw))
+	for i, b := range contents {
+		if _, ok := entry[hashes[i]]; ok || b == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(index int, b []byte) {
+			defer wg.Done()
+			parsed, err := c.parser.og.parseReleaseNote(bytes.NewReader(b))
+			if err != nil {
+				parseErrs[index] = err
+				return
+			}
+			res := toCachedResult(ParseResult{ReleaseNotes: []ParsedReleaseNote{parsed}})
+			missed[index] = &res
+		}(i, b)
+	}
+	wg.Wait()
+
+	updated := false
+	result := make([]*ParsedReleaseNote, len(raw))
+	var failed []source.FileError
+	for i := range contents {
+		if parseErrs[i] != nil {
+			failed = append(failed, source.FileError{Path: raw[i].Path, Err: parseErrs[i]})
+			continue
+		}
+		if missed[i] != nil {
+			entry[hashes[i]] = *missed[i]
+			updated = true
+		}
+		res, ok := entry[hashes[i]]
+		if !ok || len(res.Notes) == 0 {
+			continue
+		}
+		note := res.toParseResult().ReleaseNotes[0]
+		// applied after caching, the same content can be stored at different paths
+		note.Meta.fillFromPath(raw[i].Path)
+		result[i] = &note
+	}
+
+	if updated && c.cache != nil {
+		c.set(sid, entry)
+	}
+	return result, failed
+}
+
+func contentHash(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// Removes all cached release notes and the index of the source.
+func (c *Cache) Evict(sid source.ID) {
+	if c.cache == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache.Delete(cacheKey(sid))
+	c.cache.Delete(fileKey(sid))
+	c.cache.Delete(indexKey(sid))
+}
+
+func (c *Cache) get(sid source.ID) cacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := cacheEntry{}
+	b, ok := c.cache.Get(cacheKey(sid))
+	if !ok {
+		return entry
+	}
+	err := json.Unmarshal(b, &entry)
+	if err != nil {
+		slog.Warn("failed to decode cached release notes", slog.String("sid", sid.String()), xlog.ErrAttr(err))
+		return cacheEntry{}
+	}
+	return entry
+}
+
+func (c *Cache) set(sid source.ID, entry cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, err := json.Marshal(entry)
+	if err != nil {
+		slog.Warn("failed to encode parsed release notes", slog.String("sid", sid.String()), xlog.ErrAttr(err))
+		return
+	}
+	c.cache.Set(cacheKey(sid), b)
+}
+
+// Replaces the whole entry instead of updating it, so concurrent calls can't lose each other's results.
+func (c *Cache) getFile(sid source.ID) (cachedFile, bool) {
+	b, ok := c.cache.Get(fileKey(sid))
+	if !ok {
+		return cachedFile{}, false
+	}
+	var file cachedFile
+	err := json.Unmarshal(b, &file)
+	if err != nil {
+		slog.Warn("failed to decode cached release notes", slog.String("sid", sid.String()), xlog.ErrAttr(err))
+		return cachedFile{}, false
+	}
+	return file, true
+}
+
+func (c *Cache) setFile(sid source.ID, file cachedFile) {
+	b, err := json.Marshal(file)
+	if err != nil {
+		slog.Warn("failed to encode parsed release notes", slog.String("sid", sid.String()), xlog.ErrAttr(err))
+		return
+	}
+	c.cache.Set(fileKey(sid), b)
+}
+
+// Reads the content of the parsed release notes, consumes the readers of parsed.
+func toCachedResult(parsed ParseResult) cachedResult {
+	res := cachedResult{
+		Notes:   make([]cachedNote, 0, len(parsed.ReleaseNotes)),
+		HasMore: parsed.HasMore,
+	}
+	for _, n := range parsed.ReleaseNotes {
+		var html []byte
+		if n.Content != nil {
+			html, _ = io.ReadAll(n.Content)
+		}
+		res.Notes = append(res.Notes, cachedNote{Meta: n.Meta, HTML: html})
+	}
+	return res
+}
+
+// Returns the release notes of page, the result must not be paginated already.
+func (r cachedResult) paginate(page internal.Pagination) cachedResult {
+	if !page.IsDefined() {
+		return r
+	}
+	start := min(page.StartIdx(), len(r.Notes))
+	end := min(start+page.PageSize(), len(r.Notes))
+	return cachedResult{
+		Notes:   r.Notes[start:end],
+		HasMore: end < len(r.Notes),
+	}
+}
+
+// Returns a new ParseResult, each call creates new readers for the content.
+func (r cachedResult) toParseResult() ParseResult {
+	notes := make([]ParsedReleaseNote, len(r.Notes))
+	for i, n := range r.Notes {
+		notes[i] = ParsedReleaseNote{
+			Meta:    n.Meta,
+			Content: bytes.NewReader(n.HTML),
+		}
+	}
+	return ParseResult{
+		ReleaseNotes: notes,
+		HasMore:      r.HasMore,
+	}
+}
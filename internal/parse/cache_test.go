@@ -0,0 +1,146 @@
+package parse
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jonashiltl/openchangelog/internal"
+	"github.com/jonashiltl/openchangelog/internal/source"
+	"github.com/jonashiltl/openchangelog/internal/xcache"
+)
+
+func openTestNotes(t *testing.T, files ...string) []source.RawReleaseNote {
+	notes := make([]source.RawReleaseNote, 0, len(files))
+	for _, file := range files {
+		content, err := os.Open(fmt.Sprintf("../../.testdata/%s", file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { content.Close() })
+		notes = append(notes, source.RawReleaseNote{Content: content})
+	}
+	return notes
+}
+
+func TestCacheParse(t *testing.T) {
+	tables := []struct {
+		name                  string
+		files                 []string
+		page                  internal.Pagination
+		expectedHasMore       bool
+		expectedArticleLength int
+	}{
+		{
+			name:                  "OG multiple",
+			files:                 []string{"v0.0.1-commonmark.md", "v0.0.2-open-source.md", "v0.0.5-beta.md"},
+			page:                  internal.NoPagination(),
+			expectedArticleLength: 3,
+		},
+		{
+			name:                  "Keepachangelog full paginated",
+			files:                 []string{"keepachangelog/full.md"},
+			page:                  internal.NewPagination(4, 2),
+			expectedHasMore:       true,
+			expectedArticleLength: 4,
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			p := NewParser(CreateGoldmark())
+			mem := xcache.NewMemoryCache()
+			c := NewCache(p, mem)
+			sid := source.ID(table.name)
+
+			expected := p.Parse(context.Background(), openTestNotes(t, table.files...), table.page)
+
+			// first parse fills the cache, second parse is served from it
+			for i := 0; i < 2; i++ {
+				parsed := c.Parse(context.Background(), sid, openTestNotes(t, table.files...), table.page)
+				if len(parsed.ReleaseNotes) != table.expectedArticleLength {
+					t.Fatalf("Expected article length %d but got %d", table.expectedArticleLength, len(parsed.ReleaseNotes))
+				}
+				if parsed.HasMore != table.expectedHasMore {
+					t.Errorf("Expected hasMore %t but got %t", table.expectedHasMore, parsed.HasMore)
+				}
+				for j, n := range parsed.ReleaseNotes {
+					if n.Meta.ID != expected.ReleaseNotes[j].Meta.ID {
+						t.Errorf("Expected note %s at index %d but got %s", expected.ReleaseNotes[j].Meta.ID, j, n.Meta.ID)
+					}
+					html, _ := io.ReadAll(n.Content)
+					if len(html) == 0 {
+						t.Errorf("Expected content of note %s to not be empty", n.Meta.ID)
+					}
+				}
+			}
+
+			key := cacheKey(sid)
+			if len(table.files) == 1 {
+				key = fileKey(sid)
+			}
+			if _, ok := mem.Get(key); !ok {
+				t.Fatal("Expected parsed release notes to be cached")
+			}
+			c.Evict(sid)
+			if _, ok := mem.Get(key); ok {
+				t.Error("Expected parsed release notes to be evicted")
+			}
+		})
+	}
+}
+
+func TestCacheParseChangedContent(t *testing.T) {
+	c := NewCache(NewParser(CreateGoldmark()), xcache.NewMemoryCache())
+	sid := source.ID("changed")
+
+	note := func(title string) []source.RawReleaseNote {
+		return []source.RawReleaseNote{{
+			Content: strings.NewReader(fmt.Sprintf("---\ntitle: %s\npublishedAt: 2024-01-01T00:00:00Z\n---\ncontent", title)),
+		}}
+	}
+
+	first := c.Parse(context.Background(), sid, note("first"), internal.NoPagination())
+	second := c.Parse(context.Background(), sid, note("second"), internal.NoPagination())
+	if first.ReleaseNotes[0].Meta.Title != "first" || second.ReleaseNotes[0].Meta.Title != "second" {
+		t.Errorf("Expected changed content to be parsed again, got %s and %s", first.ReleaseNotes[0].Meta.Title, second.ReleaseNotes[0].Meta.Title)
+	}
+}
+
+func TestCacheParsePagesOfSingleFile(t *testing.T) {
+	p := NewParser(CreateGoldmark())
+	mem := xcache.NewMemoryCache()
+	c := NewCache(p, mem)
+	sid := source.ID("pages")
+
+	full := p.Parse(context.Background(), openTestNotes(t, "keepachangelog/full.md"), internal.NoPagination())
+	var cached []byte
+	var ids []string
+	for page := 1; ; page++ {
+		parsed := c.Parse(context.Background(), sid, openTestNotes(t, "keepachangelog/full.md"), internal.NewPagination(3, page))
+		for _, n := range parsed.ReleaseNotes {
+			ids = append(ids, n.Meta.ID)
+		}
+
+		b, _ := mem.Get(fileKey(sid))
+		if cached != nil && string(b) != string(cached) {
+			t.Fatalf("Expected page %d to be served from the cached file", page)
+		}
+		cached = b
+		if !parsed.HasMore {
+			break
+		}
+	}
+
+	if len(ids) != len(full.ReleaseNotes) {
+		t.Fatalf("Expected %d release notes over all pages but got %d", len(full.ReleaseNotes), len(ids))
+	}
+	for i, n := range full.ReleaseNotes {
+		if ids[i] != n.Meta.ID {
+			t.Errorf("Expected note %s at index %d but got %s", n.Meta.ID, i, ids[i])
+		}
+	}
+}
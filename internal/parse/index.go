@@ -0,0 +1,141 @@
+package parse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/internal"
+	"github.com/jonashiltl/openchangelog/internal/source"
+	"github.com/jonashiltl/openchangelog/internal/xlog"
+)
+
+// Locates a single release note inside of it's source.
+type IndexEntry struct {
+	ID          string
+	PublishedAt time.Time
+	Draft       bool
+	// The index of the file in a directory source, or the index of the release in a single keep-a-changelog file.
+	Pos int
+}
+
+// Contains all release notes of a source in the order they are displayed.
+// Used to load and parse a single release note without loading the whole changelog.
+type Index struct {
+	Entries []IndexEntry
+	// True if all release notes were parsed from a single file.
+	SingleFile bool
+}
+
+// Returns the pagination that loads (directory) or parses (single file) only the release note of e.
+func (i Index) Page(e IndexEntry) internal.Pagination {
+	return internal.NewPagination(1, e.Pos+1)
+}
+
+// Returns the entry with the specified id and it's published neighbours.
+// Drafts and release notes scheduled to be published after now are skipped.
+func (i Index) Find(id string, now time.Time) (cur IndexEntry, prev *IndexEntry, next *IndexEntry, ok bool) {
+	published := make([]IndexEntry, 0, len(i.Entries))
+	for _, e := range i.Entries {
+		if !e.Draft && !e.PublishedAt.After(now) {
+			published = append(published, e)
+		}
+	}
+
+	for idx, e := range published {
+		if e.ID != id {
+			continue
+		}
+		if idx > 0 {
+			prev = &published[idx-1]
+		}
+		if idx < len(published)-1 {
+			next = &published[idx+1]
+		}
+		return e, prev, next, true
+	}
+	return IndexEntry{}, nil, nil, false
+}
+
+func indexKey(sid source.ID) string {
+	return fmt.Sprintf("index/v%d/%s", cacheVersion, sid)
+}
+
+// Parses all raw release notes of the source and stores the index of the result.
+// raw must contain all release notes of the source, loaded without pagination.
+func (c *Cache) ParseIndexed(ctx context.Context, sid source.ID, raw []source.RawReleaseNote) (ParseResult, Index) {
+	var idx Index
+	var result ParseResult
+
+	if len(raw) == 1 {
+		result = c.Parse(ctx, sid, raw, internal.NoPagination())
+		idx.SingleFile = true
+		for i, n := range result.ReleaseNotes {
+			idx.Entries = append(idx.Entries, IndexEntry{ID: n.Meta.ID, PublishedAt: n.Meta.PublishedAt, Draft: n.Meta.Draft, Pos: i})
+		}
+	} else {
+		type positioned struct {
+			note ParsedReleaseNote
+			pos  int
+		}
+		parsed, failed := c.parseFiles(sid, raw)
+		result.Errors = failed
+		notes := make([]positioned, 0, len(parsed))
+		for i, n := range parsed {
+			if n != nil {
+				notes = append(notes, positioned{note: *n, pos: i})
+			}
+		}
+		slices.SortStableFunc(notes, func(a, b positioned) int {
+			return sortArticleDesc(a.note, b.note)
+		})
+
+		result.ReleaseNotes = make([]ParsedReleaseNote, len(notes))
+		for i, n := range notes {
+			result.ReleaseNotes[i] = n.note
+			idx.Entries = append(idx.Entries, IndexEntry{ID: n.note.Meta.ID, PublishedAt: n.note.Meta.PublishedAt, Draft: n.note.Meta.Draft, Pos: n.pos})
+		}
+	}
+
+	c.setIndex(sid, idx)
+	return result, idx
+}
+
+// Returns the index of the source, if it was stored by ParseIndexed and not evicted since.
+func (c *Cache) GetIndex(sid source.ID) (Index, bool) {
+	if c.cache == nil {
+		return Index{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, ok := c.cache.Get(indexKey(sid))
+	if !ok {
+		return Index{}, false
+	}
+	var idx Index
+	err := json.Unmarshal(b, &idx)
+	if err != nil {
+		slog.Warn("failed to decode release note index", slog.String("sid", sid.String()), xlog.ErrAttr(err))
+		return Index{}, false
+	}
+	return idx, true
+}
+
+func (c *Cache) setIndex(sid source.ID, idx Index) {
+	if c.cache == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, err := json.Marshal(idx)
+	if err != nil {
+		slog.Warn("failed to encode release note index", slog.String("sid", sid.String()), xlog.ErrAttr(err))
+		return
+	}
+	c.cache.Set(indexKey(sid), b)
+}
@@ -0,0 +1,156 @@
+package parse
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/internal"
+	"github.com/jonashiltl/openchangelog/internal/source"
+	"github.com/jonashiltl/openchangelog/internal/xcache"
+)
+
+func TestParseIndexed(t *testing.T) {
+	tables := []struct {
+		name               string
+		files              []string
+		expectedSingleFile bool
+	}{
+		{
+			name:  "OG multiple",
+			files: []string{"v0.0.1-commonmark.md", "v0.0.5-beta.md", "v0.0.2-open-source.md"},
+		},
+		{
+			name:               "Keepachangelog full",
+			files:              []string{"keepachangelog/full.md"},
+			expectedSingleFile: true,
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			c := NewCache(NewParser(CreateGoldmark()), xcache.NewMemoryCache())
+			sid := source.ID(table.name)
+
+			res, idx := c.ParseIndexed(context.Background(), sid, openTestNotes(t, table.files...))
+			if idx.SingleFile != table.expectedSingleFile {
+				t.Errorf("expected single file to be %t but got %t", table.expectedSingleFile, idx.SingleFile)
+			}
+			if len(idx.Entries) != len(res.ReleaseNotes) {
+				t.Fatalf("expected %d index entries but got %d", len(res.ReleaseNotes), len(idx.Entries))
+			}
+
+			for i, e := range idx.Entries {
+				if e.ID != res.ReleaseNotes[i].Meta.ID {
+					t.Errorf("expected entry at index %d to be %s but got %s", i, res.ReleaseNotes[i].Meta.ID, e.ID)
+				}
+
+				// parsing only the release note at the position of the entry should return the same release note
+				page := idx.Page(e)
+				raw := openTestNotes(t, table.files...)
+				parsePage := internal.NoPagination()
+				if idx.SingleFile {
+					parsePage = page
+				} else {
+					raw = raw[page.StartIdx() : page.EndIdx()+1]
+				}
+				single := c.Parse(context.Background(), sid, raw, parsePage)
+				if len(single.ReleaseNotes) != 1 || single.ReleaseNotes[0].Meta.ID != e.ID {
+					t.Errorf("expected entry %s to be parsed at position %d", e.ID, e.Pos)
+				}
+			}
+
+			cached, ok := c.GetIndex(sid)
+			if !ok || len(cached.Entries) != len(idx.Entries) {
+				t.Errorf("expected index to be cached")
+			}
+
+			c.Evict(sid)
+			if _, ok := c.GetIndex(sid); ok {
+				t.Errorf("expected index to be evicted")
+			}
+		})
+	}
+}
+
+func TestIndexFind(t *testing.T) {
+	now := time.Now()
+	idx := Index{
+		Entries: []IndexEntry{
+			{ID: "future", PublishedAt: now.Add(time.Hour), Pos: 0},
+			{ID: "newest", PublishedAt: now.Add(-time.Hour), Pos: 1},
+			{ID: "middle", PublishedAt: now.Add(-2 * time.Hour), Pos: 2},
+			{ID: "draft", PublishedAt: now.Add(-150 * time.Minute), Draft: true, Pos: 3},
+			{ID: "oldest", PublishedAt: now.Add(-3 * time.Hour), Pos: 4},
+		},
+	}
+
+	tables := []struct {
+		name         string
+		id           string
+		expectedOk   bool
+		expectedPrev string
+		expectedNext string
+	}{
+		{
+			name:         "middle",
+			id:           "middle",
+			expectedOk:   true,
+			expectedPrev: "newest",
+			expectedNext: "oldest",
+		},
+		{
+			name:         "skips unpublished prev",
+			id:           "newest",
+			expectedOk:   true,
+			expectedNext: "middle",
+		},
+		{
+			name:         "last",
+			id:           "oldest",
+			expectedOk:   true,
+			expectedPrev: "middle",
+		},
+		{
+			name: "unpublished",
+			id:   "future",
+		},
+		{
+			name: "draft",
+			id:   "draft",
+		},
+		{
+			name: "unknown",
+			id:   "unknown",
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			cur, prev, next, ok := idx.Find(table.id, now)
+			if ok != table.expectedOk {
+				t.Fatalf("expected ok to be %t but got %t", table.expectedOk, ok)
+			}
+			if !ok {
+				return
+			}
+			if cur.ID != table.id {
+				t.Errorf("expected %s but got %s", table.id, cur.ID)
+			}
+
+			var prevID, nextID string
+			if prev != nil {
+				prevID = prev.ID
+			}
+			if next != nil {
+				nextID = next.ID
+			}
+			if prevID != table.expectedPrev {
+				t.Errorf("expected prev %q but got %q", table.expectedPrev, prevID)
+			}
+			if nextID != table.expectedNext {
+				t.Errorf("expected next %q but got %q", table.expectedNext, nextID)
+			}
+		})
+	}
+}
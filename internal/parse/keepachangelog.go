@@ -92,6 +92,9 @@ func (g *kparser) parseRelease(release string) (ParsedReleaseNote, error) {
 			Title:       title,
 			ID:          strings.ToLower(title),
 			PublishedAt: releaseDate,
+			Version:     versionPrefixRegex.FindString(title),
+			// the upcoming changes of the [Unreleased] section aren't published yet
+			Draft: strings.EqualFold(title, "unreleased"),
 		},
 	}
 
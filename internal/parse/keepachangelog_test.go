@@ -93,6 +93,10 @@ func TestKParseUnreleased(t *testing.T) {
 	if article.Meta.ID != expectedID {
 		t.Errorf("Expected %s to equal %s", article.Meta.ID, expectedID)
 	}
+
+	if !article.Meta.Draft {
+		t.Error("Expected unreleased section to be a draft")
+	}
 }
 
 func TestKParseFull(t *testing.T) {
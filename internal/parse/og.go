@@ -2,11 +2,8 @@ package parse
 
 import (
 	"bytes"
-	"fmt"
 	"io"
-	"log/slog"
 
-	"github.com/jonashiltl/openchangelog/internal/xlog"
 	enclave "github.com/quail-ink/goldmark-enclave"
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/extension"
@@ -80,7 +77,6 @@ func (g *ogparser) parseReleaseNoteBytes(content []byte) (ParsedReleaseNote, err
 	var target bytes.Buffer
 	err := g.gm.Convert(content, &target, gmparser.WithContext(ctx))
 	if err != nil {
-		slog.Warn("failed to convert to html", xlog.ErrAttr(err))
 		return ParsedReleaseNote{}, err
 	}
 
@@ -93,11 +89,10 @@ func (g *ogparser) parseReleaseNoteBytes(content []byte) (ParsedReleaseNote, err
 	var meta Meta
 	err = data.Decode(&meta)
 	if err != nil {
-		slog.Warn("failed to parse frontmatter", xlog.ErrAttr(err))
 		return ParsedReleaseNote{}, err
 	}
 
-	meta.ID = fmt.Sprint(meta.PublishedAt.Unix())
+	meta.setID()
 
 	return ParsedReleaseNote{
 		Meta:    meta,
@@ -71,3 +71,51 @@ func TestOGParseArticleRead(t *testing.T) {
 		t.Errorf("Expected %s to equal %s", parsed.Meta.PublishedAt, expectedPublishedAt)
 	}
 }
+
+func TestOGParseExtendedFrontmatter(t *testing.T) {
+	p := NewOGParser(CreateGoldmark())
+	parsed, err := p.parseReleaseNoteBytes([]byte(`---
+title: Dark Mode
+publishedAt: 2025-03-01
+version: v2.4.0
+slug: Dark Mode
+authors:
+  - Jane Doe
+  - John Doe
+image: https://example.com/cover.png
+draft: true
+pinned: true
+---
+Content`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := Meta{
+		ID:          "dark-mode",
+		Title:       "Dark Mode",
+		PublishedAt: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		Version:     "v2.4.0",
+		Slug:        "dark-mode",
+		Authors:     []string{"Jane Doe", "John Doe"},
+		Image:       "https://example.com/cover.png",
+		Draft:       true,
+		Pinned:      true,
+	}
+	if !reflect.DeepEqual(parsed.Meta, expected) {
+		t.Errorf("Expected %+v to equal %+v", parsed.Meta, expected)
+	}
+}
+
+func TestOGParseIDWithoutSlug(t *testing.T) {
+	p := NewOGParser(CreateGoldmark())
+	parsed, err := p.parseReleaseNoteBytes([]byte("---\ntitle: Dark Mode\npublishedAt: 2025-03-01\n---\nContent"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedID := fmt.Sprint(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC).Unix())
+	if parsed.Meta.ID != expectedID {
+		t.Errorf("Expected %s to equal %s", parsed.Meta.ID, expectedID)
+	}
+}
@@ -2,6 +2,7 @@ package parse
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"slices"
 	"sync"
@@ -13,12 +14,38 @@ import (
 )
 
 type Meta struct {
-	// unique id, we use the published date as unix timestampe
+	// unique id, the slug of the frontmatter or the published date as unix timestamp, see setID
 	ID          string
 	Title       string    `yaml:"title"`
 	Description string    `yaml:"description"`
 	PublishedAt time.Time `yaml:"publishedAt"`
 	Tags        []string  `yaml:"tags"`
+	// Derived from the file path if not set, see fillFromPath.
+	Version string   `yaml:"version"`
+	Slug    string   `yaml:"slug"`
+	Authors []string `yaml:"authors"`
+	// The url of the cover image.
+	Image string `yaml:"image"`
+	// Drafts are hidden until the flag is removed, but can be previewed, see Published.
+	Draft bool `yaml:"draft"`
+	// Pinned release notes are displayed before all others.
+	Pinned bool `yaml:"pinned"`
+}
+
+// Returns true if the release note is visible to everyone at now.
+// Drafts and release notes scheduled to be published after now are hidden.
+func (m Meta) Published(now time.Time) bool {
+	return !m.Draft && !m.PublishedAt.After(now)
+}
+
+// Uses the slug of the frontmatter as stable id, falls back to the published date.
+func (m *Meta) setID() {
+	m.Slug = toSlug(m.Slug)
+	if m.Slug != "" {
+		m.ID = m.Slug
+		return
+	}
+	m.ID = fmt.Sprint(m.PublishedAt.Unix())
 }
 
 type ParsedReleaseNote struct {
@@ -35,6 +62,8 @@ func (a *ParsedReleaseNote) AddTag(t string) {
 type ParseResult struct {
 	ReleaseNotes []ParsedReleaseNote
 	HasMore      bool
+	// The files that failed to be parsed, they are not part of ReleaseNotes.
+	Errors []source.FileError
 }
 
 // Creates a new Parser that can be used to parse RawReleaseNote files to ParsedReleaseNote.
@@ -84,6 +113,7 @@ func (p *Parser) Parse(ctx context.Context, raw []source.RawReleaseNote, kPage i
 	}
 
 	result := make([]ParsedReleaseNote, len(raw))
+	parseErrs := make([]error, len(raw))
 	var wg sync.WaitGroup
 	for i, a := range raw {
 		wg.Add(1)
@@ -91,8 +121,10 @@ func (p *Parser) Parse(ctx context.Context, raw []source.RawReleaseNote, kPage i
 			defer wg.Done()
 			parsed, err := p.og.parseReleaseNote(a.Content)
 			if err != nil {
+				parseErrs[index] = err
 				return
 			}
+			parsed.Meta.fillFromPath(a.Path)
 			// Store at the correct index to maintain order
 			result[index] = parsed
 		}(i, a)
@@ -101,9 +133,16 @@ func (p *Parser) Parse(ctx context.Context, raw []source.RawReleaseNote, kPage i
 
 	// Create ParseResult with the correctly ordered results
 	parseResult := ParseResult{
-		ReleaseNotes: result,
+		ReleaseNotes: make([]ParsedReleaseNote, 0, len(raw)),
 		HasMore:      false, // hasMore is only true for keep-a-changelog parser
 	}
+	for i, err := range parseErrs {
+		if err != nil {
+			parseResult.Errors = append(parseResult.Errors, source.FileError{Path: raw[i].Path, Err: err})
+			continue
+		}
+		parseResult.ReleaseNotes = append(parseResult.ReleaseNotes, result[i])
+	}
 
 	// Sort by descending order
 	slices.SortFunc(parseResult.ReleaseNotes, sortArticleDesc)
@@ -121,8 +160,11 @@ func (p *Parser) parseOne(raw source.RawReleaseNote, kPage internal.Pagination)
 	// use og parser
 	parsed, err := p.og.parseReleaseNoteRead(read, raw.Content)
 	if err != nil {
-		return ParseResult{}
+		return ParseResult{
+			Errors: []source.FileError{{Path: raw.Path, Err: err}},
+		}
 	}
+	parsed.Meta.fillFromPath(raw.Path)
 	return ParseResult{
 		ReleaseNotes: []ParsedReleaseNote{parsed},
 		HasMore:      false, // hasMore can only be true with the keep-a-changelog parser
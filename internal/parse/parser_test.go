@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/jonashiltl/openchangelog/internal"
 	"github.com/jonashiltl/openchangelog/internal/source"
+	"github.com/jonashiltl/openchangelog/internal/xcache"
 )
 
 func TestParse(t *testing.T) {
@@ -84,3 +86,50 @@ func TestParse(t *testing.T) {
 		})
 	}
 }
+
+func TestParseReportsFailedFiles(t *testing.T) {
+	newRaw := func() []source.RawReleaseNote {
+		return []source.RawReleaseNote{
+			{Path: "v2.md", Content: strings.NewReader("---\ntitle: v2\npublishedAt: 2025-03-01T00:00:00Z\n---\nv2")},
+			{Path: "invalid.md", Content: strings.NewReader("---\npublishedAt: not a date\n---\ninvalid")},
+			{Path: "v1.md", Content: strings.NewReader("---\ntitle: v1\npublishedAt: 2025-01-01T00:00:00Z\n---\nv1")},
+		}
+	}
+
+	assertResult := func(t *testing.T, parsed ParseResult) {
+		if len(parsed.ReleaseNotes) != 2 {
+			t.Fatalf("expected 2 release notes but got %d", len(parsed.ReleaseNotes))
+		}
+		for _, n := range parsed.ReleaseNotes {
+			if n.Content == nil {
+				t.Errorf("expected failed release notes to be left out")
+			}
+		}
+		if len(parsed.Errors) != 1 || parsed.Errors[0].Path != "invalid.md" {
+			t.Errorf("expected invalid.md to fail, got %v", parsed.Errors)
+		}
+	}
+
+	p := NewParser(CreateGoldmark())
+	t.Run("parser", func(t *testing.T) {
+		assertResult(t, p.Parse(context.Background(), newRaw(), internal.NoPagination()))
+	})
+
+	t.Run("cache", func(t *testing.T) {
+		c := NewCache(p, xcache.NewMemoryCache())
+		// the failed file isn't cached, so it's reported again
+		for range 2 {
+			assertResult(t, c.Parse(context.Background(), "sid", newRaw(), internal.NoPagination()))
+		}
+	})
+
+	t.Run("single file", func(t *testing.T) {
+		c := NewCache(p, xcache.NewMemoryCache())
+		for range 2 {
+			parsed := c.Parse(context.Background(), "sid", newRaw()[1:2], internal.NoPagination())
+			if len(parsed.ReleaseNotes) != 0 || len(parsed.Errors) != 1 {
+				t.Errorf("expected only an error, got %d release notes and %d errors", len(parsed.ReleaseNotes), len(parsed.Errors))
+			}
+		}
+	})
+}
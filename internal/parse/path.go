@@ -0,0 +1,103 @@
+package parse
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// e.g. 2025-03-14-v2.4.0.md
+	datePrefixRegex = regexp.MustCompile(`^(\d{4})-(\d{2})-(\d{2})(?:[-_ ]|$)`)
+	// e.g. v2.4.0-search.md
+	versionPrefixRegex = regexp.MustCompile(`^v?\d+(?:\.\d+)+`)
+	yearRegex          = regexp.MustCompile(`^\d{4}$`)
+	monthOrDayRegex    = regexp.MustCompile(`^\d{2}$`)
+	slugInvalidRegex   = regexp.MustCompile(`[^a-z0-9.]+`)
+)
+
+// Sets the publishedAt, version and slug that aren't defined in the frontmatter from the path of the release note file.
+// The date is read from a YYYY-MM-DD prefix of the file name or from YYYY/MM(/DD) directories,
+// the version from a semver like prefix of the remaining file name, which is also used as slug.
+// The slug of the path is used as id, unless the frontmatter defines a slug,
+// the id only falls back to the derived date if the path has no slug.
+func (m *Meta) fillFromPath(p string) {
+	if p == "" {
+		return
+	}
+	hasSlug := m.Slug != ""
+	publishedAt, version, slug := metaFromPath(p)
+	if m.PublishedAt.IsZero() && !publishedAt.IsZero() {
+		m.PublishedAt = publishedAt
+		if !hasSlug {
+			m.ID = fmt.Sprint(publishedAt.Unix())
+		}
+	}
+	if m.Version == "" {
+		m.Version = version
+	}
+	if !hasSlug && slug != "" {
+		// the file name is stable, unlike the published date which can be changed or shared by release notes
+		m.Slug = slug
+		m.ID = slug
+	}
+}
+
+func metaFromPath(p string) (publishedAt time.Time, version, slug string) {
+	name := strings.TrimSuffix(path.Base(p), path.Ext(p))
+
+	if match := datePrefixRegex.FindStringSubmatch(name); match != nil {
+		publishedAt = newDate(match[1], match[2], match[3])
+		if !publishedAt.IsZero() {
+			rest := name[len(match[0]):]
+			if rest != "" {
+				name = rest
+			}
+		}
+	}
+	if publishedAt.IsZero() {
+		publishedAt = dateFromDirs(path.Dir(p))
+	}
+
+	version = versionPrefixRegex.FindString(name)
+	return publishedAt, version, toSlug(name)
+}
+
+// Lowercases s and replaces everything except letters, digits and dots with dashes, so it can be used in urls.
+func toSlug(s string) string {
+	return strings.Trim(slugInvalidRegex.ReplaceAllString(strings.ToLower(s), "-"), "-")
+}
+
+// Reads the date from YYYY/MM or YYYY/MM/DD directories, e.g. 2025/03/v2.4.0.md.
+func dateFromDirs(dir string) time.Time {
+	dirs := strings.Split(dir, "/")
+	for i := 0; i+1 < len(dirs); i++ {
+		if !yearRegex.MatchString(dirs[i]) || !monthOrDayRegex.MatchString(dirs[i+1]) {
+			continue
+		}
+		day := "01"
+		if i+2 < len(dirs) && monthOrDayRegex.MatchString(dirs[i+2]) {
+			day = dirs[i+2]
+		}
+		if date := newDate(dirs[i], dirs[i+1], day); !date.IsZero() {
+			return date
+		}
+	}
+	return time.Time{}
+}
+
+// Returns the zero time if the date is invalid.
+func newDate(year, month, day string) time.Time {
+	y, _ := strconv.Atoi(year)
+	m, _ := strconv.Atoi(month)
+	d, _ := strconv.Atoi(day)
+	date := time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC)
+	// time.Date normalizes invalid dates like 2025-02-30
+	if date.Year() != y || int(date.Month()) != m || date.Day() != d {
+		return time.Time{}
+	}
+	return date
+}
@@ -0,0 +1,137 @@
+package parse
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/internal"
+	"github.com/jonashiltl/openchangelog/internal/source"
+)
+
+func TestMetaFromPath(t *testing.T) {
+	tables := []struct {
+		path        string
+		publishedAt time.Time
+		version     string
+		slug        string
+	}{
+		{
+			path:        "2025/03/v2.4.0.md",
+			publishedAt: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+			version:     "v2.4.0",
+			slug:        "v2.4.0",
+		},
+		{
+			path:        "2025/03/14/search.md",
+			publishedAt: time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC),
+			slug:        "search",
+		},
+		{
+			path:        "2025-03-14-v2.4.0-Search Filters.md",
+			publishedAt: time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC),
+			version:     "v2.4.0",
+			slug:        "v2.4.0-search-filters",
+		},
+		{
+			path:        "notes/2025-03-14.md",
+			publishedAt: time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC),
+			slug:        "2025-03-14",
+		},
+		{
+			path:    "1.2.md",
+			version: "1.2",
+			slug:    "1.2",
+		},
+		{
+			path: "v0.0.1-commonmark.md",
+			// the version prefix is no date
+			version: "v0.0.1",
+			slug:    "v0.0.1-commonmark",
+		},
+		{
+			path: "2025-02-30-invalid.md",
+			slug: "2025-02-30-invalid",
+		},
+		{
+			path: "2025/13/release.md",
+			slug: "release",
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.path, func(t *testing.T) {
+			publishedAt, version, slug := metaFromPath(table.path)
+			if !publishedAt.Equal(table.publishedAt) {
+				t.Errorf("expected publishedAt %s but got %s", table.publishedAt, publishedAt)
+			}
+			if version != table.version {
+				t.Errorf("expected version %q but got %q", table.version, version)
+			}
+			if slug != table.slug {
+				t.Errorf("expected slug %q but got %q", table.slug, slug)
+			}
+		})
+	}
+}
+
+func TestFillFromPath(t *testing.T) {
+	t.Run("keeps frontmatter", func(t *testing.T) {
+		publishedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		m := Meta{ID: "1704067200", PublishedAt: publishedAt, Version: "v1", Slug: "first"}
+		m.fillFromPath("2025/03/v2.4.0.md")
+		if !m.PublishedAt.Equal(publishedAt) || m.ID != "1704067200" || m.Version != "v1" || m.Slug != "first" {
+			t.Errorf("expected frontmatter to be kept, got %+v", m)
+		}
+	})
+
+	t.Run("fills missing", func(t *testing.T) {
+		var m Meta
+		m.fillFromPath("2025/03/v2.4.0.md")
+		expected := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+		if !m.PublishedAt.Equal(expected) {
+			t.Errorf("expected publishedAt %s but got %s", expected, m.PublishedAt)
+		}
+		if m.ID != "v2.4.0" {
+			t.Errorf("expected id of the path slug, got %s", m.ID)
+		}
+		if m.Version != "v2.4.0" {
+			t.Errorf("expected version v2.4.0 but got %s", m.Version)
+		}
+	})
+
+	t.Run("prefers path slug over date", func(t *testing.T) {
+		m := Meta{ID: "1704067200", PublishedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+		m.fillFromPath("notes/search.md")
+		if m.ID != "search" || m.Slug != "search" {
+			t.Errorf("expected id and slug of the path, got %+v", m)
+		}
+	})
+
+	t.Run("date only file name", func(t *testing.T) {
+		var m Meta
+		m.fillFromPath("2025-03-14.md")
+		if m.ID != "2025-03-14" || !m.PublishedAt.Equal(time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("expected the date as slug and publishedAt, got %+v", m)
+		}
+	})
+}
+
+func TestParseDerivesMetaFromPath(t *testing.T) {
+	p := NewParser(CreateGoldmark())
+	res := p.Parse(context.Background(), []source.RawReleaseNote{
+		{Path: "2025/03/v2.4.0.md", Content: strings.NewReader("---\ntitle: Search\n---\nsearch")},
+		{Path: "2025/01/v2.3.0.md", Content: strings.NewReader("no frontmatter")},
+	}, internal.NoPagination())
+
+	if len(res.ReleaseNotes) != 2 {
+		t.Fatalf("expected 2 release notes but got %d", len(res.ReleaseNotes))
+	}
+	if res.ReleaseNotes[0].Meta.Version != "v2.4.0" || res.ReleaseNotes[1].Meta.Version != "v2.3.0" {
+		t.Errorf("expected release notes to be sorted by the derived date, got %s, %s", res.ReleaseNotes[0].Meta.Version, res.ReleaseNotes[1].Meta.Version)
+	}
+	if res.ReleaseNotes[0].Meta.Title != "Search" {
+		t.Errorf("expected title from frontmatter, got %s", res.ReleaseNotes[0].Meta.Title)
+	}
+}
@@ -28,8 +28,11 @@ func detectFileFormat(r io.Reader) (FileFormat, string) {
 	return KeepAChangelog, start
 }
 
-// Sorts ParsedArticles by their published date.
+// Sorts ParsedArticles by their published date, pinned release notes first.
 func sortArticleDesc(a ParsedReleaseNote, b ParsedReleaseNote) int {
+	if c := ComparePinned(a, b); c != 0 {
+		return c
+	}
 	if a.Meta.PublishedAt.IsZero() && b.Meta.PublishedAt.IsZero() {
 		return 0
 	}
@@ -46,3 +49,14 @@ func sortArticleDesc(a ParsedReleaseNote, b ParsedReleaseNote) int {
 
 	return 1
 }
+
+// Orders pinned release notes before the others, returns 0 if both or neither are pinned.
+func ComparePinned(a ParsedReleaseNote, b ParsedReleaseNote) int {
+	if a.Meta.Pinned == b.Meta.Pinned {
+		return 0
+	}
+	if a.Meta.Pinned {
+		return -1
+	}
+	return 1
+}
@@ -55,6 +55,20 @@ func TestSortArticleDesc(t *testing.T) {
 	}
 }
 
+func TestSortArticleDescPinned(t *testing.T) {
+	slice := []ParsedReleaseNote{
+		{Meta: Meta{Title: "a", PublishedAt: time.Date(2024, 10, 20, 0, 0, 0, 0, time.UTC)}},
+		{Meta: Meta{Title: "b", PublishedAt: time.Date(2024, 10, 19, 0, 0, 0, 0, time.UTC), Pinned: true}},
+		{Meta: Meta{Title: "c"}},
+	}
+	slices.SortFunc(slice, sortArticleDesc)
+
+	titles := []string{slice[0].Meta.Title, slice[1].Meta.Title, slice[2].Meta.Title}
+	if !slices.Equal(titles, []string{"b", "c", "a"}) {
+		t.Errorf("Expected pinned release note first but got %v", titles)
+	}
+}
+
 func TestDetectFileFormat(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -0,0 +1,76 @@
+package preview
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/internal/errs"
+	"github.com/jonashiltl/openchangelog/internal/store"
+)
+
+// Preview links render a single release note of a changelog, including drafts and scheduled release notes,
+// until they expire. They are signed with the preview secret, so they can be shared without further auth.
+const (
+	EXPIRES_QUERY   = "expires"
+	SIGNATURE_QUERY = "sig"
+	// Used if no expiry is specified when creating a link.
+	DefaultTTL = 7 * 24 * time.Hour
+	MaxTTL     = 90 * 24 * time.Hour
+)
+
+var errInvalidLink = errs.NewUnauthorized(errors.New("invalid or expired preview link"))
+
+// Returns the path of the preview link of the release note nid, relative to the changelog url.
+func Path(secret string, cid store.ChangelogID, nid string, expiresAt time.Time) string {
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+	q := url.Values{}
+	q.Set(EXPIRES_QUERY, expires)
+	q.Set(SIGNATURE_QUERY, sign(secret, cid, nid, expires))
+	return fmt.Sprintf("/preview/%s?%s", url.PathEscape(nid), q.Encode())
+}
+
+// Returns an error if q doesn't contain a valid signature for the release note nid or the link expired.
+func Verify(secret string, cid store.ChangelogID, nid string, q url.Values, now time.Time) error {
+	expires := q.Get(EXPIRES_QUERY)
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || !now.Before(time.Unix(unix, 0)) {
+		return errInvalidLink
+	}
+
+	sig, err := hex.DecodeString(q.Get(SIGNATURE_QUERY))
+	if err != nil {
+		return errInvalidLink
+	}
+	expected, _ := hex.DecodeString(sign(secret, cid, nid, expires))
+	if !hmac.Equal(sig, expected) {
+		return errInvalidLink
+	}
+	return nil
+}
+
+// Returns the ttl of a new link, ttl is the requested ttl in seconds.
+func ParseTTL(ttl int) (time.Duration, error) {
+	if ttl < 0 {
+		return 0, errs.NewBadRequest(errors.New("expiresIn can't be negative"))
+	}
+	if ttl == 0 {
+		return DefaultTTL, nil
+	}
+	d := time.Duration(ttl) * time.Second
+	if d > MaxTTL {
+		return 0, errs.NewBadRequest(fmt.Errorf("preview links can expire in at most %d seconds", int(MaxTTL.Seconds())))
+	}
+	return d, nil
+}
+
+func sign(secret string, cid store.ChangelogID, nid string, expires string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(fmt.Sprintf("preview:%s:%s:%s", cid, nid, expires)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,132 @@
+package preview
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/internal/store"
+)
+
+func TestVerify(t *testing.T) {
+	now := time.Now()
+	cid := store.ChangelogID("cl_1")
+	valid := linkQuery(t, Path("secret", cid, "v1.0.0", now.Add(time.Hour)))
+
+	tables := []struct {
+		name     string
+		secret   string
+		cid      store.ChangelogID
+		nid      string
+		q        url.Values
+		expectOk bool
+	}{
+		{
+			name:     "valid",
+			secret:   "secret",
+			cid:      cid,
+			nid:      "v1.0.0",
+			q:        valid,
+			expectOk: true,
+		},
+		{
+			name:   "other release note",
+			secret: "secret",
+			cid:    cid,
+			nid:    "v2.0.0",
+			q:      valid,
+		},
+		{
+			name:   "other changelog",
+			secret: "secret",
+			cid:    store.ChangelogID("cl_2"),
+			nid:    "v1.0.0",
+			q:      valid,
+		},
+		{
+			name:   "other secret",
+			secret: "other",
+			cid:    cid,
+			nid:    "v1.0.0",
+			q:      valid,
+		},
+		{
+			name:   "expired",
+			secret: "secret",
+			cid:    cid,
+			nid:    "v1.0.0",
+			q:      linkQuery(t, Path("secret", cid, "v1.0.0", now.Add(-time.Minute))),
+		},
+		{
+			name:   "extended expiry",
+			secret: "secret",
+			cid:    cid,
+			nid:    "v1.0.0",
+			q: url.Values{
+				EXPIRES_QUERY:   {"99999999999"},
+				SIGNATURE_QUERY: {valid.Get(SIGNATURE_QUERY)},
+			},
+		},
+		{
+			name:   "missing query",
+			secret: "secret",
+			cid:    cid,
+			nid:    "v1.0.0",
+			q:      url.Values{},
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			err := Verify(table.secret, table.cid, table.nid, table.q, now)
+			if table.expectOk && err != nil {
+				t.Errorf("expected link to be valid but got %s", err)
+			}
+			if !table.expectOk && err == nil {
+				t.Error("expected link to be invalid")
+			}
+		})
+	}
+}
+
+func TestParseTTL(t *testing.T) {
+	tables := []struct {
+		ttl       int
+		expected  time.Duration
+		expectErr bool
+	}{
+		{ttl: 0, expected: DefaultTTL},
+		{ttl: 3600, expected: time.Hour},
+		{ttl: -1, expectErr: true},
+		{ttl: int(MaxTTL.Seconds()) + 1, expectErr: true},
+	}
+
+	for _, table := range tables {
+		got, err := ParseTTL(table.ttl)
+		if table.expectErr {
+			if err == nil {
+				t.Errorf("expected error for ttl %d", table.ttl)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != table.expected {
+			t.Errorf("expected %s but got %s", table.expected, got)
+		}
+	}
+}
+
+func linkQuery(t *testing.T, path string) url.Values {
+	t.Helper()
+	if !strings.HasPrefix(path, "/preview/") {
+		t.Fatalf("unexpected preview path %s", path)
+	}
+	u, err := url.Parse(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u.Query()
+}
@@ -97,6 +97,8 @@ func buildIndexMapping() (mapping.IndexMapping, error) {
 	releaseNoteMapping.AddFieldMappingsAt("Description", descriptionFieldMapping())
 	releaseNoteMapping.AddFieldMappingsAt("PublishedAt", publishedAtFieldMapping())
 	releaseNoteMapping.AddFieldMappingsAt("Tags", tagsFieldMapping())
+	releaseNoteMapping.AddFieldMappingsAt("Version", versionFieldMapping())
+	releaseNoteMapping.AddFieldMappingsAt("Authors", authorsFieldMapping())
 	releaseNoteMapping.AddFieldMappingsAt("Content", contentFieldMapping())
 
 	indexMapping.AddDocumentMapping("note", releaseNoteMapping)
@@ -133,6 +135,16 @@ func tagsFieldMapping() *mapping.FieldMapping {
 	return fm
 }
 
+func versionFieldMapping() *mapping.FieldMapping {
+	fm := mapping.NewTextFieldMapping()
+	fm.Analyzer = keyword.Name
+	return fm
+}
+
+func authorsFieldMapping() *mapping.FieldMapping {
+	return titleFieldMapping()
+}
+
 func contentFieldMapping() *mapping.FieldMapping {
 	fm := mapping.NewTextFieldMapping()
 	fm.Analyzer = "custom-html"
@@ -31,3 +31,7 @@ func (s noopSearcher) GetAllTags(ctx context.Context, sid string) []string {
 func (s noopSearcher) BatchRemove(ctx context.Context, args BatchRemoveArgs) error {
 	return nil
 }
+
+func (s noopSearcher) Prune(ctx context.Context, args PruneArgs) error {
+	return nil
+}
@@ -25,6 +25,7 @@ type Searcher interface {
 	Index(context.Context, IndexArgs) error
 	BatchIndex(context.Context, BatchIndexArgs) error
 	BatchRemove(ctx context.Context, args BatchRemoveArgs) error
+	Prune(ctx context.Context, args PruneArgs) error
 	Close()
 }
 
@@ -52,6 +53,8 @@ type storedReleaseNote struct {
 	Description string
 	PublishedAt time.Time
 	Tags        []string
+	Version     string
+	Authors     []string
 	Content     string
 }
 
@@ -72,11 +75,14 @@ func (s *bleveSearcher) Close() {
 
 type SearchResult struct {
 	// the id of the release note
-	ID               string
+	ID string
+	// the id of the source of the release note
+	SID              string
 	Title            string
 	Description      string
 	ContentHighlight string
 	Content          string
+	Version          string
 	PublishedAt      time.Time
 	Score            float64
 	Fragments        map[string][]string
@@ -90,15 +96,23 @@ type SearchResults struct {
 }
 
 type SearchArgs struct {
-	SID   string
+	SID string
+	// Searches the release notes of all sources, used instead of SID if not empty.
+	SIDs  []string
 	Tags  []string
 	Query string
+	// Maximum number of hits, defaults to 10 if zero.
+	Size int
 }
 
 func (s *bleveSearcher) Search(ctx context.Context, args SearchArgs) (SearchResults, error) {
 	query := buildSearchQuery(ctx, args)
-	req := bleve.NewSearchRequestOptions(query, 10, 0, false)
-	req.Fields = []string{"Title", "Description", "Content", "PublishedAt"}
+	size := args.Size
+	if size <= 0 {
+		size = 10
+	}
+	req := bleve.NewSearchRequestOptions(query, size, 0, false)
+	req.Fields = []string{"Title", "Description", "Content", "Version", "PublishedAt"}
 	req.Highlight = bleve.NewHighlightWithStyle("html")
 
 	res, err := s.idx.SearchInContext(ctx, req)
@@ -116,6 +130,7 @@ func (s *bleveSearcher) Search(ctx context.Context, args SearchArgs) (SearchResu
 	for i, hit := range res.Hits {
 		result := SearchResult{
 			ID:        idToReleaseNoteID(hit.ID),
+			SID:       idToSID(hit.ID),
 			Score:     hit.Score,
 			Fragments: hit.Fragments,
 			Fields:    hit.Fields,
@@ -143,6 +158,9 @@ func (s *bleveSearcher) Search(ctx context.Context, args SearchArgs) (SearchResu
 		if highlights, exists := hit.Fragments["Content"]; exists && len(highlights) > 0 {
 			result.ContentHighlight = surroundWithEllipsis(stripPartialHTML(highlights[0]))
 		}
+		if version, exists := hit.Fields["Version"]; exists {
+			result.Version = fmt.Sprint(version)
+		}
 		if ts, exists := hit.Fields["PublishedAt"]; exists {
 			t, err := time.Parse(time.RFC3339, ts.(string))
 			if err == nil {
@@ -277,11 +295,23 @@ func buildSearchQuery(ctx context.Context, args SearchArgs) query.Query {
 		slog.String("sid", args.SID),
 		slog.String("query", args.Query),
 	)
-	sIDQuery := bleve.NewMatchQuery(args.SID)
-	sIDQuery.SetField("SID")
+	sids := args.SIDs
+	if len(sids) == 0 {
+		sids = []string{args.SID}
+	}
+	sIDQueries := make([]query.Query, len(sids))
+	for i, sid := range sids {
+		sIDQuery := bleve.NewMatchQuery(sid)
+		sIDQuery.SetField("SID")
+		sIDQueries[i] = sIDQuery
+	}
 
 	query := bleve.NewBooleanQuery()
-	query.AddMust(sIDQuery)
+	if len(sIDQueries) == 1 {
+		query.AddMust(sIDQueries[0])
+	} else {
+		query.AddMust(bleve.NewDisjunctionQuery(sIDQueries...))
+	}
 
 	if len(args.Tags) > 0 {
 		for _, t := range args.Tags {
@@ -302,8 +332,15 @@ func buildSearchQuery(ctx context.Context, args SearchArgs) query.Query {
 		contentQuery := bleve.NewMatchQuery(args.Query)
 		contentQuery.SetField("Content")
 
-		combinedQuery := bleve.NewDisjunctionQuery(titleQuery, descQuery, contentQuery)
-		query.AddMust(combinedQuery) // ... AND title OR desc OR content
+		versionQuery := bleve.NewMatchQuery(args.Query)
+		versionQuery.SetField("Version")
+		versionQuery.SetBoost(4)
+
+		authorsQuery := bleve.NewMatchQuery(args.Query)
+		authorsQuery.SetField("Authors")
+
+		combinedQuery := bleve.NewDisjunctionQuery(titleQuery, descQuery, contentQuery, versionQuery, authorsQuery)
+		query.AddMust(combinedQuery) // ... AND title OR desc OR content OR version OR authors
 	}
 	return query
 }
@@ -329,6 +366,8 @@ func (s *bleveSearcher) Index(ctx context.Context, args IndexArgs) error {
 		Description: args.ReleaseNote.Meta.Description,
 		PublishedAt: args.ReleaseNote.Meta.PublishedAt,
 		Tags:        args.ReleaseNote.Meta.Tags,
+		Version:     args.ReleaseNote.Meta.Version,
+		Authors:     args.ReleaseNote.Meta.Authors,
 		Content:     string(content),
 	}
 
@@ -358,6 +397,8 @@ func (s *bleveSearcher) BatchIndex(ctx context.Context, args BatchIndexArgs) err
 			Description: note.Meta.Description,
 			PublishedAt: note.Meta.PublishedAt,
 			Tags:        note.Meta.Tags,
+			Version:     note.Meta.Version,
+			Authors:     note.Meta.Authors,
 			Content:     string(content),
 		}
 
@@ -396,6 +437,49 @@ func (s *bleveSearcher) BatchRemove(ctx context.Context, args BatchRemoveArgs) e
 	return nil
 }
 
+type PruneArgs struct {
+	SID string
+	// The release notes that still exist, every other document of the source is removed.
+	ReleaseNotes []parse.ParsedReleaseNote
+}
+
+// Removes the documents of release notes that no longer exist in the source, e.g. because their file was deleted.
+func (s *bleveSearcher) Prune(ctx context.Context, args PruneArgs) error {
+	keep := mapset.NewThreadUnsafeSet[string]()
+	for _, note := range args.ReleaseNotes {
+		keep.Add(createID(args.SID, note.Meta.ID))
+	}
+
+	query := bleve.NewMatchQuery(args.SID)
+	query.SetField("SID")
+
+	const pageSize = 100
+	b := s.idx.NewBatch()
+	for from := 0; ; from += pageSize {
+		req := bleve.NewSearchRequestOptions(query, pageSize, from, false)
+		res, err := s.idx.SearchInContext(ctx, req)
+		if err != nil {
+			return err
+		}
+		for _, hit := range res.Hits {
+			if !keep.Contains(hit.ID) {
+				slog.Debug("removing document", slog.String("id", hit.ID))
+				b.Delete(hit.ID)
+			}
+		}
+		if len(res.Hits) < pageSize {
+			break
+		}
+	}
+
+	if b.Size() > 0 {
+		if err := s.idx.Batch(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func createID(sID, releaseNoteID string) string {
 	return fmt.Sprintf("%s/%s", sID, releaseNoteID)
 }
@@ -404,3 +488,11 @@ func idToReleaseNoteID(id string) string {
 	parts := strings.Split(id, "/")
 	return parts[len(parts)-1]
 }
+
+func idToSID(id string) string {
+	i := strings.LastIndex(id, "/")
+	if i < 0 {
+		return ""
+	}
+	return id[:i]
+}
@@ -13,7 +13,7 @@ import (
 	"github.com/jonashiltl/openchangelog/internal/source"
 )
 
-var sid = source.NewGitHubID("owner", "repo", "path")
+var sid = source.NewGitHubID("", "owner", "repo", "", "path")
 var indexData = BatchIndexArgs{
 	SID: sid.String(),
 	ReleaseNotes: []parse.ParsedReleaseNote{
@@ -183,6 +183,32 @@ func TestBatchRemove(t *testing.T) {
 	}
 }
 
+func TestPrune(t *testing.T) {
+	searcher := newMemorySearcher(t)
+	ctx := context.Background()
+	err := searcher.Prune(ctx, PruneArgs{
+		SID:          sid.String(),
+		ReleaseNotes: indexData.ReleaseNotes[:1],
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	res, err := searcher.Search(ctx, SearchArgs{
+		SID: sid.String(),
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(res.Hits) != 1 {
+		t.Fatalf("expected 1 hit, but got %d", len(res.Hits))
+	}
+	if res.Hits[0].ID != indexData.ReleaseNotes[0].Meta.ID {
+		t.Errorf("expected %s to be kept, but got %s", indexData.ReleaseNotes[0].Meta.ID, res.Hits[0].ID)
+	}
+}
+
 func TestGetTags(t *testing.T) {
 	searcher := newMemorySearcher(t)
 
@@ -0,0 +1,198 @@
+package source
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/jonashiltl/openchangelog/internal/xcache"
+	"golang.org/x/sync/errgroup"
+)
+
+// A file of a git repository, identified by the sha of it's content.
+type blobFile struct {
+	// The path of the file in the repository.
+	path string
+	sha  string
+}
+
+// Up to this many uncached files are fetched by their sha, more are read from an archive of the repository.
+const maxBlobFetches = 3
+
+// Limits the concurrent requests, a directory without a cache is loaded blob by blob.
+const concurrentBlobFetches = 8
+
+// Archives are read until this size, larger archives fail to load, so a single source can't exhaust the memory.
+const maxArchiveSize = 256 << 20
+
+// Loads the contents of files in a git repository.
+// Contents are cached by their blob sha, which only changes with the content,
+// so unchanged files are never downloaded again.
+type blobLoader struct {
+	cache xcache.Cache
+	// prefix of the cache keys, separates the repositories
+	prefix string
+	// fetches the content of a single blob
+	fetchBlob func(ctx context.Context, sha string) ([]byte, error)
+	// fetches a gzipped tarball of the repository, containing the files below a single root directory
+	fetchArchive func(ctx context.Context) (io.ReadCloser, error)
+}
+
+// Returns the contents of files in the same order, or the errors of the files that failed to be loaded.
+// Uncached files are fetched by their sha, unless more than maxBlobFetches are missing,
+// then they are read from one archive of the repository.
+// All listed files found in the archive are cached, so the following pages of a directory don't need another request.
+// Without a cache the archive would be downloaded for every page, so files are always fetched by their sha.
+// Files which aren't part of the archive, e.g. because the ref moved since it was listed, are fetched by their sha.
+func (b blobLoader) load(ctx context.Context, files []blobFile, listed []blobFile) ([][]byte, []error) {
+	contents := make([][]byte, len(files))
+	loadErrs := make([]error, len(files))
+
+	missing := make([]int, 0)
+	for i, f := range files {
+		if c, ok := b.get(f.sha); ok {
+			contents[i] = c
+		} else {
+			missing = append(missing, i)
+		}
+	}
+
+	if b.cache != nil && len(missing) > maxBlobFetches {
+		archived, err := b.loadArchive(ctx, listed)
+		if err != nil {
+			for _, i := range missing {
+				loadErrs[i] = err
+			}
+			return contents, loadErrs
+		}
+		remaining := missing[:0]
+		for _, i := range missing {
+			if c, ok := archived[files[i].sha]; ok {
+				contents[i] = c
+			} else {
+				remaining = append(remaining, i)
+			}
+		}
+		missing = remaining
+	}
+
+	var eg errgroup.Group
+	eg.SetLimit(concurrentBlobFetches)
+	for _, i := range missing {
+		eg.Go(func() error {
+			c, err := b.fetchBlob(ctx, files[i].sha)
+			if err != nil {
+				loadErrs[i] = err
+				return nil
+			}
+			b.set(files[i].sha, c)
+			contents[i] = c
+			return nil
+		})
+	}
+	eg.Wait()
+
+	return contents, loadErrs
+}
+
+// Reads the listed files from the archive and caches them, returns their contents by sha.
+// Files whose content doesn't match their sha are skipped.
+func (b blobLoader) loadArchive(ctx context.Context, listed []blobFile) (map[string][]byte, error) {
+	shas := make(map[string]string, len(listed))
+	for _, f := range listed {
+		shas[strings.TrimPrefix(f.path, "/")] = f.sha
+	}
+
+	archive, err := b.fetchArchive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download archive: %w", err)
+	}
+	defer archive.Close()
+
+	gz, err := gzip.NewReader(http.MaxBytesReader(nil, archive, maxArchiveSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+	defer gz.Close()
+
+	contents := make(map[string][]byte, len(listed))
+	tr := tar.NewReader(gz)
+	for {
+		h, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return contents, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if h.Typeflag != tar.TypeReg {
+			continue
+		}
+		// strip the root directory
+		_, path, ok := strings.Cut(h.Name, "/")
+		if !ok {
+			continue
+		}
+		sha, ok := shas[path]
+		if !ok {
+			continue
+		}
+		c, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from archive: %w", path, err)
+		}
+		if gitBlobSHA(c) == sha {
+			b.set(sha, c)
+			contents[sha] = c
+		}
+	}
+}
+
+func (b blobLoader) get(sha string) ([]byte, bool) {
+	if b.cache == nil {
+		return nil, false
+	}
+	return b.cache.Get(b.key(sha))
+}
+
+func (b blobLoader) set(sha string, content []byte) {
+	if b.cache != nil {
+		b.cache.Set(b.key(sha), content)
+	}
+}
+
+func (b blobLoader) key(sha string) string {
+	return fmt.Sprintf("blob/%s/%s", b.prefix, sha)
+}
+
+// Returns the sha git uses to identify a blob with the content.
+func gitBlobSHA(content []byte) string {
+	h := sha1.New()
+	fmt.Fprintf(h, "blob %d\x00", len(content))
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Downloads the archive from the url, which is usually a short lived link of the provider.
+func downloadArchive(ctx context.Context, client *http.Client, url string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to download archive, status %d", resp.StatusCode)
+	}
+	return resp.Body, nil
+}
@@ -0,0 +1,180 @@
+package source
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jonashiltl/openchangelog/internal/xcache"
+)
+
+func createArchive(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		err := tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Typeflag: tar.TypeReg,
+			Mode:     0644,
+			Size:     int64(len(content)),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		tw.Write([]byte(content))
+	}
+	tw.Close()
+	gz.Close()
+	return buf.Bytes()
+}
+
+type blobTestServer struct {
+	archive  []byte
+	blobs    map[string]string
+	archives atomic.Int32
+	fetched  atomic.Int32
+}
+
+func (s *blobTestServer) loader(cache xcache.Cache) blobLoader {
+	return blobLoader{
+		cache:  cache,
+		prefix: "test",
+		fetchBlob: func(_ context.Context, sha string) ([]byte, error) {
+			s.fetched.Add(1)
+			c, ok := s.blobs[sha]
+			if !ok {
+				return nil, errors.New("blob not found")
+			}
+			return []byte(c), nil
+		},
+		fetchArchive: func(_ context.Context) (io.ReadCloser, error) {
+			s.archives.Add(1)
+			return io.NopCloser(bytes.NewReader(s.archive)), nil
+		},
+	}
+}
+
+func TestGitBlobSHA(t *testing.T) {
+	// sha of "hello world\n" as computed by git hash-object
+	expected := "3b18e512dba79e4c8300dd08aeb37f8e728b8dad"
+	if sha := gitBlobSHA([]byte("hello world\n")); sha != expected {
+		t.Errorf("expected %s but got %s", expected, sha)
+	}
+}
+
+func TestBlobLoaderLoad(t *testing.T) {
+	files := []blobFile{
+		{path: "notes/v3.md", sha: gitBlobSHA([]byte("v3"))},
+		{path: "notes/v2.md", sha: gitBlobSHA([]byte("v2"))},
+		{path: "notes/v1.md", sha: gitBlobSHA([]byte("v1"))},
+		{path: "notes/v0.md", sha: gitBlobSHA([]byte("v0"))},
+	}
+	srv := &blobTestServer{
+		// v0 is missing from the archive, v1 doesn't match it's sha
+		archive: createArchive(t, map[string]string{
+			"repo-main/notes/v3.md": "v3",
+			"repo-main/notes/v2.md": "v2",
+			"repo-main/notes/v1.md": "changed",
+			"repo-main/README.md":   "readme",
+		}),
+		blobs: map[string]string{
+			files[2].sha: "v1",
+			files[3].sha: "v0",
+		},
+	}
+	cache := xcache.NewMemoryCache()
+	loader := srv.loader(cache)
+
+	contents, errs := loader.load(context.Background(), files, files)
+	for i, expected := range []string{"v3", "v2", "v1", "v0"} {
+		if errs[i] != nil {
+			t.Fatalf("unexpected error for %s: %v", files[i].path, errs[i])
+		}
+		if string(contents[i]) != expected {
+			t.Errorf("expected %s but got %s", expected, contents[i])
+		}
+	}
+	if srv.archives.Load() != 1 || srv.fetched.Load() != 2 {
+		t.Errorf("expected 1 archive and 2 blob downloads, got %d and %d", srv.archives.Load(), srv.fetched.Load())
+	}
+
+	// unchanged files are read from the cache
+	_, errs = loader.load(context.Background(), files, files)
+	if errors.Join(errs...) != nil {
+		t.Fatal(errors.Join(errs...))
+	}
+	if srv.archives.Load() != 1 || srv.fetched.Load() != 2 {
+		t.Errorf("expected cached files not to be downloaded again")
+	}
+}
+
+func TestBlobLoaderFetchesFewBlobs(t *testing.T) {
+	files := []blobFile{
+		{path: "v2.md", sha: gitBlobSHA([]byte("v2"))},
+		{path: "v1.md", sha: gitBlobSHA([]byte("v1"))},
+	}
+	srv := &blobTestServer{
+		blobs: map[string]string{files[0].sha: "v2", files[1].sha: "v1"},
+	}
+
+	contents, errs := srv.loader(xcache.NewMemoryCache()).load(context.Background(), files, files)
+	for i, expected := range []string{"v2", "v1"} {
+		if errs[i] != nil {
+			t.Fatal(errs[i])
+		}
+		if string(contents[i]) != expected {
+			t.Errorf("expected %s but got %s", expected, contents[i])
+		}
+	}
+	if srv.archives.Load() != 0 {
+		t.Errorf("expected few files not to download the archive")
+	}
+}
+
+func TestBlobLoaderWithoutCacheFetchesBlobs(t *testing.T) {
+	files := make([]blobFile, 0)
+	blobs := make(map[string]string)
+	for i := range 2 * maxBlobFetches {
+		content := fmt.Sprintf("v%d", i)
+		files = append(files, blobFile{path: content + ".md", sha: gitBlobSHA([]byte(content))})
+		blobs[gitBlobSHA([]byte(content))] = content
+	}
+	srv := &blobTestServer{blobs: blobs}
+
+	_, errs := srv.loader(nil).load(context.Background(), files, files)
+	if errors.Join(errs...) != nil {
+		t.Fatal(errors.Join(errs...))
+	}
+	if srv.archives.Load() != 0 || int(srv.fetched.Load()) != len(files) {
+		t.Errorf("expected every file to be fetched by it's sha, got %d archives and %d blobs", srv.archives.Load(), srv.fetched.Load())
+	}
+}
+
+func TestBlobLoaderReportsFailedFiles(t *testing.T) {
+	files := []blobFile{
+		{path: "v3.md", sha: gitBlobSHA([]byte("v3"))},
+		{path: "v2.md", sha: gitBlobSHA([]byte("v2"))},
+		{path: "v1.md", sha: gitBlobSHA([]byte("v1"))},
+		{path: "v0.md", sha: gitBlobSHA([]byte("v0"))},
+	}
+	srv := &blobTestServer{
+		archive: createArchive(t, map[string]string{"repo/v3.md": "v3", "repo/v2.md": "v2"}),
+	}
+
+	contents, errs := srv.loader(xcache.NewMemoryCache()).load(context.Background(), files, files)
+	for i, expected := range []string{"v3", "v2"} {
+		if errs[i] != nil || string(contents[i]) != expected {
+			t.Errorf("expected %s to be loaded, got %s, %v", expected, contents[i], errs[i])
+		}
+	}
+	if errs[2] == nil || errs[3] == nil {
+		t.Errorf("expected error for files missing in archive and blobs")
+	}
+}
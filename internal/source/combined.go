@@ -0,0 +1,88 @@
+package source
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/jonashiltl/openchangelog/internal/config"
+	"github.com/jonashiltl/openchangelog/internal/store"
+	"github.com/jonashiltl/openchangelog/internal/xcache"
+)
+
+// Returns if the changelog combines the release notes of multiple sources.
+func IsCombined(cl store.Changelog) bool {
+	return len(cl.Sources) > 1
+}
+
+// Returns the ids of all sources of the changelog, in order.
+func NewIDsFromChangelog(cl store.Changelog) []ID {
+	if len(cl.Sources) == 0 {
+		if id := NewIDFromChangelog(cl); id != "" {
+			return []ID{id}
+		}
+		return nil
+	}
+
+	ids := make([]ID, 0, len(cl.Sources))
+	for _, cs := range cl.Sources {
+		if id := NewIDFromChangelog(withSource(cl, cs)); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Creates all sources of the changelog, in order.
+func NewSourcesFromStore(cfg config.Config, cl store.Changelog, cache xcache.Cache) ([]Source, error) {
+	if len(cl.Sources) == 0 {
+		s, err := NewSourceFromStore(cfg, cl, cache)
+		if err != nil {
+			return nil, err
+		}
+		return []Source{s}, nil
+	}
+
+	sources := make([]Source, len(cl.Sources))
+	for i, cs := range cl.Sources {
+		s, err := NewSourceFromStore(cfg, withSource(cl, cs), cache)
+		if err != nil {
+			return nil, err
+		}
+		sources[i] = s
+	}
+	return sources, nil
+}
+
+// Returns cl with cs as it's only source.
+func withSource(cl store.Changelog, cs store.ChangelogSource) store.Changelog {
+	cl.GHSource = cs.GHSource
+	cl.GLSource = cs.GLSource
+	cl.FJSource = cs.FJSource
+	cl.LocalSource = cs.LocalSource
+	cl.GitSource = cs.GitSource
+	cl.URLSource = cs.URLSource
+	cl.S3Source = cs.S3Source
+	cl.Sources = nil
+	return cl
+}
+
+// Returns the id of the release note with id, loaded from the source sid of the changelog.
+// Release note ids are only unique per source, changelogs combining multiple sources
+// prefix them with a short hash of the source id.
+func CombinedNoteID(cl store.Changelog, sid ID, id string) string {
+	if !IsCombined(cl) {
+		return id
+	}
+	return combinedNotePrefix(sid) + id
+}
+
+// Returns if id is the id of a release note of the source sid in a changelog combining multiple sources.
+func IsCombinedNoteOf(sid ID, id string) bool {
+	return strings.HasPrefix(id, combinedNotePrefix(sid))
+}
+
+func combinedNotePrefix(sid ID) string {
+	sum := sha256.Sum256([]byte(sid))
+	return hex.EncodeToString(sum[:4]) + "-"
+}
@@ -0,0 +1,280 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/internal"
+	"github.com/jonashiltl/openchangelog/internal/xcache"
+)
+
+// The commit types which are part of the generated release notes, in the order of their sections.
+var conventionalSections = []struct {
+	typ   string
+	title string
+}{
+	{typ: "feat", title: "Features"},
+	{typ: "fix", title: "Bug Fixes"},
+	{typ: "perf", title: "Performance Improvements"},
+}
+
+// Matches the header of a conventional commit, e.g. feat(api)!: add endpoint
+var conventionalHeader = regexp.MustCompile(`^(\w+)(?:\(([^()]*)\))?(!)?: +(.+)$`)
+
+// Generates one release note per tag of a git repository from the Conventional Commits (https://www.conventionalcommits.org)
+// since the previous tag. Only tags reachable from ref are used, if path is set only commits touching path are included.
+type conventionalSource struct {
+	remote        string
+	ref           string
+	path          string
+	protocols     string
+	mirror        *gitMirror
+	fetchInterval time.Duration
+	cache         xcache.Cache
+}
+
+func NewConventionalCommitsID(remote, ref, path string) ID {
+	return ID(fmt.Sprintf("git-cc/%s/%s/%s", RedactGitRemote(remote), ref, path))
+}
+
+func (s *conventionalSource) ID() ID {
+	return NewConventionalCommitsID(s.remote, s.ref, s.path)
+}
+
+func (s *conventionalSource) Load(ctx context.Context, page internal.Pagination) (LoadResult, error) {
+	if page.IsDefined() && page.PageSize() < 1 {
+		return LoadResult{}, nil
+	}
+
+	commit, err := s.mirror.sync(ctx, s.remote, s.ref, s.protocols, s.fetchInterval)
+	if err != nil {
+		return LoadResult{}, err
+	}
+
+	tags, err := s.mirror.tags(ctx, commit)
+	if err != nil {
+		return LoadResult{}, err
+	}
+
+	start, end := calculatePaginationIndices(page, len(tags))
+	if start >= len(tags) {
+		return LoadResult{}, nil
+	}
+
+	notes := make([]RawReleaseNote, 0, end-start)
+	for i := start; i < end; i++ {
+		// tags are sorted newest first, the commits of the previous tag are not part of the release
+		var prev string
+		if i+1 < len(tags) {
+			prev = tags[i+1].commit
+		}
+		commits, err := s.mirror.log(ctx, tags[i].commit, prev, s.path)
+		if err != nil {
+			return LoadResult{}, err
+		}
+		notes = append(notes, newGeneratedNote(
+			conventionalFrontmatter(tags[i], commits),
+			conventionalBody(commits),
+			s.hasChanged(tags[i], prev),
+		))
+	}
+
+	return LoadResult{
+		Raw:     notes,
+		HasMore: end < len(tags),
+	}, nil
+}
+
+// The commits of a tag only change if the tag or the previous tag are moved.
+func (s *conventionalSource) hasChanged(tag gitTag, prev string) bool {
+	if s.cache == nil {
+		return true
+	}
+	key := fmt.Sprintf("%s/%s", s.ID(), tag.name)
+	value := tag.commit + ".." + prev
+	cached, found := s.cache.Get(key)
+	if found && string(cached) == value {
+		return false
+	}
+	s.cache.Set(key, []byte(value))
+	return true
+}
+
+type conventionalCommit struct {
+	typ         string
+	scope       string
+	description string
+	// the description of the breaking change, empty if the commit is not breaking
+	breaking string
+}
+
+// Parses the commit message, returns false if it's not a conventional commit.
+func parseConventionalCommit(msg string) (conventionalCommit, bool) {
+	header, body, _ := strings.Cut(strings.TrimSpace(msg), "\n")
+	m := conventionalHeader.FindStringSubmatch(strings.TrimSpace(header))
+	if m == nil {
+		return conventionalCommit{}, false
+	}
+
+	c := conventionalCommit{
+		typ:         strings.ToLower(m[1]),
+		scope:       m[2],
+		description: strings.TrimSpace(m[4]),
+	}
+	if m[3] == "!" {
+		c.breaking = c.description
+	}
+	for _, line := range strings.Split(body, "\n") {
+		for _, footer := range []string{"BREAKING CHANGE:", "BREAKING-CHANGE:"} {
+			if desc, ok := strings.CutPrefix(line, footer); ok {
+				c.breaking = strings.TrimSpace(desc)
+			}
+		}
+	}
+	return c, true
+}
+
+// The tag is used as title, the types of the listed commits as tags.
+func conventionalFrontmatter(tag gitTag, commits []conventionalCommit) noteFrontmatter {
+	fm := noteFrontmatter{
+		Title:       tag.name,
+		PublishedAt: tag.date,
+	}
+	for _, section := range conventionalSections {
+		for _, c := range commits {
+			if c.typ == section.typ {
+				fm.Tags = append(fm.Tags, section.typ)
+				break
+			}
+		}
+	}
+	return fm
+}
+
+// Lists the breaking changes, followed by one section per commit type.
+func conventionalBody(commits []conventionalCommit) string {
+	var b strings.Builder
+	writeSection := func(title string, include func(conventionalCommit) bool, description func(conventionalCommit) string) {
+		first := true
+		for _, c := range commits {
+			if !include(c) {
+				continue
+			}
+			if first {
+				if b.Len() > 0 {
+					b.WriteString("\n")
+				}
+				fmt.Fprintf(&b, "### %s\n", title)
+				first = false
+			}
+			if c.scope != "" {
+				fmt.Fprintf(&b, "- **%s:** %s\n", c.scope, description(c))
+			} else {
+				fmt.Fprintf(&b, "- %s\n", description(c))
+			}
+		}
+	}
+
+	writeSection("Breaking Changes", func(c conventionalCommit) bool {
+		return c.breaking != ""
+	}, func(c conventionalCommit) string {
+		return c.breaking
+	})
+	for _, section := range conventionalSections {
+		writeSection(section.title, func(c conventionalCommit) bool {
+			return c.typ == section.typ
+		}, func(c conventionalCommit) string {
+			return c.description
+		})
+	}
+
+	if b.Len() == 0 {
+		return "No notable changes.\n"
+	}
+	return b.String()
+}
+
+type gitTag struct {
+	name   string
+	commit string
+	date   time.Time
+}
+
+// Lists the tags pointing to commits reachable from commit, newest first.
+// The date of annotated tags is the date they were tagged, the commit date for lightweight tags.
+func (m *gitMirror) tags(ctx context.Context, commit string) ([]gitTag, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out, err := runGit(
+		ctx,
+		m.dir,
+		"for-each-ref",
+		"--merged="+commit,
+		// the last key is the primary one, tags of the same date are sorted by version
+		"--sort=-v:refname",
+		"--sort=-creatordate",
+		"--format=%(refname:strip=2)%00%(objectname)%00%(*objectname)%00%(creatordate:unix)",
+		"refs/tags",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []gitTag
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Split(line, "\x00")
+		if len(fields) != 4 {
+			continue
+		}
+		// annotated tags point to a tag object, the commit is the peeled object
+		tagCommit := fields[2]
+		if tagCommit == "" {
+			tagCommit = fields[1]
+		}
+		unix, err := strconv.ParseInt(fields[3], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, gitTag{
+			name:   fields[0],
+			commit: tagCommit,
+			date:   time.Unix(unix, 0),
+		})
+	}
+	return tags, nil
+}
+
+// Returns the conventional commits reachable from commit but not from exclude, newest first.
+// Merge commits and commits that don't follow the Conventional Commits format are skipped.
+// If path is not empty, only commits touching path are returned.
+func (m *gitMirror) log(ctx context.Context, commit, exclude, path string) ([]conventionalCommit, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	args := []string{"log", "--no-merges", "--format=%x1e%B", commit}
+	if exclude != "" {
+		args = append(args, "^"+exclude)
+	}
+	args = append(args, "--")
+	if path != "" {
+		args = append(args, path)
+	}
+
+	out, err := runGit(ctx, m.dir, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []conventionalCommit
+	for _, msg := range strings.Split(string(out), "\x1e") {
+		if c, ok := parseConventionalCommit(msg); ok {
+			commits = append(commits, c)
+		}
+	}
+	return commits, nil
+}
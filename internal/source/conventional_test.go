@@ -0,0 +1,250 @@
+package source
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/jonashiltl/openchangelog/internal"
+	"github.com/jonashiltl/openchangelog/internal/config"
+	"github.com/jonashiltl/openchangelog/internal/store"
+	"github.com/jonashiltl/openchangelog/internal/xcache"
+)
+
+// Commits the file with msg, authored and committed at date.
+func commitAt(t *testing.T, repo, date, file, msg string) {
+	t.Helper()
+	p := filepath.Join(repo, file)
+	err := os.MkdirAll(filepath.Dir(p), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(p, []byte(msg), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	git(t, repo, "add", "-A")
+	gitAt(t, repo, date, "commit", "--quiet", "-m", msg)
+}
+
+// Runs git with the author, committer and tagger date set to date.
+func gitAt(t *testing.T, dir, date string, args ...string) {
+	t.Helper()
+	args = append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %s", args, out)
+	}
+}
+
+func createConventionalRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	repo := t.TempDir()
+	git(t, repo, "init", "--quiet", "--initial-branch=main")
+
+	commitAt(t, repo, "2024-01-01T00:00:00Z", "README.md", "chore: init")
+	commitAt(t, repo, "2024-01-02T00:00:00Z", "api/a", "feat(api): add endpoint")
+	commitAt(t, repo, "2024-01-03T00:00:00Z", "web/a", "fix: crash on start")
+	gitAt(t, repo, "2024-01-10T00:00:00Z", "tag", "-a", "v0.1.0", "-m", "v0.1.0")
+
+	commitAt(t, repo, "2024-01-11T00:00:00Z", "README.md", "docs: update readme")
+	commitAt(t, repo, "2024-01-12T00:00:00Z", "api/b", "perf(api): cache responses")
+	commitAt(t, repo, "2024-01-13T00:00:00Z", "web/b", "not a conventional commit")
+	commitAt(t, repo, "2024-01-14T00:00:00Z", "web/c", "fix(ui): rename button\n\nBREAKING CHANGE: the button prop is called label")
+	commitAt(t, repo, "2024-02-01T00:00:00Z", "api/c", "feat!: drop node 16")
+	git(t, repo, "tag", "v0.2.0")
+
+	commitAt(t, repo, "2024-03-01T00:00:00Z", "README.md", "chore: release")
+	gitAt(t, repo, "2024-03-02T00:00:00Z", "tag", "-a", "v0.3.0", "-m", "v0.3.0")
+
+	// not reachable from main
+	git(t, repo, "checkout", "--quiet", "-b", "next")
+	commitAt(t, repo, "2024-04-01T00:00:00Z", "api/d", "feat: unreleased")
+	git(t, repo, "tag", "v1.0.0-rc")
+	git(t, repo, "checkout", "--quiet", "main")
+	return repo
+}
+
+func createConventionalSource(t *testing.T, repo, path string) *conventionalSource {
+	t.Helper()
+	cfg := config.Config{Git: &config.GitConfig{MirrorPath: t.TempDir()}}
+	s := NewGitSourceFromStore(cfg, store.GitSource{
+		URL:                 "file://" + repo,
+		Ref:                 "main",
+		Path:                path,
+		ConventionalCommits: true,
+	}, xcache.NewMemoryCache()).(*conventionalSource)
+	s.fetchInterval = 0
+	return s
+}
+
+func TestConventionalCommitsLoad(t *testing.T) {
+	repo := createConventionalRepo(t)
+
+	v3 := "---\ntitle: v0.3.0\npublishedAt: 2024-03-02T00:00:00Z\n---\nNo notable changes.\n"
+	v2 := "---\ntitle: v0.2.0\npublishedAt: 2024-02-01T00:00:00Z\ntags:\n    - feat\n    - fix\n    - perf\n---\n" +
+		"### Breaking Changes\n- drop node 16\n- **ui:** the button prop is called label\n\n" +
+		"### Features\n- drop node 16\n\n" +
+		"### Bug Fixes\n- **ui:** rename button\n\n" +
+		"### Performance Improvements\n- **api:** cache responses\n"
+	v1 := "---\ntitle: v0.1.0\npublishedAt: 2024-01-10T00:00:00Z\ntags:\n    - feat\n    - fix\n---\n" +
+		"### Features\n- **api:** add endpoint\n\n" +
+		"### Bug Fixes\n- crash on start\n"
+	v2API := "---\ntitle: v0.2.0\npublishedAt: 2024-02-01T00:00:00Z\ntags:\n    - feat\n    - perf\n---\n" +
+		"### Breaking Changes\n- drop node 16\n\n" +
+		"### Features\n- drop node 16\n\n" +
+		"### Performance Improvements\n- **api:** cache responses\n"
+
+	tables := []struct {
+		name     string
+		path     string
+		page     internal.Pagination
+		expected []string
+		hasMore  bool
+	}{
+		{
+			name:     "all tags",
+			page:     internal.NoPagination(),
+			expected: []string{v3, v2, v1},
+		},
+		{
+			name:     "first page",
+			page:     internal.NewPagination(2, 1),
+			expected: []string{v3, v2},
+			hasMore:  true,
+		},
+		{
+			name:     "last page",
+			page:     internal.NewPagination(2, 2),
+			expected: []string{v1},
+		},
+		{
+			name:     "page out of range",
+			page:     internal.NewPagination(2, 3),
+			expected: []string{},
+		},
+		{
+			name:     "path",
+			path:     "/api/",
+			page:     internal.NewPagination(1, 2),
+			expected: []string{v2API},
+			hasMore:  true,
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			s := createConventionalSource(t, repo, table.path)
+			res, err := s.Load(context.Background(), table.page)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.HasMore != table.hasMore {
+				t.Errorf("expected hasMore %t but got %t", table.hasMore, res.HasMore)
+			}
+			got := readAll(t, res)
+			if len(got) != len(table.expected) {
+				t.Fatalf("expected %d release notes but got %d", len(table.expected), len(got))
+			}
+			for i := range got {
+				if got[i] != table.expected[i] {
+					t.Errorf("expected release note %d to be\n%s\nbut got\n%s", i, table.expected[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestConventionalCommitsHasChanged(t *testing.T) {
+	repo := createConventionalRepo(t)
+	s := createConventionalSource(t, repo, "")
+	ctx := context.Background()
+
+	res, err := s.Load(ctx, internal.NoPagination())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.HasChanged() {
+		t.Error("expected release notes to have changed initially")
+	}
+
+	res, err = s.Load(ctx, internal.NoPagination())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.HasChanged() {
+		t.Error("expected release notes to be unchanged")
+	}
+
+	commitAt(t, repo, "2024-05-01T00:00:00Z", "api/e", "feat: new feature")
+	res, err = s.Load(ctx, internal.NoPagination())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.HasChanged() {
+		t.Error("expected untagged commits to not change the release notes")
+	}
+
+	git(t, repo, "tag", "v0.4.0")
+	res, err = s.Load(ctx, internal.NoPagination())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.HasChanged() {
+		t.Error("expected a new tag to change the release notes")
+	}
+	if len(res.Raw) != 4 {
+		t.Errorf("expected 4 release notes but got %d", len(res.Raw))
+	}
+}
+
+func TestParseConventionalCommit(t *testing.T) {
+	tables := []struct {
+		msg      string
+		ok       bool
+		expected conventionalCommit
+	}{
+		{
+			msg:      "feat: add search",
+			ok:       true,
+			expected: conventionalCommit{typ: "feat", description: "add search"},
+		},
+		{
+			msg:      "Fix(parser): handle empty files\n\nsome details",
+			ok:       true,
+			expected: conventionalCommit{typ: "fix", scope: "parser", description: "handle empty files"},
+		},
+		{
+			msg:      "refactor(api)!: rename routes",
+			ok:       true,
+			expected: conventionalCommit{typ: "refactor", scope: "api", description: "rename routes", breaking: "rename routes"},
+		},
+		{
+			msg:      "perf: faster load\n\nBREAKING-CHANGE: requires go 1.22",
+			ok:       true,
+			expected: conventionalCommit{typ: "perf", description: "faster load", breaking: "requires go 1.22"},
+		},
+		{msg: "Merge branch 'main'"},
+		{msg: "feat:missing space"},
+		{msg: ""},
+	}
+
+	for _, table := range tables {
+		c, ok := parseConventionalCommit(table.msg)
+		if ok != table.ok {
+			t.Errorf("expected %q to be conventional=%t", table.msg, table.ok)
+			continue
+		}
+		if c != table.expected {
+			t.Errorf("expected %q to be parsed as %+v but got %+v", table.msg, table.expected, c)
+		}
+	}
+}
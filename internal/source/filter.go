@@ -0,0 +1,113 @@
+package source
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Loads the markdown files directly inside the directory if no include pattern is set.
+var defaultInclude = []string{"*.md"}
+
+// Selects the files of directory sources by glob patterns matched against the path relative to the directory.
+// Patterns use the syntax of path.Match, additionally "**" matches any number of directories.
+// Only markdown files are loaded, independent of the patterns.
+type fileFilter struct {
+	include []string
+	exclude []string
+}
+
+func newFileFilter(include, exclude []string) fileFilter {
+	if len(include) == 0 {
+		include = defaultInclude
+	}
+	return fileFilter{
+		include: include,
+		exclude: exclude,
+	}
+}
+
+// Returns true if an include pattern can match files in sub directories.
+func (f fileFilter) recursive() bool {
+	for _, p := range f.include {
+		if strings.Contains(p, "/") || strings.Contains(p, "**") {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if the file at the relative path should be loaded.
+func (f fileFilter) match(rel string) bool {
+	if path.Ext(rel) != ".md" {
+		return false
+	}
+	for _, p := range f.exclude {
+		if globMatch(p, rel) {
+			return false
+		}
+	}
+	for _, p := range f.include {
+		if globMatch(p, rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns an error if one of the patterns is malformed.
+// Patterns are stored comma separated, so they can't contain commas.
+func ValidatePatterns(patterns []string) error {
+	for _, p := range patterns {
+		if p == "" {
+			return errors.New("pattern must not be empty")
+		}
+		if strings.Contains(p, ",") {
+			return fmt.Errorf("pattern %q must not contain a comma", p)
+		}
+		for _, segment := range strings.Split(p, "/") {
+			if _, err := path.Match(segment, ""); err != nil {
+				return fmt.Errorf("invalid pattern %q", p)
+			}
+		}
+	}
+	return nil
+}
+
+// Matches name against pattern segment by segment, "**" matches zero or more segments.
+func globMatch(pattern, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		ok, err := path.Match(pattern[0], name[0])
+		if err != nil || !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// Returns the slash separated path of file relative to the directory dir of a repository.
+func relPath(dir, file string) string {
+	dir = strings.Trim(dir, "/")
+	file = strings.TrimPrefix(file, "/")
+	if dir == "" {
+		return file
+	}
+	return strings.TrimPrefix(file, dir+"/")
+}
@@ -0,0 +1,51 @@
+package source
+
+import "testing"
+
+func TestFileFilter(t *testing.T) {
+	tables := []struct {
+		name      string
+		include   []string
+		exclude   []string
+		path      string
+		expected  bool
+		recursive bool
+	}{
+		{name: "default", path: "v1.md", expected: true},
+		{name: "default ignores sub directories", path: "2025/v1.md", expected: false},
+		{name: "only markdown", include: []string{"**"}, path: "notes.txt", expected: false, recursive: true},
+		{name: "double star matches no directory", include: []string{"**/*.md"}, path: "v1.md", expected: true, recursive: true},
+		{name: "double star matches nested directories", include: []string{"**/*.md"}, path: "2025/03/v1.md", expected: true, recursive: true},
+		{name: "directory pattern", include: []string{"2025/*/*.md"}, path: "2025/03/v1.md", expected: true, recursive: true},
+		{name: "directory pattern other year", include: []string{"2025/*/*.md"}, path: "2024/03/v1.md", expected: false, recursive: true},
+		{name: "excluded", include: []string{"**/*.md"}, exclude: []string{"**/draft-*.md"}, path: "2025/draft-v1.md", expected: false, recursive: true},
+		{name: "exclude directory", include: []string{"**/*.md"}, exclude: []string{"archive/**"}, path: "archive/2020/v1.md", expected: false, recursive: true},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			f := newFileFilter(table.include, table.exclude)
+			if got := f.match(table.path); got != table.expected {
+				t.Errorf("expected match %t but got %t", table.expected, got)
+			}
+			if got := f.recursive(); got != table.recursive {
+				t.Errorf("expected recursive %t but got %t", table.recursive, got)
+			}
+		})
+	}
+}
+
+func TestValidatePatterns(t *testing.T) {
+	if err := ValidatePatterns([]string{"**/*.md", "2025/[0-9]*/*.md"}); err != nil {
+		t.Errorf("expected patterns to be valid, got %s", err)
+	}
+	if err := ValidatePatterns([]string{"[*.md"}); err == nil {
+		t.Error("expected malformed pattern to be invalid")
+	}
+	if err := ValidatePatterns([]string{"{a,b}.md"}); err == nil {
+		t.Error("expected pattern with a comma to be invalid")
+	}
+	if err := ValidatePatterns([]string{""}); err == nil {
+		t.Error("expected empty pattern to be invalid")
+	}
+}
@@ -3,13 +3,13 @@ package source
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"fmt"
-	"log"
+	"io"
 	"net/http"
-	"path/filepath"
+	"path"
 	"sort"
 	"strings"
-	"sync"
 
 	"github.com/jonashiltl/openchangelog/internal"
 	"github.com/jonashiltl/openchangelog/internal/config"
@@ -20,12 +20,18 @@ import (
 	forgejo "codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v3"
 )
 
+// The default MAX_RESPONSE_ITEMS of Forgejo, the highest page size of the api.
+const forgejoMaxPerPage = 50
+
 type fjSource struct {
-	client  *forgejo.Client
-	baseUrl string
-	project string
-	path    string
-	ref     string
+	client   *forgejo.Client
+	baseUrl  string
+	project  string
+	path     string
+	ref      string
+	releases bool
+	filter   fileFilter
+	cache    xcache.Cache
 }
 
 func NewFJSourceFromStore(cfg config.Config, fj store.FJSource, cache xcache.Cache) (Source, error) {
@@ -46,7 +52,7 @@ func NewFJSourceFromStore(cfg config.Config, fj store.FJSource, cache xcache.Cac
 
 	url := fj.BaseURL
 
-	if url != "" {
+	if url == "" && cfg.Forgejo != nil {
 		url = cfg.Forgejo.BaseURL
 	}
 
@@ -58,6 +64,7 @@ func NewFJSourceFromStore(cfg config.Config, fj store.FJSource, cache xcache.Cac
 	client, err := forgejo.NewClient(
 		url,
 		forgejo.SetToken(token),
+		forgejo.SetHTTPClient(&http.Client{Transport: tr}),
 	)
 
 	if err != nil {
@@ -65,11 +72,14 @@ func NewFJSourceFromStore(cfg config.Config, fj store.FJSource, cache xcache.Cac
 	}
 
 	return &fjSource{
-		client:  client,
-		baseUrl: fj.BaseURL,
-		project: fj.Project,
-		path:    fj.Path,
-		ref:     fj.Ref,
+		client:   client,
+		baseUrl:  fj.BaseURL,
+		project:  fj.Project,
+		path:     fj.Path,
+		ref:      fj.Ref,
+		releases: fj.Releases,
+		filter:   newFileFilter(fj.Include, fj.Exclude),
+		cache:    cache,
 	}, nil
 
 }
@@ -78,7 +88,14 @@ func NewForgejoID(project, path string) ID {
 	return ID(fmt.Sprintf("fj/%s/%s", project, path))
 }
 
+func NewForgejoReleasesID(project string) ID {
+	return ID(fmt.Sprintf("fj-releases/%s", project))
+}
+
 func (f *fjSource) ID() ID {
+	if f.releases {
+		return NewForgejoReleasesID(f.project)
+	}
 	return NewForgejoID(f.project, f.path)
 }
 
@@ -91,6 +108,11 @@ func (f *fjSource) Load(ctx context.Context, page internal.Pagination) (LoadResu
 	if !found || owner == "" || repo == "" {
 		return LoadResult{}, fmt.Errorf("invalid project format: %s", f.project)
 	}
+	if f.releases {
+		return loadReleases(ctx, page, forgejoMaxPerPage, func(ctx context.Context, page, perPage int) ([]release, bool, error) {
+			return f.listReleases(owner, repo, page, perPage)
+		})
+	}
 
 	file, resp, err := f.client.GetFile(
 		owner,
@@ -104,7 +126,7 @@ func (f *fjSource) Load(ctx context.Context, page internal.Pagination) (LoadResu
 		return f.singleFileResult(resp, file)
 	}
 
-	return f.loadDir(ctx, page)
+	return f.loadDir(ctx, owner, repo, page)
 }
 
 func (f *fjSource) singleFileResult(resp *forgejo.Response, file []byte) (LoadResult, error) {
@@ -113,95 +135,147 @@ func (f *fjSource) singleFileResult(resp *forgejo.Response, file []byte) (LoadRe
 			{
 				hasChanged: !fromCache(resp.Header),
 				Content:    bytes.NewReader(file),
+				Path:       path.Base(f.path),
 			},
 		},
 	}, nil
 }
 
-func (f *fjSource) loadDir(ctx context.Context, page internal.Pagination) (LoadResult, error) {
-
-	owner := strings.Split(f.project, "/")
-
-	treeResp, _, err := f.client.GetTrees(owner[0], owner[1], f.ref, forgejo.GetTreesOptions{})
+func (f *fjSource) loadDir(ctx context.Context, owner, repo string, page internal.Pagination) (LoadResult, error) {
+	files, hasChanged, err := f.listFiles(owner, repo)
 	if err != nil {
-		return LoadResult{}, fmt.Errorf("failed to get trees: %w", err)
+		return LoadResult{}, err
 	}
 
-	nodes := treeResp.Entries
-
-	var mdFiles []forgejo.GitEntry
-	for _, node := range nodes {
-		if node.Type == "blob" && forgejoFileIsMD(node.URL) {
-			mdFiles = append(mdFiles, node)
-		}
-	}
-
-	sort.Slice(mdFiles, func(i, j int) bool {
-		return mdFiles[i].Path >= mdFiles[j].Path
+	sort.Slice(files, func(i, j int) bool {
+		return relPath(f.path, files[i].path) >= relPath(f.path, files[j].path)
 	})
 
-	totalFiles := len(mdFiles)
+	totalFiles := len(files)
 	start, end := calculatePaginationIndices(page, totalFiles)
 	if start >= totalFiles {
 		return LoadResult{}, nil
 	}
 
-	notes := make([]RawReleaseNote, end-start)
-
-	var wg sync.WaitGroup
-	for i, file := range mdFiles[start:end] {
-		wg.Add(1)
-		go func(index int, path string) {
-			defer wg.Done()
-
-			note, err := f.fetchFile(ctx, path)
-			if err != nil {
-				log.Printf("Warning: failed to fetch file %s: %v", path, err)
-				return
-			}
-
-			notes[index] = note
-		}(i, file.Path)
+	blobs := blobLoader{
+		cache:  f.cache,
+		prefix: fmt.Sprintf("fj/%s/%s", f.baseUrl, f.project),
+		fetchBlob: func(_ context.Context, sha string) ([]byte, error) {
+			return f.fetchBlob(owner, repo, sha)
+		},
+		fetchArchive: func(_ context.Context) (io.ReadCloser, error) {
+			return f.fetchArchive(owner, repo)
+		},
 	}
+	contents, loadErrs := blobs.load(ctx, files[start:end], files)
 
-	wg.Wait()
+	notes := make([]RawReleaseNote, end-start)
+	paths := make([]string, end-start)
+	for i, file := range files[start:end] {
+		paths[i] = relPath(f.path, file.path)
+		notes[i] = RawReleaseNote{
+			hasChanged: hasChanged,
+			Content:    bytes.NewReader(contents[i]),
+			Path:       paths[i],
+		}
+	}
 
+	notes, failed := removeFailed(notes, paths, loadErrs)
 	return LoadResult{
 		Raw:     notes,
 		HasMore: end < totalFiles,
+		Errors:  failed,
 	}, nil
 }
 
-func (f *fjSource) fetchFile(ctx context.Context, path string) (RawReleaseNote, error) {
+// Lists the files below the path selected by the filter, returns true if any page of the tree changed.
+// The tree of the ref is always read recursively, the filter decides if files of sub directories are loaded.
+func (f *fjSource) listFiles(owner, repo string) ([]blobFile, bool, error) {
+	dir := strings.Trim(f.path, "/")
+	files := make([]blobFile, 0)
+	hasChanged := false
+	for page, seen := 1, 0; ; page++ {
+		treeResp, resp, err := f.client.GetTrees(owner, repo, f.ref, forgejo.GetTreesOptions{
+			Recursive: true,
+			ListOptions: forgejo.ListOptions{
+				Page:     page,
+				PageSize: forgejoMaxPerPage,
+			},
+		})
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get trees: %w", err)
+		}
+		hasChanged = hasChanged || !fromCache(resp.Header)
 
-	owner, repo, found := strings.Cut(f.project, "/")
-	if !found || owner == "" || repo == "" {
-		return RawReleaseNote{}, fmt.Errorf("invalid project format: %s", f.project)
+		for _, node := range treeResp.Entries {
+			if node.Type != "blob" || (dir != "" && !strings.HasPrefix(node.Path, dir+"/")) {
+				continue
+			}
+			if f.filter.match(relPath(dir, node.Path)) {
+				files = append(files, blobFile{path: node.Path, sha: node.SHA})
+			}
+		}
+
+		seen += len(treeResp.Entries)
+		if len(treeResp.Entries) == 0 || seen >= treeResp.TotalCount {
+			return files, hasChanged, nil
+		}
 	}
-	content, resp, err := f.client.GetFile(
-		owner,
-		repo,
-		f.ref,
-		f.path,
-		false,
-	)
+}
 
+func (f *fjSource) fetchBlob(owner, repo, sha string) ([]byte, error) {
+	blob, _, err := f.client.GetBlob(owner, repo, sha)
 	if err != nil {
-		return RawReleaseNote{}, err
+		return nil, err
 	}
-	if resp.StatusCode >= 400 {
-		return RawReleaseNote{}, fmt.Errorf("gitlab returned status %d for file %s", resp.StatusCode, path)
+	if blob.Encoding != "base64" {
+		return []byte(blob.Content), nil
 	}
-	return RawReleaseNote{
-		hasChanged: !fromCache(resp.Header),
-		Content:    bytes.NewReader(content),
-	}, nil
+	return base64.StdEncoding.DecodeString(blob.Content)
 }
 
-type ForgejoFile struct {
-	URL string
+// Downloads the archive of the ref, the default branch is looked up if no ref is configured.
+func (f *fjSource) fetchArchive(owner, repo string) (io.ReadCloser, error) {
+	ref := f.ref
+	if ref == "" {
+		r, _, err := f.client.GetRepo(owner, repo)
+		if err != nil {
+			return nil, err
+		}
+		ref = r.DefaultBranch
+	}
+	archive, _, err := f.client.GetArchiveReader(owner, repo, ref, forgejo.TarGZArchive)
+	return archive, err
 }
 
-func forgejoFileIsMD(path string) bool {
-	return filepath.Ext(path) == ".md"
+// Draft releases are filtered by the API, loadReleases skips any that are still listed.
+func (f *fjSource) listReleases(owner, repo string, page, perPage int) ([]release, bool, error) {
+	res, resp, err := f.client.ListReleases(owner, repo, forgejo.ListReleasesOptions{
+		ListOptions: forgejo.ListOptions{
+			Page:     page,
+			PageSize: perPage,
+		},
+		IsDraft: forgejo.OptionalBool(false),
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	releases := make([]release, 0, len(res))
+	for _, r := range res {
+		releases = append(releases, release{
+			tag:         r.TagName,
+			name:        r.Title,
+			body:        r.Note,
+			publishedAt: r.PublishedAt,
+			prerelease:  r.IsPrerelease,
+			draft:       r.IsDraft,
+			fromCache:   fromCache(resp.Header),
+		})
+	}
+	return releases, resp.NextPage != 0, nil
+}
+
+type ForgejoFile struct {
+	URL string
 }
@@ -0,0 +1,392 @@
+package source
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/internal"
+	"github.com/jonashiltl/openchangelog/internal/config"
+	"github.com/jonashiltl/openchangelog/internal/store"
+	"github.com/jonashiltl/openchangelog/internal/xcache"
+)
+
+const (
+	// The mirror of a remote is fetched at most once per interval.
+	defaultFetchInterval = time.Minute
+	// Transports git may use, excludes transports like ext:: that run arbitrary commands.
+	gitAllowedProtocols = "file:http:https:ssh"
+	// Transports of the remotes of db mode, ssh would authenticate with the keys of the server.
+	gitTenantProtocols = "file:http:https"
+)
+
+// Reads release notes from any git repository.
+// The remote is cloned into a local bare mirror, which is fetched again when loading after the fetch interval passed.
+type gitSource struct {
+	remote string
+	ref    string
+	path   string
+	filter fileFilter
+	// transports the remote may be fetched with
+	protocols     string
+	mirror        *gitMirror
+	fetchInterval time.Duration
+	cache         xcache.Cache
+}
+
+func NewGitSourceFromStore(cfg config.Config, git store.GitSource, cache xcache.Cache) Source {
+	root := filepath.Join(os.TempDir(), "openchangelog-git")
+	if cfg.Git != nil && cfg.Git.MirrorPath != "" {
+		root = cfg.Git.MirrorPath
+	}
+	protocols := gitAllowedProtocols
+	if cfg.IsDBMode() {
+		protocols = gitTenantProtocols
+	}
+
+	if git.ConventionalCommits {
+		return &conventionalSource{
+			remote:        git.URL,
+			ref:           git.Ref,
+			path:          strings.Trim(git.Path, "/"),
+			protocols:     protocols,
+			mirror:        getMirror(root, git.URL),
+			fetchInterval: defaultFetchInterval,
+			cache:         cache,
+		}
+	}
+
+	return &gitSource{
+		remote:        git.URL,
+		ref:           git.Ref,
+		path:          strings.Trim(git.Path, "/"),
+		filter:        newFileFilter(git.Include, git.Exclude),
+		protocols:     protocols,
+		mirror:        getMirror(root, git.URL),
+		fetchInterval: defaultFetchInterval,
+		cache:         cache,
+	}
+}
+
+// Credentials of the remote are not part of the id.
+func NewGitID(remote, ref, path string) ID {
+	return ID(fmt.Sprintf("git/%s/%s/%s", RedactGitRemote(remote), ref, path))
+}
+
+func (s *gitSource) ID() ID {
+	return NewGitID(s.remote, s.ref, s.path)
+}
+
+func (s *gitSource) Load(ctx context.Context, page internal.Pagination) (LoadResult, error) {
+	if page.IsDefined() && page.PageSize() < 1 {
+		return LoadResult{}, nil
+	}
+
+	commit, err := s.mirror.sync(ctx, s.remote, s.ref, s.protocols, s.fetchInterval)
+	if err != nil {
+		return LoadResult{}, err
+	}
+
+	entries, isDir, err := s.mirror.lsTree(ctx, commit, s.path, s.filter.recursive())
+	if err != nil {
+		return LoadResult{}, err
+	}
+
+	if isDir {
+		entries = filter(entries, func(e gitEntry) bool {
+			return e.typ == "blob" && s.filter.match(e.name)
+		})
+		// sort files in descending order by filename
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].name >= entries[j].name
+		})
+	}
+
+	start, end := calculatePaginationIndices(page, len(entries))
+	if start >= len(entries) {
+		return LoadResult{}, nil
+	}
+
+	contents, err := s.mirror.readBlobs(ctx, entries[start:end])
+	if err != nil {
+		return LoadResult{}, err
+	}
+
+	result := make([]RawReleaseNote, 0, end-start)
+	for i, e := range entries[start:end] {
+		result = append(result, RawReleaseNote{
+			Content:    bytes.NewReader(contents[i]),
+			Path:       e.name,
+			hasChanged: s.hasChanged(e),
+		})
+	}
+
+	return LoadResult{
+		Raw:     result,
+		HasMore: end < len(entries),
+	}, nil
+}
+
+// Compares the blob hash of the entry with the cached one.
+// Updates the cached hash if the file has changed.
+func (s *gitSource) hasChanged(e gitEntry) bool {
+	if s.cache == nil {
+		return true
+	}
+	key := fmt.Sprintf("%s/%s", s.ID(), e.name)
+	cached, found := s.cache.Get(key)
+	if found && string(cached) == e.hash {
+		return false
+	}
+	s.cache.Set(key, []byte(e.hash))
+	return true
+}
+
+// Removes the userinfo of remote urls, so credentials don't show up in ids, logs and api responses.
+func RedactGitRemote(remote string) string {
+	u, err := url.Parse(remote)
+	if err != nil || u.User == nil {
+		return remote
+	}
+	u.User = nil
+	return u.String()
+}
+
+// Returns an error if remote can't be used as git remote.
+// Only https, http, ssh and file urls, as well as scp-like ssh remotes (user@host:path) are supported.
+func ValidateGitRemote(remote string) error {
+	if strings.HasPrefix(remote, "-") || strings.Contains(remote, "::") {
+		return errors.New("invalid git remote")
+	}
+	if strings.Contains(remote, "://") {
+		u, err := url.Parse(remote)
+		if err != nil {
+			return err
+		}
+		switch u.Scheme {
+		case "https", "http", "ssh":
+			if u.Host == "" {
+				return errors.New("git remote is missing the host")
+			}
+			return nil
+		case "file":
+			return nil
+		}
+		return fmt.Errorf("unsupported git remote scheme %s", u.Scheme)
+	}
+	// scp-like syntax, e.g. git@example.com:org/repo.git
+	host, _, ok := strings.Cut(remote, ":")
+	if ok && host != "" && !strings.Contains(host, "/") {
+		return nil
+	}
+	return errors.New("git remote must be an https, ssh or file url")
+}
+
+// A bare mirror of a remote on the local disk, shared by all sources of the remote.
+type gitMirror struct {
+	dir string
+	// serializes git commands on the mirror
+	mu        sync.Mutex
+	lastFetch time.Time
+}
+
+var (
+	mirrorsMu sync.Mutex
+	mirrors   = make(map[string]*gitMirror)
+)
+
+func getMirror(root, remote string) *gitMirror {
+	sum := sha256.Sum256([]byte(remote))
+	dir := filepath.Join(root, hex.EncodeToString(sum[:16]))
+
+	mirrorsMu.Lock()
+	defer mirrorsMu.Unlock()
+	m, ok := mirrors[dir]
+	if !ok {
+		m = &gitMirror{dir: dir}
+		mirrors[dir] = m
+	}
+	return m
+}
+
+// Clones the remote if the mirror doesn't exist yet, fetches it if the last fetch is older than interval.
+// The remote is only accessed with the transports listed in protocols.
+// Returns the commit ref points to, the default branch of the remote if ref is empty.
+func (m *gitMirror) sync(ctx context.Context, remote, ref, protocols string, interval time.Duration) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, err := os.Stat(m.dir); errors.Is(err, os.ErrNotExist) {
+		err = os.MkdirAll(filepath.Dir(m.dir), 0755)
+		if err != nil {
+			return "", err
+		}
+		_, err = runGitWithProtocols(ctx, "", protocols, "clone", "--mirror", "--quiet", "--", remote, m.dir)
+		if err != nil {
+			os.RemoveAll(m.dir)
+			return "", err
+		}
+		m.lastFetch = time.Now()
+	} else if time.Since(m.lastFetch) >= interval {
+		_, err := runGitWithProtocols(ctx, m.dir, protocols, "fetch", "--prune", "--quiet", "origin")
+		if err != nil {
+			return "", err
+		}
+		m.lastFetch = time.Now()
+	}
+
+	if ref == "" {
+		ref = "HEAD"
+	}
+	out, err := runGit(ctx, m.dir, "rev-parse", "--verify", "--quiet", "--end-of-options", ref+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("git ref %s not found", ref)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+type gitEntry struct {
+	typ  string
+	hash string
+	name string
+}
+
+// Lists the entry at p of the commit.
+// If p is a directory, it's entries are returned and isDir is true, with the entries of all
+// sub directories if recursive is set. The names of the entries are relative to p.
+func (m *gitMirror) lsTree(ctx context.Context, commit, p string, recursive bool) (entries []gitEntry, isDir bool, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	obj := commit + ":" + p
+	typ, err := runGit(ctx, m.dir, "cat-file", "-t", obj)
+	if err != nil {
+		return nil, false, fmt.Errorf("path %s not found in git repository", p)
+	}
+	if strings.TrimSpace(string(typ)) == "blob" {
+		hash, err := runGit(ctx, m.dir, "rev-parse", obj)
+		if err != nil {
+			return nil, false, err
+		}
+		return []gitEntry{{typ: "blob", hash: strings.TrimSpace(string(hash)), name: path.Base(p)}}, false, nil
+	}
+
+	args := []string{"ls-tree", "-z"}
+	if recursive {
+		args = append(args, "-r")
+	}
+	out, err := runGit(ctx, m.dir, append(args, obj)...)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, line := range strings.Split(string(out), "\x00") {
+		// <mode> SP <type> SP <hash> TAB <name>
+		meta, name, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(meta)
+		if len(fields) != 3 {
+			continue
+		}
+		entries = append(entries, gitEntry{typ: fields[1], hash: fields[2], name: name})
+	}
+	return entries, true, nil
+}
+
+// Reads the content of the blobs with a single git process.
+func (m *gitMirror) readBlobs(ctx context.Context, entries []gitEntry) ([][]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var in bytes.Buffer
+	for _, e := range entries {
+		in.WriteString(e.hash + "\n")
+	}
+
+	cmd := gitCommand(ctx, m.dir, "cat-file", "--batch")
+	cmd.Stdin = &in
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, gitError(err)
+	}
+
+	// every object is printed as <hash> SP <type> SP <size> LF <content> LF
+	r := bufio.NewReader(bytes.NewReader(out))
+	contents := make([][]byte, len(entries))
+	for i := range entries {
+		header, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		fields := strings.Fields(header)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("failed to read git object %s", entries[i].hash)
+		}
+		size, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, err
+		}
+		contents[i] = make([]byte, size)
+		_, err = io.ReadFull(r, contents[i])
+		if err != nil {
+			return nil, err
+		}
+		_, err = r.Discard(1)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return contents, nil
+}
+
+func gitCommand(ctx context.Context, dir string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(
+		os.Environ(),
+		"GIT_TERMINAL_PROMPT=0",
+		"GIT_ALLOW_PROTOCOL="+gitAllowedProtocols,
+	)
+	return cmd
+}
+
+func runGit(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	return runGitWithProtocols(ctx, dir, gitAllowedProtocols, args...)
+}
+
+// Runs git with the transports limited to protocols, a colon separated list.
+func runGitWithProtocols(ctx context.Context, dir, protocols string, args ...string) ([]byte, error) {
+	cmd := gitCommand(ctx, dir, args...)
+	// the last value of a variable wins
+	cmd.Env = append(cmd.Env, "GIT_ALLOW_PROTOCOL="+protocols)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, gitError(err)
+	}
+	return out, nil
+}
+
+// Adds the stderr output of git to the error.
+func gitError(err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		return fmt.Errorf("git: %s", strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return err
+}
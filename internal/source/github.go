@@ -1,14 +1,16 @@
 package source
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
-	"io/fs"
+	"io"
 	"net/http"
-	"path/filepath"
+	"net/url"
+	"slices"
 	"sort"
 	"strings"
-	"sync"
 
 	"github.com/bradleyfalzon/ghinstallation/v2"
 	"github.com/google/go-github/v62/github"
@@ -19,26 +21,48 @@ import (
 	"github.com/naveensrinivasan/httpcache"
 )
 
+// The highest page size of the GitHub REST api.
+const githubMaxPerPage = 100
+
 type ghSource struct {
 	client         *github.Client
+	archiveClient  *http.Client
 	Owner          string
 	Repo           string
 	Path           string
+	Ref            string
+	BaseURL        string
 	InstallationID int64
+	Releases       bool
+	filter         fileFilter
+	blobs          blobLoader
 }
 
+// In db mode the repositories are defined by the workspaces, so requests can't reach private addresses of the server's network
+// and the credentials of the server are only sent to github.com and the allowlisted Enterprise Servers.
 func NewGHSourceFromStore(cfg config.Config, gh store.GHSource, cache xcache.Cache) (Source, error) {
-	tr := http.DefaultTransport
+	err := ValidateGitHubBaseURL(cfg, gh.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var tr http.RoundTripper = http.DefaultTransport
+	if cfg.IsDBMode() {
+		tr = NewPublicTransport()
+	}
 
+	var itr *ghinstallation.Transport
 	if cfg.HasGithubAuth() && cfg.Github.Auth.AppPrivateKey != "" && gh.InstallationID != 0 {
-		// Wrap the shared transport for use with the app ID 1 authenticating with installation ID 99.
-		itr, err := ghinstallation.NewKeyFromFile(tr, cfg.Github.Auth.AppID, gh.InstallationID, cfg.Github.Auth.AppPrivateKey)
+		// authenticate as the installation of the app, tokens are refreshed by the transport
+		itr, err = ghinstallation.NewKeyFromFile(tr, cfg.Github.Auth.AppID, gh.InstallationID, cfg.Github.Auth.AppPrivateKey)
 		if err != nil {
 			return nil, err
 		}
 		tr = itr
 	}
 
+	// archives are too large to be cached, they are downloaded without the cache
+	archiveClient := &http.Client{Transport: tr}
 	if cache != nil {
 		cachedTransport := httpcache.NewTransport(cache)
 		cachedTransport.Transport = tr
@@ -46,25 +70,100 @@ func NewGHSourceFromStore(cfg config.Config, gh store.GHSource, cache xcache.Cac
 	}
 
 	client := github.NewClient(&http.Client{Transport: tr})
+	if gh.BaseURL != "" {
+		client, err = client.WithEnterpriseURLs(gh.BaseURL, gh.BaseURL)
+		if err != nil {
+			return nil, err
+		}
+		if itr != nil {
+			// installation tokens need to be created by the enterprise server
+			itr.BaseURL = strings.TrimSuffix(client.BaseURL.String(), "/")
+		}
+	}
 	if cfg.HasGithubAuth() && cfg.Github.Auth.AccessToken != "" {
 		client = client.WithAuthToken(cfg.Github.Auth.AccessToken)
 	}
 
-	return &ghSource{
+	s := &ghSource{
 		client:         client,
+		archiveClient:  archiveClient,
 		Owner:          gh.Owner,
 		Repo:           gh.Repo,
 		Path:           gh.Path,
+		Ref:            gh.Ref,
+		BaseURL:        gh.BaseURL,
 		InstallationID: gh.InstallationID,
-	}, nil
+		Releases:       gh.Releases,
+		filter:         newFileFilter(gh.Include, gh.Exclude),
+	}
+	s.blobs = blobLoader{
+		cache:        cache,
+		prefix:       fmt.Sprintf("gh/%s/%s/%s", gh.BaseURL, gh.Owner, gh.Repo),
+		fetchBlob:    s.fetchBlob,
+		fetchArchive: s.fetchArchive,
+	}
+	return s, nil
+}
+
+// Returned in db mode if a GitHub source uses an Enterprise Server which isn't listed in github.enterpriseHosts.
+var ErrEnterpriseHostNotAllowed = errors.New("github enterprise server is not allowed, it's host must be listed in github.enterpriseHosts")
+
+// Returns an error if baseURL can't be used as api url of a GitHub Enterprise Server.
+// The credentials of the server are sent to it, so in db mode only the hosts allowlisted in the config are accepted.
+func ValidateGitHubBaseURL(cfg config.Config, baseURL string) error {
+	if baseURL == "" {
+		return nil
+	}
+	err := ValidateURL(baseURL)
+	if err != nil {
+		return err
+	}
+	if !cfg.IsDBMode() {
+		return nil
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return err
+	}
+	if cfg.Github != nil && slices.ContainsFunc(cfg.Github.EnterpriseHosts, func(host string) bool {
+		return strings.EqualFold(host, u.Host)
+	}) {
+		return nil
+	}
+	return ErrEnterpriseHostNotAllowed
+}
+
+// The ref is only part of the id if set, sources loading the default branch keep their previous id.
+// Repositories of a GitHub Enterprise Server are prefixed with it's host, so they don't share the id of a github.com repository.
+func NewGitHubID(baseURL, owner, repo, ref, path string) ID {
+	repo = githubRepoKey(baseURL, owner, repo)
+	if ref == "" {
+		return ID(fmt.Sprintf("gh/%s/%s", repo, path))
+	}
+	return ID(fmt.Sprintf("gh/%s/%s/%s", repo, ref, path))
 }
 
-func NewGitHubID(owner, repo, path string) ID {
-	return ID(fmt.Sprintf("gh/%s/%s/%s", owner, repo, path))
+func NewGitHubReleasesID(baseURL, owner, repo string) ID {
+	return ID(fmt.Sprintf("gh-releases/%s", githubRepoKey(baseURL, owner, repo)))
+}
+
+// Returns owner/repo, prefixed with the host of baseURL if set.
+func githubRepoKey(baseURL, owner, repo string) string {
+	if baseURL == "" {
+		return fmt.Sprintf("%s/%s", owner, repo)
+	}
+	host := baseURL
+	if u, err := url.Parse(baseURL); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	return fmt.Sprintf("%s/%s/%s", host, owner, repo)
 }
 
 func (s *ghSource) ID() ID {
-	return NewGitHubID(s.Owner, s.Repo, s.Path)
+	if s.Releases {
+		return NewGitHubReleasesID(s.BaseURL, s.Owner, s.Repo)
+	}
+	return NewGitHubID(s.BaseURL, s.Owner, s.Repo, s.Ref, s.Path)
 }
 
 func (s *ghSource) Load(ctx context.Context, page internal.Pagination) (LoadResult, error) {
@@ -72,8 +171,11 @@ func (s *ghSource) Load(ctx context.Context, page internal.Pagination) (LoadResu
 	if page.IsDefined() && page.PageSize() < 1 {
 		return LoadResult{}, nil
 	}
+	if s.Releases {
+		return loadReleases(ctx, page, githubMaxPerPage, s.listReleases)
+	}
 
-	file, dir, resp, err := s.client.Repositories.GetContents(ctx, s.Owner, s.Repo, s.Path, nil)
+	file, dir, resp, err := s.client.Repositories.GetContents(ctx, s.Owner, s.Repo, s.Path, &github.RepositoryContentGetOptions{Ref: s.Ref})
 	if err != nil {
 		return LoadResult{}, err
 	}
@@ -87,63 +189,135 @@ func (s *ghSource) Load(ctx context.Context, page internal.Pagination) (LoadResu
 				{
 					hasChanged: !fromCache(resp.Header),
 					Content:    strings.NewReader(c),
+					Path:       file.GetName(),
 				},
 			},
 		}, nil
 	}
-	return s.loadDir(ctx, dir, page)
+	return s.loadDir(ctx, dir, resp, page)
 }
 
-func (s *ghSource) loadDir(ctx context.Context, files []*github.RepositoryContent, page internal.Pagination) (LoadResult, error) {
-	files = filter(files, githubFileIsMD)
+func (s *ghSource) loadDir(ctx context.Context, dir []*github.RepositoryContent, resp *github.Response, page internal.Pagination) (LoadResult, error) {
+	files, err := s.listFiles(ctx, dir)
+	if err != nil {
+		return LoadResult{}, err
+	}
+
 	totalFiles := len(files)
 	start, end := calculatePaginationIndices(page, totalFiles)
 	if start >= totalFiles {
 		return LoadResult{}, nil
 	}
 
-	// sort files in descending order by filename
+	// sort files in descending order by their path
 	sort.Slice(files, func(i, j int) bool {
-		return files[i].GetName() >= files[j].GetName()
+		return relPath(s.Path, files[i].path) >= relPath(s.Path, files[j].path)
 	})
 
-	var wg sync.WaitGroup
-	var mutex sync.Mutex
-	notes := make([]RawReleaseNote, 0, page.PageSize())
-
-	for _, file := range files[start:end] {
-		wg.Add(1)
-		go func(name string) {
-			defer wg.Done()
-			note, err := s.loadFile(ctx, name)
-			if err != nil {
-				return
-			}
-			mutex.Lock()
-			notes = append(notes, note)
-			mutex.Unlock()
-		}(file.GetName())
-	}
-	wg.Wait()
+	// the sha of a sub directory changes with it's content, so the listing changes with every file
+	hasChanged := !fromCache(resp.Header)
+	contents, loadErrs := s.blobs.load(ctx, files[start:end], files)
+
+	notes := make([]RawReleaseNote, end-start)
+	paths := make([]string, end-start)
+	for i, file := range files[start:end] {
+		paths[i] = relPath(s.Path, file.path)
+		notes[i] = RawReleaseNote{
+			hasChanged: hasChanged,
+			Content:    bytes.NewReader(contents[i]),
+			Path:       paths[i],
+		}
+	}
 
+	notes, failed := removeFailed(notes, paths, loadErrs)
 	return LoadResult{
 		Raw:     notes,
 		HasMore: end < len(files),
+		Errors:  failed,
 	}, nil
 }
 
-func (s *ghSource) loadFile(ctx context.Context, filename string) (RawReleaseNote, error) {
-	read, resp, err := s.client.Repositories.DownloadContents(ctx, s.Owner, s.Repo, fmt.Sprintf("%s/%s", s.Path, filename), nil)
+// Returns the files of the directory listing selected by the filter.
+// If the filter is recursive, the files of all sub directories are listed with a single recursive
+// request of the tree of the ref, filtered by the path of the source.
+func (s *ghSource) listFiles(ctx context.Context, dir []*github.RepositoryContent) ([]blobFile, error) {
+	if s.filter.recursive() {
+		return s.listTree(ctx)
+	}
+
+	files := make([]blobFile, 0, len(dir))
+	for _, c := range dir {
+		if c.GetType() == "file" && s.filter.match(relPath(s.Path, c.GetPath())) {
+			files = append(files, blobFile{path: c.GetPath(), sha: c.GetSHA()})
+		}
+	}
+	return files, nil
+}
+
+func (s *ghSource) listTree(ctx context.Context) ([]blobFile, error) {
+	ref := s.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+	tree, _, err := s.client.Git.GetTree(ctx, s.Owner, s.Repo, ref, true)
 	if err != nil {
-		return RawReleaseNote{}, err
+		return nil, err
 	}
-	if resp.StatusCode >= 400 {
-		return RawReleaseNote{}, fmt.Errorf("failed to download file from github, status %d", resp.StatusCode)
+	if tree.GetTruncated() {
+		return nil, fmt.Errorf("tree of %s/%s is too large to be listed recursively", s.Owner, s.Repo)
 	}
-	return RawReleaseNote{
-		hasChanged: !fromCache(resp.Header),
-		Content:    read,
-	}, nil
+
+	prefix := strings.Trim(s.Path, "/") + "/"
+	files := make([]blobFile, 0)
+	for _, e := range tree.Entries {
+		path := e.GetPath()
+		if e.GetType() != "blob" || (prefix != "/" && !strings.HasPrefix(path, prefix)) {
+			continue
+		}
+		if s.filter.match(relPath(s.Path, path)) {
+			files = append(files, blobFile{path: path, sha: e.GetSHA()})
+		}
+	}
+	return files, nil
+}
+
+func (s *ghSource) fetchBlob(ctx context.Context, sha string) ([]byte, error) {
+	b, _, err := s.client.Git.GetBlobRaw(ctx, s.Owner, s.Repo, sha)
+	return b, err
+}
+
+// Downloads the tarball of the ref, defaults to the default branch.
+func (s *ghSource) fetchArchive(ctx context.Context) (io.ReadCloser, error) {
+	u, _, err := s.client.Repositories.GetArchiveLink(ctx, s.Owner, s.Repo, github.Tarball, &github.RepositoryContentGetOptions{Ref: s.Ref}, 1)
+	if err != nil {
+		return nil, err
+	}
+	return downloadArchive(ctx, s.archiveClient, u.String())
+}
+
+// Draft releases are only listed if the client has push access to the repository, they are skipped by loadReleases.
+func (s *ghSource) listReleases(ctx context.Context, page, perPage int) ([]release, bool, error) {
+	res, resp, err := s.client.Repositories.ListReleases(ctx, s.Owner, s.Repo, &github.ListOptions{
+		Page:    page,
+		PerPage: perPage,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	releases := make([]release, 0, len(res))
+	for _, r := range res {
+		releases = append(releases, release{
+			tag:         r.GetTagName(),
+			name:        r.GetName(),
+			body:        r.GetBody(),
+			publishedAt: r.GetPublishedAt().Time,
+			prerelease:  r.GetPrerelease(),
+			draft:       r.GetDraft(),
+			fromCache:   fromCache(resp.Header),
+		})
+	}
+	return releases, resp.NextPage != 0, nil
 }
 
 // Returns true if the headers indicate that the response comes from the cache, else returns false.
@@ -159,11 +333,3 @@ func filter[T any](ss []T, test func(T) bool) (ret []T) {
 	}
 	return
 }
-
-func fileIsMD(f fs.DirEntry) bool {
-	return filepath.Ext(f.Name()) == ".md"
-}
-
-func githubFileIsMD(f *github.RepositoryContent) bool {
-	return filepath.Ext(f.GetName()) == ".md"
-}
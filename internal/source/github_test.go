@@ -0,0 +1,221 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jonashiltl/openchangelog/internal"
+	"github.com/jonashiltl/openchangelog/internal/config"
+	"github.com/jonashiltl/openchangelog/internal/store"
+	"github.com/jonashiltl/openchangelog/internal/xcache"
+)
+
+func TestGitHubEnterpriseRef(t *testing.T) {
+	// more files than fetched by their sha, so the archive is downloaded
+	notes := map[string]string{
+		"notes/v4.md": "v4",
+		"notes/v3.md": "v3",
+		"notes/v2.md": "v2",
+		"notes/v1.md": "v1",
+	}
+	archived := make(map[string]string, len(notes))
+	for path, content := range notes {
+		archived["owner-repo-abc/"+path] = content
+	}
+	archive := createArchive(t, archived)
+
+	var srv *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/repos/owner/repo/contents/notes", func(w http.ResponseWriter, r *http.Request) {
+		if ref := r.URL.Query().Get("ref"); ref != "docs" {
+			t.Errorf("expected ref docs but got %q", ref)
+		}
+		dir := make([]map[string]string, 0, len(notes))
+		for path, content := range notes {
+			dir = append(dir, map[string]string{"type": "file", "path": path, "sha": gitBlobSHA([]byte(content))})
+		}
+		json.NewEncoder(w).Encode(dir)
+	})
+	mux.HandleFunc("GET /api/v3/repos/owner/repo/tarball/docs", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, srv.URL+"/archive.tar.gz", http.StatusFound)
+	})
+	mux.HandleFunc("GET /archive.tar.gz", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(archive)
+	})
+	srv = httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	s, err := NewGHSourceFromStore(config.Config{}, store.GHSource{
+		Owner:   "owner",
+		Repo:    "repo",
+		Path:    "notes",
+		Ref:     "docs",
+		BaseURL: srv.URL,
+	}, xcache.NewMemoryCache())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedID := ID(fmt.Sprintf("gh/%s/owner/repo/docs/notes", strings.TrimPrefix(srv.URL, "http://")))
+	if s.ID() != expectedID {
+		t.Errorf("expected the host and ref to be part of the id %s, got %s", expectedID, s.ID())
+	}
+
+	res, err := s.Load(context.Background(), internal.NoPagination())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Raw) != 4 || len(res.Errors) != 0 {
+		t.Fatalf("expected 4 release notes but got %d, errors: %v", len(res.Raw), res.Errors)
+	}
+	for i, expected := range []string{"v4", "v3", "v2", "v1"} {
+		c, _ := io.ReadAll(res.Raw[i].Content)
+		if string(c) != expected {
+			t.Errorf("expected %s but got %s", expected, c)
+		}
+	}
+}
+
+func TestGitHubRecursiveTree(t *testing.T) {
+	notes := map[string]string{
+		"notes/v1.md":          "v1",
+		"notes/2024/v2.md":     "v2",
+		"notes/2024/q4/v3.md":  "v3",
+		"notes/2024/image.png": "image",
+		"docs/v4.md":           "outside of path",
+	}
+
+	var treeRequests int
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/repos/owner/repo/contents/notes", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]map[string]string{
+			{"type": "file", "path": "notes/v1.md", "sha": gitBlobSHA([]byte("v1"))},
+			{"type": "dir", "path": "notes/2024", "sha": "dir"},
+		})
+	})
+	mux.HandleFunc("GET /api/v3/repos/owner/repo/git/trees/{sha}", func(w http.ResponseWriter, r *http.Request) {
+		treeRequests++
+		if r.PathValue("sha") != "HEAD" || r.URL.Query().Get("recursive") == "" {
+			t.Errorf("expected a recursive request of the HEAD tree but got %s", r.URL)
+		}
+		entries := []map[string]string{{"type": "tree", "path": "notes", "sha": "dir"}}
+		for path, content := range notes {
+			entries = append(entries, map[string]string{"type": "blob", "path": path, "sha": gitBlobSHA([]byte(content))})
+		}
+		json.NewEncoder(w).Encode(map[string]any{"sha": "root", "tree": entries})
+	})
+	mux.HandleFunc("GET /api/v3/repos/owner/repo/git/blobs/{sha}", func(w http.ResponseWriter, r *http.Request) {
+		for _, content := range notes {
+			if gitBlobSHA([]byte(content)) == r.PathValue("sha") {
+				w.Write([]byte(content))
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	s, err := NewGHSourceFromStore(config.Config{}, store.GHSource{
+		Owner:   "owner",
+		Repo:    "repo",
+		Path:    "notes",
+		BaseURL: srv.URL,
+		Include: []string{"**/*.md"},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := s.Load(context.Background(), internal.NewPagination(1, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if treeRequests != 1 {
+		t.Errorf("expected a single tree request but got %d", treeRequests)
+	}
+	if !res.HasMore || len(res.Raw) != 1 || res.Raw[0].Path != "v1.md" {
+		t.Fatalf("expected the first of 3 release notes, got %+v", res)
+	}
+}
+
+func TestNewGitHubID(t *testing.T) {
+	tables := []struct {
+		id       ID
+		expected ID
+	}{
+		{id: NewGitHubID("", "owner", "repo", "", "notes"), expected: "gh/owner/repo/notes"},
+		{id: NewGitHubID("", "owner", "repo", "main", "notes"), expected: "gh/owner/repo/main/notes"},
+		{id: NewGitHubID("https://github.example.com", "owner", "repo", "", "notes"), expected: "gh/github.example.com/owner/repo/notes"},
+		{id: NewGitHubReleasesID("", "owner", "repo"), expected: "gh-releases/owner/repo"},
+		{id: NewGitHubReleasesID("https://github.example.com/", "owner", "repo"), expected: "gh-releases/github.example.com/owner/repo"},
+	}
+
+	for _, table := range tables {
+		if table.id != table.expected {
+			t.Errorf("expected %s but got %s", table.expected, table.id)
+		}
+	}
+}
+
+func TestValidateGitHubBaseURL(t *testing.T) {
+	dbMode := config.Config{
+		SqliteURL: "file::memory:",
+		Github:    &config.GithubConfig{EnterpriseHosts: []string{"github.example.com"}},
+	}
+
+	tables := []struct {
+		name    string
+		cfg     config.Config
+		baseURL string
+		err     bool
+	}{
+		{name: "github.com", cfg: dbMode, baseURL: ""},
+		{name: "allowlisted host", cfg: dbMode, baseURL: "https://GitHub.example.com/api/v3"},
+		{name: "other host", cfg: dbMode, baseURL: "https://attacker.example.com", err: true},
+		{name: "no allowlist", cfg: config.Config{SqliteURL: "file::memory:"}, baseURL: "https://github.example.com", err: true},
+		{name: "config mode", cfg: config.Config{}, baseURL: "https://github.example.com"},
+		{name: "invalid scheme", cfg: config.Config{}, baseURL: "ftp://github.example.com", err: true},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			err := ValidateGitHubBaseURL(table.cfg, table.baseURL)
+			if (err != nil) != table.err {
+				t.Errorf("expected error %t but got %v", table.err, err)
+			}
+		})
+	}
+}
+
+func TestGitHubDBModeRejectsPrivateAddresses(t *testing.T) {
+	requested := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := config.Config{
+		SqliteURL: "file::memory:",
+		Github: &config.GithubConfig{
+			EnterpriseHosts: []string{strings.TrimPrefix(srv.URL, "http://")},
+			Auth:            &config.GithubAuth{AccessToken: "server-token"},
+		},
+	}
+	s, err := NewGHSourceFromStore(cfg, store.GHSource{Owner: "owner", Repo: "repo", BaseURL: srv.URL}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.Load(context.Background(), internal.NoPagination())
+	if err == nil || !strings.Contains(err.Error(), ErrPrivateAddress.Error()) {
+		t.Errorf("expected the private address to be rejected but got %v", err)
+	}
+	if requested {
+		t.Error("expected no request to reach the server")
+	}
+}
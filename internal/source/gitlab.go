@@ -5,10 +5,10 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"io"
 	"net/http"
-	"path/filepath"
 	"sort"
-	"sync"
+	"time"
 
 	"github.com/jonashiltl/openchangelog/internal"
 	"github.com/jonashiltl/openchangelog/internal/config"
@@ -18,12 +18,18 @@ import (
 	gitlab "gitlab.com/gitlab-org/api/client-go"
 )
 
+// The highest page size of the GitLab REST api.
+const gitlabMaxPerPage = 100
+
 type glSource struct {
-	client  *gitlab.Client
-	baseURL string
-	project string
-	path    string
-	ref     string
+	client   *gitlab.Client
+	baseURL  string
+	project  string
+	path     string
+	ref      string
+	releases bool
+	filter   fileFilter
+	blobs    blobLoader
 }
 
 func NewGLSourceFromStore(cfg config.Config, gl store.GLSource, cache xcache.Cache) (Source, error) {
@@ -53,20 +59,36 @@ func NewGLSourceFromStore(cfg config.Config, gl store.GLSource, cache xcache.Cac
 		return nil, err
 	}
 
-	return &glSource{
-		client:  client,
-		baseURL: gl.BaseURL,
-		project: gl.Project,
-		path:    gl.Path,
-		ref:     gl.Ref,
-	}, nil
+	s := &glSource{
+		client:   client,
+		baseURL:  gl.BaseURL,
+		project:  gl.Project,
+		path:     gl.Path,
+		ref:      gl.Ref,
+		releases: gl.Releases,
+		filter:   newFileFilter(gl.Include, gl.Exclude),
+	}
+	s.blobs = blobLoader{
+		cache:        cache,
+		prefix:       fmt.Sprintf("gl/%s/%s", gl.BaseURL, gl.Project),
+		fetchBlob:    s.fetchBlob,
+		fetchArchive: s.fetchArchive,
+	}
+	return s, nil
 }
 
 func NewGitLabID(project, path string) ID {
 	return ID(fmt.Sprintf("gl/%s/%s", project, path))
 }
 
+func NewGitLabReleasesID(project string) ID {
+	return ID(fmt.Sprintf("gl-releases/%s", project))
+}
+
 func (s *glSource) ID() ID {
+	if s.releases {
+		return NewGitLabReleasesID(s.project)
+	}
 	return NewGitLabID(s.project, s.path)
 }
 
@@ -74,6 +96,9 @@ func (s *glSource) Load(ctx context.Context, page internal.Pagination) (LoadResu
 	if page.IsDefined() && page.PageSize() < 1 {
 		return LoadResult{}, nil
 	}
+	if s.releases {
+		return loadReleases(ctx, page, gitlabMaxPerPage, s.listReleases)
+	}
 
 	//-> Load as FIle if that works-> return that else load as Folder
 	file, resp, err := s.client.RepositoryFiles.GetFile(
@@ -99,76 +124,137 @@ func (s *glSource) singleFileResult(resp *gitlab.Response, file *gitlab.File) (L
 			{
 				hasChanged: !fromCache(resp.Header),
 				Content:    bytes.NewReader(decoded),
+				Path:       file.FileName,
 			},
 		},
 	}, nil
 }
 
 func (s *glSource) loadDir(ctx context.Context, page internal.Pagination) (LoadResult, error) {
-	nodes, _, err := s.client.Repositories.ListTree(
-		s.project,
-		&gitlab.ListTreeOptions{
-			Path: gitlab.Ptr(s.path),
-			Ref:  gitlab.Ptr(s.ref),
-		},
-		gitlab.WithContext(ctx),
-	)
+	files, hasChanged, err := s.listFiles(ctx)
 	if err != nil {
 		return LoadResult{}, err
 	}
 
-	// Only keep markdown files and sort newest first by filename
-	mdFiles := filter(nodes, gitlabFileIsMD)
-	sort.Slice(mdFiles, func(i, j int) bool {
-		return mdFiles[i].Name >= mdFiles[j].Name
+	// sort newest first by path
+	sort.Slice(files, func(i, j int) bool {
+		return relPath(s.path, files[i].path) >= relPath(s.path, files[j].path)
 	})
 
-	totalFiles := len(mdFiles)
+	totalFiles := len(files)
 	start, end := calculatePaginationIndices(page, totalFiles)
 	if start >= totalFiles {
 		return LoadResult{}, nil
 	}
+
+	contents, loadErrs := s.blobs.load(ctx, files[start:end], files)
 	notes := make([]RawReleaseNote, end-start)
-	var wg sync.WaitGroup
-	for i, file := range mdFiles[start:end] {
-		wg.Add(1)
-		go func(index int, path string) {
-			defer wg.Done()
-			note, err := s.fetchFile(ctx, path)
-			if err != nil {
-				return
-			}
-			notes[index] = note
-		}(i, file.Path)
+	paths := make([]string, end-start)
+	for i, file := range files[start:end] {
+		paths[i] = relPath(s.path, file.path)
+		notes[i] = RawReleaseNote{
+			hasChanged: hasChanged,
+			Content:    bytes.NewReader(contents[i]),
+			Path:       paths[i],
+		}
 	}
-	wg.Wait()
 
+	notes, failed := removeFailed(notes, paths, loadErrs)
 	return LoadResult{
 		Raw:     notes,
 		HasMore: end < totalFiles,
+		Errors:  failed,
 	}, nil
 }
 
-func (s *glSource) fetchFile(ctx context.Context, path string) (RawReleaseNote, error) {
-	content, resp, err := s.client.RepositoryFiles.GetRawFile(
+// Lists the files of the directory selected by the filter, sub directories are listed if the filter is recursive.
+// Returns true if any page of the listing changed.
+func (s *glSource) listFiles(ctx context.Context) ([]blobFile, bool, error) {
+	opts := &gitlab.ListTreeOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: gitlabMaxPerPage,
+		},
+		Path:      gitlab.Ptr(s.path),
+		Ref:       gitlab.Ptr(s.ref),
+		Recursive: gitlab.Ptr(s.filter.recursive()),
+	}
+
+	files := make([]blobFile, 0)
+	hasChanged := false
+	for {
+		nodes, resp, err := s.client.Repositories.ListTree(s.project, opts, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, false, err
+		}
+		hasChanged = hasChanged || !fromCache(resp.Header)
+		for _, n := range nodes {
+			if n.Type == "blob" && s.filter.match(relPath(s.path, n.Path)) {
+				files = append(files, blobFile{path: n.Path, sha: n.ID})
+			}
+		}
+		if resp.NextPage == 0 {
+			return files, hasChanged, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func (s *glSource) fetchBlob(ctx context.Context, sha string) ([]byte, error) {
+	b, _, err := s.client.Repositories.RawBlobContent(s.project, sha, gitlab.WithContext(ctx))
+	return b, err
+}
+
+// Downloads the archive of the directory, the ref defaults to the default branch.
+func (s *glSource) fetchArchive(ctx context.Context) (io.ReadCloser, error) {
+	opts := &gitlab.ArchiveOptions{
+		Format: gitlab.Ptr("tar.gz"),
+	}
+	if s.path != "" {
+		opts.Path = gitlab.Ptr(s.path)
+	}
+	if s.ref != "" {
+		opts.SHA = gitlab.Ptr(s.ref)
+	}
+	b, _, err := s.client.Repositories.Archive(s.project, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(b)), nil
+}
+
+// Releases are ordered by their release date, GitLab has no prerelease flag.
+func (s *glSource) listReleases(ctx context.Context, page, perPage int) ([]release, bool, error) {
+	res, resp, err := s.client.Releases.ListReleases(
 		s.project,
-		path,
-		&gitlab.GetRawFileOptions{Ref: gitlab.Ptr(s.ref)},
+		&gitlab.ListReleasesOptions{
+			ListOptions: gitlab.ListOptions{
+				Page:    int64(page),
+				PerPage: int64(perPage),
+			},
+			OrderBy: gitlab.Ptr("released_at"),
+			Sort:    gitlab.Ptr("desc"),
+		},
 		gitlab.WithContext(ctx),
 	)
 	if err != nil {
-		return RawReleaseNote{}, err
+		return nil, false, err
 	}
-	if resp.StatusCode >= 400 {
-		return RawReleaseNote{}, fmt.Errorf("gitlab returned status %d for file %s", resp.StatusCode, path)
-	}
-	return RawReleaseNote{
-		hasChanged: !fromCache(resp.Header),
-		Content:    bytes.NewReader(content),
-	}, nil
-}
 
-// some functions are used from github.go itself,
-func gitlabFileIsMD(f *gitlab.TreeNode) bool {
-	return filepath.Ext(f.Name) == ".md"
+	releases := make([]release, 0, len(res))
+	for _, r := range res {
+		var publishedAt time.Time
+		if r.ReleasedAt != nil {
+			publishedAt = *r.ReleasedAt
+		} else if r.CreatedAt != nil {
+			publishedAt = *r.CreatedAt
+		}
+		releases = append(releases, release{
+			tag:         r.TagName,
+			name:        r.Name,
+			body:        r.Description,
+			publishedAt: publishedAt,
+			fromCache:   fromCache(resp.Header),
+		})
+	}
+	return releases, resp.NextPage != 0, nil
 }
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"sync"
 
 	"github.com/jonashiltl/openchangelog/internal"
@@ -16,14 +17,16 @@ import (
 )
 
 type localSource struct {
-	path  string
-	cache xcache.Cache
+	path   string
+	filter fileFilter
+	cache  xcache.Cache
 }
 
 func NewLocalSourceFromStore(s store.LocalSource, cache xcache.Cache) Source {
 	return &localSource{
-		path:  s.Path,
-		cache: cache,
+		path:   s.Path,
+		filter: newFileFilter(s.Include, s.Exclude),
+		cache:  cache,
 	}
 }
 
@@ -54,52 +57,83 @@ func (s *localSource) Load(ctx context.Context, page internal.Pagination) (LoadR
 }
 
 func (s *localSource) loadDir(path string, page internal.Pagination) (LoadResult, error) {
-	files, err := os.ReadDir(path)
+	files, err := s.listFiles(path)
 	if err != nil {
 		return LoadResult{}, err
 	}
 
-	files = filter(files, fileIsMD)
 	totalFiles := len(files)
 	start, end := calculatePaginationIndices(page, totalFiles)
 	if start >= totalFiles {
 		return LoadResult{}, nil
 	}
 
-	// sort files in descending order by filename
+	// sort files in descending order by their path, e.g. 2025/03/v2.4.0.md before 2025/01/v2.3.0.md
 	sort.Slice(files, func(i, j int) bool {
-		return files[i].Name() >= files[j].Name()
+		return files[i] >= files[j]
 	})
 
 	result := make([]RawReleaseNote, end-start)
+	loadErrs := make([]error, end-start)
 	var wg sync.WaitGroup
 
 	for i, file := range files[start:end] {
-            wg.Add(1)
-            go func(index int, file fs.DirEntry) {
-                defer wg.Done()
-                raw, err := s.openAndCacheFile(filepath.Join(path, file.Name()))
-                if err != nil {
-                    
-                    return
-               }
-                // Store at the correct index to maintain order
-                result[index] = raw
-            }(i, file)
-        }
+		wg.Add(1)
+		go func(index int, file string) {
+			defer wg.Done()
+			raw, err := s.openAndCacheFile(filepath.Join(path, filepath.FromSlash(file)))
+			if err != nil {
+				loadErrs[index] = err
+				return
+			}
+			raw.Path = file
+			// Store at the correct index to maintain order
+			result[index] = raw
+		}(i, file)
+	}
 	wg.Wait()
 
+	result, failed := removeFailed(result, files[start:end], loadErrs)
 	return LoadResult{
 		Raw:     result,
 		HasMore: end < len(files),
+		Errors:  failed,
 	}, nil
 }
 
+// Returns the slash separated paths of the files selected by the filter, relative to dir.
+// Sub directories are only walked if the filter is recursive.
+func (s *localSource) listFiles(dir string) ([]string, error) {
+	files := make([]string, 0)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && !s.filter.recursive() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if s.filter.match(rel) {
+			files = append(files, rel)
+		}
+		return nil
+	})
+	return files, err
+}
+
 func (s *localSource) loadFile(path string) (LoadResult, error) {
 	raw, err := s.openAndCacheFile(path)
 	if err != nil {
 		return LoadResult{}, err
 	}
+	raw.Path = filepath.Base(path)
 
 	return LoadResult{
 		Raw:     []RawReleaseNote{raw},
@@ -132,6 +166,32 @@ func (s *localSource) openAndCacheFile(path string) (RawReleaseNote, error) {
 	}, nil
 }
 
+// Returns if the local source loads the file at path or if path is a directory it could load files from.
+// Path needs to be absolute.
+func LocalSourceContains(lc store.LocalSource, path string) bool {
+	root, err := filepath.Abs(lc.Path)
+	if err != nil {
+		return false
+	}
+	path = filepath.Clean(path)
+	if path == root {
+		return true
+	}
+
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+
+	f := newFileFilter(lc.Include, lc.Exclude)
+	if f.match(rel) {
+		return true
+	}
+	// created or deleted directories of recursive sources, deleted paths can't be checked with os.Stat
+	return f.recursive() && filepath.Ext(rel) == ""
+}
+
 // Caculates the start and end index for the total files.
 // Start is inclusive, end ist exclusive.
 func calculatePaginationIndices(page internal.Pagination, totalFiles int) (start, end int) {
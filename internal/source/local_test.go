@@ -1,7 +1,9 @@
 package source
 
 import (
+	"context"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/jonashiltl/openchangelog/internal"
@@ -156,6 +158,97 @@ func TestLoadDirPagination(t *testing.T) {
 	}
 }
 
+func TestLoadDirReportsFailedFiles(t *testing.T) {
+	source, dir := createLocalSourceDir(t)
+	defer os.RemoveAll(dir)
+	createNTempFiles(t, 2, dir)
+	err := os.Symlink(filepath.Join(dir, "missing"), filepath.Join(dir, "broken.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := source.loadDir(dir, internal.NoPagination())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Raw) != 2 {
+		t.Errorf("expected 2 raw notes, but got %d", len(loaded.Raw))
+	}
+	for _, raw := range loaded.Raw {
+		if raw.Content == nil {
+			t.Error("expected failed files to be left out")
+		}
+	}
+	if len(loaded.Errors) != 1 || loaded.Errors[0].Path != "broken.md" {
+		t.Errorf("expected broken.md to fail, got %v", loaded.Errors)
+	}
+}
+
+func TestLoadDirRecursive(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []string{"2024/12/v2.3.0.md", "2025/03/v2.4.0.md", "2025/03/draft-v2.5.0.md", "2025/03/notes.txt", "v1.0.0.md"} {
+		path := filepath.Join(dir, filepath.FromSlash(f))
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(path, []byte(f), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tables := []struct {
+		name     string
+		include  []string
+		exclude  []string
+		expected []string
+	}{
+		{
+			name:     "default only loads top level",
+			expected: []string{"v1.0.0.md"},
+		},
+		{
+			name:     "recursive",
+			include:  []string{"**/*.md"},
+			expected: []string{"v1.0.0.md", "2025/03/v2.4.0.md", "2025/03/draft-v2.5.0.md", "2024/12/v2.3.0.md"},
+		},
+		{
+			name:     "exclude",
+			include:  []string{"**/*.md"},
+			exclude:  []string{"**/draft-*"},
+			expected: []string{"v1.0.0.md", "2025/03/v2.4.0.md", "2024/12/v2.3.0.md"},
+		},
+		{
+			name:     "single directory",
+			include:  []string{"2025/*/*.md"},
+			expected: []string{"2025/03/v2.4.0.md", "2025/03/draft-v2.5.0.md"},
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			source := NewLocalSourceFromStore(store.LocalSource{
+				Path:    dir,
+				Include: table.include,
+				Exclude: table.exclude,
+			}, nil)
+			loaded, err := source.Load(context.Background(), internal.NoPagination())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(loaded.Raw) != len(table.expected) {
+				t.Fatalf("expected %d raw notes, but got %d", len(table.expected), len(loaded.Raw))
+			}
+			for i, raw := range loaded.Raw {
+				if raw.Path != table.expected[i] {
+					t.Errorf("expected %s at index %d, but got %s", table.expected[i], i, raw.Path)
+				}
+			}
+		})
+	}
+}
+
 func TestCalculatePaginationIndices(t *testing.T) {
 	tests := []struct {
 		page          internal.Pagination
@@ -199,3 +292,32 @@ func TestCalculatePaginationIndices(t *testing.T) {
 		}
 	}
 }
+
+func TestLocalSourceContains(t *testing.T) {
+	tables := []struct {
+		name     string
+		source   store.LocalSource
+		path     string
+		expected bool
+	}{
+		{name: "file itself", source: store.LocalSource{Path: "/notes/v1.md"}, path: "/notes/v1.md", expected: true},
+		{name: "other file", source: store.LocalSource{Path: "/notes/v1.md"}, path: "/notes/v2.md", expected: false},
+		{name: "markdown in dir", source: store.LocalSource{Path: "/notes"}, path: "/notes/v1.md", expected: true},
+		{name: "dir itself", source: store.LocalSource{Path: "/notes/"}, path: "/notes", expected: true},
+		{name: "other extension in dir", source: store.LocalSource{Path: "/notes"}, path: "/notes/v1.txt", expected: false},
+		{name: "nested dir", source: store.LocalSource{Path: "/notes"}, path: "/notes/old/v1.md", expected: false},
+		{name: "other dir", source: store.LocalSource{Path: "/notes"}, path: "/other/v1.md", expected: false},
+		{name: "recursive", source: store.LocalSource{Path: "/notes", Include: []string{"**/*.md"}}, path: "/notes/2025/03/v1.md", expected: true},
+		{name: "recursive sub dir", source: store.LocalSource{Path: "/notes", Include: []string{"**/*.md"}}, path: "/notes/2025", expected: true},
+		{name: "excluded", source: store.LocalSource{Path: "/notes", Exclude: []string{"draft-*"}}, path: "/notes/draft-v1.md", expected: false},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			got := LocalSourceContains(table.source, table.path)
+			if got != table.expected {
+				t.Errorf("expected %t but got %t", table.expected, got)
+			}
+		})
+	}
+}
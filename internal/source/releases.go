@@ -0,0 +1,141 @@
+package source
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/internal"
+	"gopkg.in/yaml.v3"
+)
+
+// Tag added to release notes created from a prerelease.
+const prereleaseTag = "Prerelease"
+
+// A release of a repository, as returned by the releases API of a hosting provider.
+type release struct {
+	tag         string
+	name        string
+	body        string
+	publishedAt time.Time
+	prerelease  bool
+	// drafts are listed, so the positions of the releases match the pages of the provider
+	draft     bool
+	fromCache bool
+}
+
+// Lists one page of releases with perPage releases, newest first, including drafts.
+// hasNext is true if the provider has another page of releases.
+type listReleasesFunc func(ctx context.Context, page, perPage int) (releases []release, hasNext bool, err error)
+
+// Loads the releases of the requested page, using list to request the pages of the provider.
+// maxPerPage is the highest page size supported by the provider.
+// If page is not defined, all releases are loaded.
+// Drafts are skipped after the page was sliced, so a page can contain less than page size release notes.
+func loadReleases(ctx context.Context, page internal.Pagination, maxPerPage int, list listReleasesFunc) (LoadResult, error) {
+	if !page.IsDefined() {
+		var notes []RawReleaseNote
+		for p := 1; ; p++ {
+			releases, hasNext, err := list(ctx, p, maxPerPage)
+			if err != nil {
+				return LoadResult{}, err
+			}
+			notes = append(notes, releaseNotes(releases)...)
+			if !hasNext || len(releases) == 0 {
+				return LoadResult{Raw: notes}, nil
+			}
+		}
+	}
+
+	// Requests the provider pages that cover the releases of the page.
+	// A single request is enough if the page size is supported by the provider.
+	perPage := min(page.PageSize(), maxPerPage)
+	start := page.StartIdx()
+	end := start + page.PageSize()
+
+	var releases []release
+	hasMore := false
+	for p := start/perPage + 1; (p-1)*perPage < end; p++ {
+		res, hasNext, err := list(ctx, p, perPage)
+		if err != nil {
+			return LoadResult{}, err
+		}
+		releases = append(releases, res...)
+		hasMore = hasNext
+		if !hasNext || len(res) == 0 {
+			break
+		}
+	}
+
+	// offset of the first release of the page in the loaded releases
+	offset := start % perPage
+	if offset >= len(releases) {
+		return LoadResult{}, nil
+	}
+	releases = releases[offset:]
+	if len(releases) > page.PageSize() {
+		releases = releases[:page.PageSize()]
+		hasMore = true
+	}
+
+	return LoadResult{
+		Raw:     releaseNotes(releases),
+		HasMore: hasMore,
+	}, nil
+}
+
+// Converts the releases to release notes, drafts are skipped.
+func releaseNotes(releases []release) []RawReleaseNote {
+	notes := make([]RawReleaseNote, 0, len(releases))
+	for _, r := range releases {
+		if !r.draft {
+			notes = append(notes, r.toRawReleaseNote())
+		}
+	}
+	return notes
+}
+
+// Frontmatter of release notes generated by a source, keys must match the parse.Meta fields.
+type noteFrontmatter struct {
+	Title       string    `yaml:"title"`
+	PublishedAt time.Time `yaml:"publishedAt"`
+	Tags        []string  `yaml:"tags,omitempty"`
+}
+
+// Creates a release note in our markdown format, with fm as frontmatter followed by body.
+func newGeneratedNote(fm noteFrontmatter, body string, hasChanged bool) RawReleaseNote {
+	fm.PublishedAt = fm.PublishedAt.UTC()
+	// encoding a struct of strings and a time can't fail
+	meta, _ := yaml.Marshal(fm)
+
+	var b bytes.Buffer
+	b.WriteString("---\n")
+	b.Write(meta)
+	b.WriteString("---\n")
+	b.WriteString(body)
+
+	return RawReleaseNote{
+		Content:    &b,
+		hasChanged: hasChanged,
+	}
+}
+
+// Converts the release to a release note in our markdown format.
+// The name is used as title, or the tag if the release has no name.
+// The tag and the prerelease flag are added as tags of the release note.
+func (r release) toRawReleaseNote() RawReleaseNote {
+	fm := noteFrontmatter{
+		Title:       r.name,
+		PublishedAt: r.publishedAt,
+	}
+	if fm.Title == "" {
+		fm.Title = r.tag
+	}
+	if r.tag != "" {
+		fm.Tags = append(fm.Tags, r.tag)
+	}
+	if r.prerelease {
+		fm.Tags = append(fm.Tags, prereleaseTag)
+	}
+	return newGeneratedNote(fm, r.body, !r.fromCache)
+}
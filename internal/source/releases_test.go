@@ -0,0 +1,302 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	forgejo "codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v3"
+	"github.com/google/go-github/v62/github"
+	"github.com/jonashiltl/openchangelog/internal"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+var releasesPublishedAt = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Creates a stand-in for the releases api of a provider, serving total releases newest first.
+// Release i is tagged v0.0.<total-i>, published i days before releasesPublishedAt and every third release is a prerelease.
+// toJSON converts a release to the json of the provider, pageSizeParam is the query param of the page size.
+func newReleasesServer(t *testing.T, path string, total int, pageSizeParam string, toJSON func(i int, published time.Time) map[string]any) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/version", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"version":"1.21.0"}`))
+	})
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get(pageSizeParam))
+		if perPage == 0 {
+			perPage = 30
+		}
+		page = max(page, 1)
+
+		res := make([]map[string]any, 0, perPage)
+		for i := (page - 1) * perPage; i < min(page*perPage, total); i++ {
+			res = append(res, toJSON(i, releasesPublishedAt.AddDate(0, 0, -i)))
+		}
+		if page*perPage < total {
+			next := *r.URL
+			q := next.Query()
+			q.Set("page", strconv.Itoa(page+1))
+			next.RawQuery = q.Encode()
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s%s>; rel="next"`, r.Host, next.String()))
+			w.Header().Set("X-Next-Page", strconv.Itoa(page+1))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(res)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func releaseTag(total, i int) string {
+	return fmt.Sprintf("v0.0.%d", total-i)
+}
+
+func newGHReleasesSource(t *testing.T, total int) Source {
+	srv := newReleasesServer(t, "/repos/owner/repo/releases", total, "per_page", func(i int, published time.Time) map[string]any {
+		return map[string]any{
+			"tag_name":     releaseTag(total, i),
+			"name":         "Release " + releaseTag(total, i),
+			"body":         "body",
+			"prerelease":   i%3 == 0,
+			"published_at": published,
+		}
+	})
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+	return &ghSource{client: client, Owner: "owner", Repo: "repo", Releases: true}
+}
+
+func newGLReleasesSource(t *testing.T, total int) Source {
+	srv := newReleasesServer(t, "/api/v4/projects/group%2Frepo/releases", total, "per_page", func(i int, published time.Time) map[string]any {
+		return map[string]any{
+			"tag_name":    releaseTag(total, i),
+			"name":        "Release " + releaseTag(total, i),
+			"description": "body",
+			"released_at": published,
+		}
+	})
+	client, err := gitlab.NewClient("", gitlab.WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &glSource{client: client, project: "group/repo", releases: true}
+}
+
+func newFJReleasesSource(t *testing.T, total int) Source {
+	srv := newReleasesServer(t, "/api/v1/repos/owner/repo/releases", total, "limit", func(i int, published time.Time) map[string]any {
+		return map[string]any{
+			"tag_name":     releaseTag(total, i),
+			"name":         "Release " + releaseTag(total, i),
+			"body":         "body",
+			"prerelease":   i%3 == 0,
+			"published_at": published,
+		}
+	})
+	client, err := forgejo.NewClient(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &fjSource{client: client, project: "owner/repo", releases: true}
+}
+
+func TestReleasesLoad(t *testing.T) {
+	const total = 7
+
+	sources := []struct {
+		name       string
+		new        func(t *testing.T, total int) Source
+		prerelease bool
+	}{
+		{name: "github", new: newGHReleasesSource, prerelease: true},
+		{name: "gitlab", new: newGLReleasesSource},
+		{name: "forgejo", new: newFJReleasesSource, prerelease: true},
+	}
+
+	tables := []struct {
+		name     string
+		page     internal.Pagination
+		expected []int
+		hasMore  bool
+	}{
+		{
+			name:     "all releases",
+			page:     internal.NoPagination(),
+			expected: []int{0, 1, 2, 3, 4, 5, 6},
+		},
+		{
+			name:     "first page",
+			page:     internal.NewPagination(3, 1),
+			expected: []int{0, 1, 2},
+			hasMore:  true,
+		},
+		{
+			name:     "last page",
+			page:     internal.NewPagination(3, 3),
+			expected: []int{6},
+		},
+		{
+			name:     "page out of range",
+			page:     internal.NewPagination(3, 4),
+			expected: []int{},
+		},
+	}
+
+	for _, src := range sources {
+		for _, table := range tables {
+			t.Run(src.name+"/"+table.name, func(t *testing.T) {
+				s := src.new(t, total)
+				res, err := s.Load(context.Background(), table.page)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if res.HasMore != table.hasMore {
+					t.Errorf("expected hasMore %t but got %t", table.hasMore, res.HasMore)
+				}
+				got := readAll(t, res)
+				if len(got) != len(table.expected) {
+					t.Fatalf("expected %d release notes but got %d", len(table.expected), len(got))
+				}
+				for i, idx := range table.expected {
+					tag := releaseTag(total, idx)
+					if !strings.Contains(got[i], "title: Release "+tag+"\n") {
+						t.Errorf("expected release note %d to have title of %s, got %q", i, tag, got[i])
+					}
+					if !strings.Contains(got[i], "    - "+tag+"\n") {
+						t.Errorf("expected release note %d to be tagged with %s, got %q", i, tag, got[i])
+					}
+					published := releasesPublishedAt.AddDate(0, 0, -idx).Format(time.RFC3339)
+					if !strings.Contains(got[i], "publishedAt: "+published+"\n") {
+						t.Errorf("expected release note %d to be published at %s, got %q", i, published, got[i])
+					}
+					isPrerelease := strings.Contains(got[i], "    - "+prereleaseTag+"\n")
+					if isPrerelease != (src.prerelease && idx%3 == 0) {
+						t.Errorf("expected release note %d prerelease to be %t", i, !isPrerelease)
+					}
+					if !strings.HasSuffix(got[i], "---\nbody") {
+						t.Errorf("expected release note %d to end with the body, got %q", i, got[i])
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestLoadReleasesPageSize(t *testing.T) {
+	all := make([]release, 25)
+	for i := range all {
+		all[i] = release{tag: fmt.Sprint(i)}
+	}
+
+	var requested []int
+	list := func(ctx context.Context, page, perPage int) ([]release, bool, error) {
+		requested = append(requested, page)
+		start := min((page-1)*perPage, len(all))
+		end := min(page*perPage, len(all))
+		return all[start:end], end < len(all), nil
+	}
+
+	tables := []struct {
+		name      string
+		page      internal.Pagination
+		expected  []string
+		requested []int
+		hasMore   bool
+	}{
+		{
+			name:      "page size below provider max",
+			page:      internal.NewPagination(4, 2),
+			expected:  []string{"4", "5", "6", "7"},
+			requested: []int{2},
+			hasMore:   true,
+		},
+		{
+			name:      "page size above provider max",
+			page:      internal.NewPagination(7, 2),
+			expected:  []string{"7", "8", "9", "10", "11", "12", "13"},
+			requested: []int{2, 3},
+			hasMore:   true,
+		},
+		{
+			name:      "last page above provider max",
+			page:      internal.NewPagination(7, 4),
+			expected:  []string{"21", "22", "23", "24"},
+			requested: []int{5},
+		},
+		{
+			name:      "no pagination",
+			page:      internal.NoPagination(),
+			expected:  []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "17", "18", "19", "20", "21", "22", "23", "24"},
+			requested: []int{1, 2, 3, 4, 5},
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			requested = nil
+			res, err := loadReleases(context.Background(), table.page, 5, list)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprint(requested) != fmt.Sprint(table.requested) {
+				t.Errorf("expected pages %v to be requested but got %v", table.requested, requested)
+			}
+			if res.HasMore != table.hasMore {
+				t.Errorf("expected hasMore %t but got %t", table.hasMore, res.HasMore)
+			}
+			got := readAll(t, res)
+			if len(got) != len(table.expected) {
+				t.Fatalf("expected %d release notes but got %d", len(table.expected), len(got))
+			}
+			for i, tag := range table.expected {
+				if !strings.Contains(got[i], "title: \""+tag+"\"\n") {
+					t.Errorf("expected release note %d to have title %s, got %q", i, tag, got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLoadReleasesSkipsDrafts(t *testing.T) {
+	// releases 1 and 5 are drafts, the providers list them for clients with push access
+	all := make([]release, 10)
+	for i := range all {
+		all[i] = release{tag: fmt.Sprint(i), draft: i == 1 || i == 5}
+	}
+	list := func(ctx context.Context, page, perPage int) ([]release, bool, error) {
+		start := min((page-1)*perPage, len(all))
+		end := min(page*perPage, len(all))
+		return all[start:end], end < len(all), nil
+	}
+
+	var got []string
+	for page := 1; ; page++ {
+		res, err := loadReleases(context.Background(), internal.NewPagination(3, page), 2, list)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, readAll(t, res)...)
+		if !res.HasMore {
+			break
+		}
+	}
+
+	expected := []string{"0", "2", "3", "4", "6", "7", "8", "9"}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d release notes over all pages but got %d", len(expected), len(got))
+	}
+	for i, tag := range expected {
+		if !strings.Contains(got[i], "title: \""+tag+"\"\n") {
+			t.Errorf("expected release note %d to have title %s, got %q", i, tag, got[i])
+		}
+	}
+}
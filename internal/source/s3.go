@@ -0,0 +1,218 @@
+package source
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/jonashiltl/openchangelog/internal"
+	"github.com/jonashiltl/openchangelog/internal/config"
+	"github.com/jonashiltl/openchangelog/internal/store"
+	"github.com/jonashiltl/openchangelog/internal/xcache"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"golang.org/x/sync/errgroup"
+)
+
+// Used if the s3 source has no endpoint.
+const defaultS3Endpoint = "https://s3.amazonaws.com"
+
+// Loads the markdown objects below a prefix of a bucket in S3 compatible object storage.
+type s3Source struct {
+	client   *minio.Client
+	endpoint string
+	bucket   string
+	prefix   string
+	cache    xcache.Cache
+}
+
+func NewS3SourceFromStore(cfg config.Config, s3 store.S3Source, cache xcache.Cache) (Source, error) {
+	endpoint := s3.Endpoint
+	if endpoint == "" {
+		endpoint = defaultS3Endpoint
+	}
+	u, err := parseS3Endpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// empty credentials send anonymous requests, the credentials of the s3 config section
+	// are only used by the source of the config mode, never by sources of a workspace
+	client, err := minio.New(u.Host, &minio.Options{
+		Creds:  credentials.NewStaticV4(s3.AccessKeyID, s3.SecretAccessKey, ""),
+		Secure: u.Scheme == "https",
+		Region: s3.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &s3Source{
+		client:   client,
+		endpoint: endpoint,
+		bucket:   s3.Bucket,
+		prefix:   strings.TrimPrefix(s3.Prefix, "/"),
+		cache:    cache,
+	}, nil
+}
+
+func NewS3ID(endpoint, bucket, prefix string) ID {
+	if endpoint == "" {
+		endpoint = defaultS3Endpoint
+	}
+	return ID(fmt.Sprintf("s3/%s/%s/%s", endpoint, bucket, strings.TrimPrefix(prefix, "/")))
+}
+
+func (s *s3Source) ID() ID {
+	return NewS3ID(s.endpoint, s.bucket, s.prefix)
+}
+
+func (s *s3Source) Load(ctx context.Context, page internal.Pagination) (LoadResult, error) {
+	if page.IsDefined() && page.PageSize() < 1 {
+		return LoadResult{}, nil
+	}
+	if objectIsMD(s.prefix) {
+		return s.loadObject(ctx)
+	}
+
+	objects, err := s.listMDObjects(ctx)
+	if err != nil {
+		return LoadResult{}, err
+	}
+
+	// sort objects in descending order by filename
+	sort.Slice(objects, func(i, j int) bool {
+		return path.Base(objects[i].Key) >= path.Base(objects[j].Key)
+	})
+
+	start, end := calculatePaginationIndices(page, len(objects))
+	if start >= len(objects) {
+		return LoadResult{}, nil
+	}
+
+	notes := make([]RawReleaseNote, end-start)
+	var eg errgroup.Group
+	for i, obj := range objects[start:end] {
+		eg.Go(func() error {
+			content, err := s.getObject(ctx, obj.Key)
+			if err != nil {
+				return err
+			}
+			notes[i] = RawReleaseNote{
+				Content:    bytes.NewReader(content),
+				hasChanged: s.hasChanged(obj.Key, obj.ETag),
+			}
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return LoadResult{}, err
+	}
+
+	return LoadResult{
+		Raw:     notes,
+		HasMore: end < len(objects),
+	}, nil
+}
+
+func (s *s3Source) loadObject(ctx context.Context) (LoadResult, error) {
+	obj, err := s.client.GetObject(ctx, s.bucket, s.prefix, minio.GetObjectOptions{})
+	if err != nil {
+		return LoadResult{}, err
+	}
+	defer obj.Close()
+
+	info, err := obj.Stat()
+	if err != nil {
+		return LoadResult{}, err
+	}
+	content, err := io.ReadAll(obj)
+	if err != nil {
+		return LoadResult{}, err
+	}
+
+	return LoadResult{
+		Raw: []RawReleaseNote{
+			{
+				Content:    bytes.NewReader(content),
+				hasChanged: s.hasChanged(info.Key, info.ETag),
+			},
+		},
+	}, nil
+}
+
+// Lists the markdown objects directly below the prefix, nested prefixes are ignored like sub directories of local sources.
+func (s *s3Source) listMDObjects(ctx context.Context) ([]minio.ObjectInfo, error) {
+	prefix := s.prefix
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+
+	objects := make([]minio.ObjectInfo, 0)
+	for obj := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Prefix: prefix}) {
+		if obj.Err != nil {
+			return nil, obj.Err
+		}
+		if objectIsMD(obj.Key) {
+			objects = append(objects, obj)
+		}
+	}
+	return objects, nil
+}
+
+func (s *s3Source) getObject(ctx context.Context, key string) ([]byte, error) {
+	obj, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer obj.Close()
+	return io.ReadAll(obj)
+}
+
+// Compares the ETag of the object with the cached one.
+// Updates the cached ETag if the object has changed.
+func (s *s3Source) hasChanged(key, etag string) bool {
+	if s.cache == nil {
+		return true
+	}
+	cacheKey := fmt.Sprintf("%s/%s", s.ID(), key)
+	cached, found := s.cache.Get(cacheKey)
+	if found && string(cached) == etag {
+		return false
+	}
+	s.cache.Set(cacheKey, []byte(etag))
+	return true
+}
+
+func objectIsMD(key string) bool {
+	return strings.HasSuffix(key, ".md")
+}
+
+// Returns an error if endpoint isn't an http or https url without a path.
+func ValidateS3Endpoint(endpoint string) error {
+	_, err := parseS3Endpoint(endpoint)
+	return err
+}
+
+func parseS3Endpoint(endpoint string) (*url.URL, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return nil, errors.New("s3 endpoint must use http or https")
+	}
+	if u.Host == "" {
+		return nil, errors.New("s3 endpoint is missing the host")
+	}
+	if strings.Trim(u.Path, "/") != "" {
+		return nil, errors.New("s3 endpoint must not contain a path")
+	}
+	return u, nil
+}
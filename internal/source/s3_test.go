@@ -0,0 +1,291 @@
+package source
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/internal"
+	"github.com/jonashiltl/openchangelog/internal/config"
+	"github.com/jonashiltl/openchangelog/internal/store"
+	"github.com/jonashiltl/openchangelog/internal/xcache"
+)
+
+// An in-process fake of the S3 api, only supports listing and getting objects with path-style requests.
+type fakeS3 struct {
+	mu      sync.Mutex
+	buckets map[string]map[string]string
+}
+
+func (f *fakeS3) put(bucket, key, content string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.buckets[bucket][key] = content
+}
+
+type fakeS3Object struct {
+	Key          string
+	LastModified string
+	ETag         string
+	Size         int
+}
+
+type fakeS3ListResult struct {
+	XMLName        xml.Name       `xml:"ListBucketResult"`
+	Name           string         `xml:"Name"`
+	Prefix         string         `xml:"Prefix"`
+	KeyCount       int            `xml:"KeyCount"`
+	IsTruncated    bool           `xml:"IsTruncated"`
+	Contents       []fakeS3Object `xml:"Contents"`
+	CommonPrefixes []struct {
+		Prefix string `xml:"Prefix"`
+	} `xml:"CommonPrefixes"`
+}
+
+func etagOf(content string) string {
+	return fmt.Sprintf(`"%x"`, md5.Sum([]byte(content)))
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	bucketName, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	bucket, ok := f.buckets[bucketName]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `<Error><Code>NoSuchBucket</Code><Message>The specified bucket does not exist</Message></Error>`)
+		return
+	}
+
+	q := r.URL.Query()
+	switch {
+	case q.Has("location"):
+		fmt.Fprint(w, `<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`)
+	case key == "":
+		prefix, delimiter := q.Get("prefix"), q.Get("delimiter")
+		res := fakeS3ListResult{Name: bucketName, Prefix: prefix}
+		seen := map[string]bool{}
+		keys := make([]string, 0, len(bucket))
+		for k := range bucket {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if !strings.HasPrefix(k, prefix) {
+				continue
+			}
+			if i := strings.Index(k[len(prefix):], delimiter); delimiter != "" && i >= 0 {
+				p := k[:len(prefix)+i+1]
+				if !seen[p] {
+					seen[p] = true
+					res.CommonPrefixes = append(res.CommonPrefixes, struct {
+						Prefix string `xml:"Prefix"`
+					}{p})
+				}
+				continue
+			}
+			res.Contents = append(res.Contents, fakeS3Object{
+				Key:          k,
+				LastModified: time.Now().UTC().Format(time.RFC3339),
+				ETag:         etagOf(bucket[k]),
+				Size:         len(bucket[k]),
+			})
+		}
+		res.KeyCount = len(res.Contents) + len(res.CommonPrefixes)
+		w.Header().Set("Content-Type", "application/xml")
+		xml.NewEncoder(w).Encode(res)
+	default:
+		content, ok := bucket[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`)
+			return
+		}
+		w.Header().Set("ETag", etagOf(content))
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set("Content-Length", fmt.Sprint(len(content)))
+		if r.Method != http.MethodHead {
+			fmt.Fprint(w, content)
+		}
+	}
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, string) {
+	t.Helper()
+	f := &fakeS3{buckets: map[string]map[string]string{
+		"changelog": {
+			"notes/v0.0.1.md":        "first",
+			"notes/v0.0.2.md":        "second",
+			"notes/v0.0.3.md":        "third",
+			"notes/image.png":        "not a release note",
+			"notes/drafts/v0.0.4.md": "nested",
+			"CHANGELOG.md":           "changelog",
+		},
+	}}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	return f, srv.URL
+}
+
+func createS3Source(t *testing.T, endpoint, bucket, prefix string) Source {
+	t.Helper()
+	s, err := NewS3SourceFromStore(config.Config{}, store.S3Source{
+		Endpoint:        endpoint,
+		Region:          "us-east-1",
+		Bucket:          bucket,
+		Prefix:          prefix,
+		AccessKeyID:     "access",
+		SecretAccessKey: "secret",
+	}, xcache.NewMemoryCache())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestS3SourcePrefix(t *testing.T) {
+	_, endpoint := newFakeS3(t)
+	s := createS3Source(t, endpoint, "changelog", "notes")
+
+	tables := []struct {
+		page     internal.Pagination
+		expected []string
+		hasMore  bool
+	}{
+		{page: internal.NoPagination(), expected: []string{"third", "second", "first"}},
+		{page: internal.NewPagination(2, 1), expected: []string{"third", "second"}, hasMore: true},
+		{page: internal.NewPagination(2, 2), expected: []string{"first"}},
+		{page: internal.NewPagination(2, 3), expected: []string{}},
+	}
+
+	for _, table := range tables {
+		res, err := s.Load(context.Background(), table.page)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := readAll(t, res)
+		if strings.Join(got, ",") != strings.Join(table.expected, ",") {
+			t.Errorf("expected %v but got %v", table.expected, got)
+		}
+		if res.HasMore != table.hasMore {
+			t.Errorf("expected hasMore %t but got %t", table.hasMore, res.HasMore)
+		}
+	}
+}
+
+func TestS3SourceHasChanged(t *testing.T) {
+	f, endpoint := newFakeS3(t)
+	s := createS3Source(t, endpoint, "changelog", "notes/")
+
+	res, err := s.Load(context.Background(), internal.NoPagination())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.HasChanged() {
+		t.Error("expected first load to have changed")
+	}
+
+	res, err = s.Load(context.Background(), internal.NoPagination())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.HasChanged() {
+		t.Error("expected objects with the same etag to be unchanged")
+	}
+
+	f.put("changelog", "notes/v0.0.2.md", "second updated")
+	res, err = s.Load(context.Background(), internal.NoPagination())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.HasChanged() {
+		t.Error("expected updated object to have changed")
+	}
+	if got := readAll(t, res); got[1] != "second updated" {
+		t.Errorf("expected updated content but got %s", got[1])
+	}
+}
+
+func TestS3SourceSingleObject(t *testing.T) {
+	_, endpoint := newFakeS3(t)
+	s := createS3Source(t, endpoint, "changelog", "CHANGELOG.md")
+
+	res, err := s.Load(context.Background(), internal.NoPagination())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := readAll(t, res); len(got) != 1 || got[0] != "changelog" {
+		t.Errorf("expected [changelog] but got %v", got)
+	}
+}
+
+func TestS3SourceMissingBucket(t *testing.T) {
+	_, endpoint := newFakeS3(t)
+	s := createS3Source(t, endpoint, "missing", "notes")
+
+	_, err := s.Load(context.Background(), internal.NoPagination())
+	if err == nil {
+		t.Error("expected error for missing bucket")
+	}
+}
+
+func TestValidateS3Endpoint(t *testing.T) {
+	tables := []struct {
+		endpoint string
+		valid    bool
+	}{
+		{endpoint: "https://s3.eu-central-1.amazonaws.com", valid: true},
+		{endpoint: "http://localhost:9000", valid: true},
+		{endpoint: "localhost:9000"},
+		{endpoint: "ftp://localhost:9000"},
+		{endpoint: "http://localhost:9000/bucket"},
+	}
+
+	for _, table := range tables {
+		err := ValidateS3Endpoint(table.endpoint)
+		if (err == nil) != table.valid {
+			t.Errorf("expected %s to be valid %t but got %v", table.endpoint, table.valid, err)
+		}
+	}
+}
+
+func TestS3SourceIgnoresConfigCredentials(t *testing.T) {
+	f, _ := newFakeS3(t)
+	var mu sync.Mutex
+	var signed bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		signed = signed || r.Header.Get("Authorization") != ""
+		mu.Unlock()
+		f.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	cfg := config.Config{S3: &config.S3Config{AccessKeyID: "operator", SecretAccessKey: "operator-secret"}}
+	s, err := NewS3SourceFromStore(cfg, store.S3Source{
+		Endpoint: srv.URL,
+		Region:   "us-east-1",
+		Bucket:   "changelog",
+		Prefix:   "notes",
+	}, xcache.NewMemoryCache())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = s.Load(context.Background(), internal.NoPagination())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signed {
+		t.Error("expected anonymous requests for a source without credentials")
+	}
+}
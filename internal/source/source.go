@@ -3,7 +3,9 @@ package source
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"strings"
 
 	"github.com/jonashiltl/openchangelog/internal"
 	"github.com/jonashiltl/openchangelog/internal/config"
@@ -12,13 +14,48 @@ import (
 )
 
 type RawReleaseNote struct {
-	Content    io.Reader
+	Content io.Reader
+	// The slash separated path of the file relative to the directory of the source,
+	// the file name for single file sources and empty if the source doesn't load files.
+	Path       string
 	hasChanged bool // only available if caching is enabled
 }
 
 type LoadResult struct {
 	Raw     []RawReleaseNote
 	HasMore bool
+	// The files that failed to be loaded, they are not part of Raw.
+	Errors []FileError
+}
+
+// A single file of a source that failed to be loaded or parsed.
+type FileError struct {
+	// The path of the file like RawReleaseNote.Path
+	Path string
+	Err  error
+}
+
+func (e FileError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+func (e FileError) Unwrap() error {
+	return e.Err
+}
+
+// Removes the release notes that failed to be loaded from notes and returns the errors of their files.
+// paths and errs need to have the same length as notes, the order of notes is preserved.
+func removeFailed(notes []RawReleaseNote, paths []string, errs []error) ([]RawReleaseNote, []FileError) {
+	loaded := notes[:0]
+	var failed []FileError
+	for i, note := range notes {
+		if errs[i] != nil {
+			failed = append(failed, FileError{Path: paths[i], Err: errs[i]})
+			continue
+		}
+		loaded = append(loaded, note)
+	}
+	return loaded, failed
 }
 
 // Returns if any of the loaded release notes have changed since last access.
@@ -49,12 +86,28 @@ func NewIDFromChangelog(cl store.Changelog) ID {
 	switch {
 	case cl.LocalSource.Valid:
 		return NewLocalID(cl.LocalSource.V.Path)
+	case cl.GHSource.Valid && cl.GHSource.V.Releases:
+		return NewGitHubReleasesID(cl.GHSource.V.BaseURL, cl.GHSource.V.Owner, cl.GHSource.V.Repo)
 	case cl.GHSource.Valid:
-		return NewGitHubID(cl.GHSource.V.Owner, cl.GHSource.V.Repo, cl.GHSource.V.Path)
+		return NewGitHubID(cl.GHSource.V.BaseURL, cl.GHSource.V.Owner, cl.GHSource.V.Repo, cl.GHSource.V.Ref, cl.GHSource.V.Path)
+	case cl.GLSource.Valid && cl.GLSource.V.Releases:
+		return NewGitLabReleasesID(cl.GLSource.V.Project)
 	case cl.GLSource.Valid:
 		return NewGitLabID(cl.GLSource.V.Project, cl.GLSource.V.Path)
+	case cl.FJSource.Valid && cl.FJSource.V.Releases:
+		return NewForgejoReleasesID(cl.FJSource.V.Project)
 	case cl.FJSource.Valid:
 		return NewForgejoID(cl.FJSource.V.Project, cl.FJSource.V.Path)
+	case cl.GitSource.Valid && cl.GitSource.V.ConventionalCommits:
+		return NewConventionalCommitsID(cl.GitSource.V.URL, cl.GitSource.V.Ref, strings.Trim(cl.GitSource.V.Path, "/"))
+	case cl.GitSource.Valid:
+		return NewGitID(cl.GitSource.V.URL, cl.GitSource.V.Ref, strings.Trim(cl.GitSource.V.Path, "/"))
+	case cl.URLSource.Valid && cl.URLSource.V.Manifest:
+		return NewURLManifestID(cl.URLSource.V.URL)
+	case cl.URLSource.Valid:
+		return NewURLID(cl.URLSource.V.URL)
+	case cl.S3Source.Valid:
+		return NewS3ID(cl.S3Source.V.Endpoint, cl.S3Source.V.Bucket, cl.S3Source.V.Prefix)
 	}
 	return ""
 }
@@ -70,6 +123,12 @@ func NewSourceFromStore(cfg config.Config, cl store.Changelog, cache xcache.Cach
 		return NewGLSourceFromStore(cfg, cl.GLSource.ValueOrZero(), cache)
 	case cl.FJSource.Valid:
 		return NewFJSourceFromStore(cfg, cl.FJSource.ValueOrZero(), cache)
+	case cl.GitSource.Valid:
+		return NewGitSourceFromStore(cfg, cl.GitSource.ValueOrZero(), cache), nil
+	case cl.URLSource.Valid:
+		return NewURLSourceFromStore(cfg, cl.URLSource.ValueOrZero(), cache), nil
+	case cl.S3Source.Valid:
+		return NewS3SourceFromStore(cfg, cl.S3Source.ValueOrZero(), cache)
 	}
 
 	return nil, errors.New("changelog has no active source")
@@ -0,0 +1,259 @@
+package source
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/internal"
+	"github.com/jonashiltl/openchangelog/internal/config"
+	"github.com/jonashiltl/openchangelog/internal/store"
+	"github.com/jonashiltl/openchangelog/internal/xcache"
+	"github.com/naveensrinivasan/httpcache"
+	"golang.org/x/sync/errgroup"
+)
+
+// Loads release notes from a file, or the files listed in a manifest, over http(s).
+// Responses are cached and revalidated with their ETag or Last-Modified header if caching is enabled.
+type urlSource struct {
+	client   *http.Client
+	url      string
+	manifest bool
+}
+
+// In db mode the urls are defined by the workspaces, so they can't reach private addresses of the server's network,
+// unless url.allowPrivateAddresses is set.
+func NewURLSourceFromStore(cfg config.Config, u store.URLSource, cache xcache.Cache) Source {
+	var guard dialGuard
+	if cfg.IsDBMode() && (cfg.URL == nil || !cfg.URL.AllowPrivateAddresses) {
+		guard = denyPrivateAddr
+	}
+	return newURLSource(u, cache, guard)
+}
+
+// Every connection of the source, including manifest entries and redirects, is checked by guard if it's not nil.
+func newURLSource(u store.URLSource, cache xcache.Cache, guard dialGuard) *urlSource {
+	var tr http.RoundTripper = http.DefaultTransport
+	if guard != nil {
+		tr = newGuardedTransport(guard)
+	}
+	if cache != nil {
+		cachedTransport := httpcache.NewTransport(cache)
+		cachedTransport.Transport = tr
+		tr = cachedTransport
+	}
+
+	return &urlSource{
+		client: &http.Client{
+			Transport: tr,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) >= 10 {
+					return errors.New("stopped after 10 redirects")
+				}
+				return ValidateURL(req.URL.String())
+			},
+		},
+		url:      u.URL,
+		manifest: u.Manifest,
+	}
+}
+
+// Returned in db mode if a url of a url source resolves to an address of the server's network.
+var ErrPrivateAddress = errors.New("url resolves to a private, loopback or link-local address")
+
+// Called with the resolved address of every connection before it's established, an error aborts the connection.
+type dialGuard func(addr netip.AddrPort) error
+
+// Not covered by netip.Addr.IsPrivate, used for carrier-grade NAT and by some cloud metadata endpoints.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// Rejects addresses which aren't reachable from the public internet,
+// e.g. services of the server's network or the cloud metadata endpoint 169.254.169.254.
+func denyPrivateAddr(addr netip.AddrPort) error {
+	ip := addr.Addr().Unmap()
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() || sharedAddressSpace.Contains(ip) {
+		return ErrPrivateAddress
+	}
+	return nil
+}
+
+// Checks the address after the dns resolution, so hosts resolving to private addresses are rejected too.
+func newGuardedTransport(guard dialGuard) *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addr, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			return guard(addr)
+		},
+	}
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.DialContext = dialer.DialContext
+	// a proxy would resolve and connect to the host instead of the dialer
+	tr.Proxy = nil
+	return tr
+}
+
+// Returns a transport which can't connect to private, loopback or link-local addresses,
+// for requests to urls defined by the workspaces in db mode.
+func NewPublicTransport() *http.Transport {
+	return newGuardedTransport(denyPrivateAddr)
+}
+
+// Credentials in the url are not part of the id.
+func NewURLID(url string) ID {
+	return ID(fmt.Sprintf("url/%s", RedactURL(url)))
+}
+
+func NewURLManifestID(url string) ID {
+	return ID(fmt.Sprintf("url-manifest/%s", RedactURL(url)))
+}
+
+func (s *urlSource) ID() ID {
+	if s.manifest {
+		return NewURLManifestID(s.url)
+	}
+	return NewURLID(s.url)
+}
+
+func (s *urlSource) Load(ctx context.Context, page internal.Pagination) (LoadResult, error) {
+	if page.IsDefined() && page.PageSize() < 1 {
+		return LoadResult{}, nil
+	}
+	if !s.manifest {
+		note, err := s.fetch(ctx, s.url)
+		if err != nil {
+			return LoadResult{}, err
+		}
+		return LoadResult{Raw: []RawReleaseNote{note}}, nil
+	}
+
+	manifest, err := s.fetch(ctx, s.url)
+	if err != nil {
+		return LoadResult{}, err
+	}
+	urls, err := parseManifest(s.url, manifest.Content)
+	if err != nil {
+		return LoadResult{}, err
+	}
+
+	start, end := calculatePaginationIndices(page, len(urls))
+	if start >= len(urls) {
+		return LoadResult{}, nil
+	}
+
+	notes := make([]RawReleaseNote, end-start)
+	var eg errgroup.Group
+	for i, u := range urls[start:end] {
+		eg.Go(func() error {
+			note, err := s.fetch(ctx, u)
+			if err != nil {
+				return err
+			}
+			// files removed from the manifest only change the manifest
+			note.hasChanged = note.hasChanged || manifest.hasChanged
+			notes[i] = note
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return LoadResult{}, err
+	}
+
+	return LoadResult{
+		Raw:     notes,
+		HasMore: end < len(urls),
+	}, nil
+}
+
+func (s *urlSource) fetch(ctx context.Context, u string) (RawReleaseNote, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return RawReleaseNote{}, err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return RawReleaseNote{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return RawReleaseNote{}, fmt.Errorf("%s returned status %d", u, resp.StatusCode)
+	}
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return RawReleaseNote{}, err
+	}
+	return RawReleaseNote{
+		hasChanged: !fromCache(resp.Header),
+		Content:    bytes.NewReader(content),
+	}, nil
+}
+
+// Removes the password of the user info from u.
+func RedactURL(u string) string {
+	parsed, err := url.Parse(u)
+	if err != nil || parsed.User == nil {
+		return u
+	}
+	return parsed.Redacted()
+}
+
+// Returns an error if u isn't an absolute http or https url.
+func ValidateURL(u string) error {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return err
+	}
+	if parsed.Scheme != "https" && parsed.Scheme != "http" {
+		return errors.New("url must use http or https")
+	}
+	if parsed.Host == "" {
+		return errors.New("url is missing the host")
+	}
+	return nil
+}
+
+// Parses the urls listed in a manifest, one per line, newest first.
+// Empty lines and lines starting with # are skipped, relative urls are resolved against the manifest url.
+func parseManifest(manifestURL string, r io.Reader) ([]string, error) {
+	base, err := url.Parse(manifestURL)
+	if err != nil {
+		return nil, err
+	}
+
+	urls := make([]string, 0)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		ref, err := url.Parse(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid url %q in manifest: %w", line, err)
+		}
+		u := base.ResolveReference(ref).String()
+		if err := ValidateURL(u); err != nil {
+			return nil, fmt.Errorf("invalid url %q in manifest: %w", line, err)
+		}
+		urls = append(urls, u)
+	}
+	return urls, scanner.Err()
+}
@@ -12,9 +12,15 @@ import (
 )
 
 const (
-	CL_DEFAULT_ID = ChangelogID("cl_config")
-	GH_DEFAULT_ID = GHSourceID("gh_config")
-	WS_DEFAULT_ID = WorkspaceID("ws_config")
+	CL_DEFAULT_ID  = ChangelogID("cl_config")
+	GH_DEFAULT_ID  = GHSourceID("gh_config")
+	GL_DEFAULT_ID  = GLSourceID("gl_config")
+	FJ_DEFAULT_ID  = FJSourceID("fj_config")
+	LC_DEFAULT_ID  = LocalSourceID("lc_config")
+	GIT_DEFAULT_ID = GitSourceID("git_config")
+	URL_DEFAULT_ID = URLSourceID("url_config")
+	S3_DEFAULT_ID  = S3SourceID("s3_config")
+	WS_DEFAULT_ID  = WorkspaceID("ws_config")
 )
 
 // Create a new store implementation, backed by the config file
@@ -84,30 +90,28 @@ func (s *configStore) GetChangelog(ctx context.Context, wID WorkspaceID, cID Cha
 	}
 
 	// parse local source from config
-	if s.cfg.Local != nil {
-		cl.LocalSource = null.NewValue(LocalSource{
-			Path: s.cfg.Local.FilesPath,
-		}, true)
+	if lc, err := s.GetLocalSource(ctx, wID, LC_DEFAULT_ID); err == nil {
+		cl.LocalSource = null.NewValue(lc, true)
 	}
 
-	if s.cfg.Gitlab != nil {
-		cl.GLSource = null.NewValue(GLSource{
-			BaseURL: s.cfg.Gitlab.BaseURL,
-			Project: s.cfg.Gitlab.Project,
-			Path:    s.cfg.Gitlab.Path,
-			Ref:     s.cfg.Gitlab.Ref,
-			Token:   s.cfg.Gitlab.Token,
-		}, true)
+	if gl, err := s.GetGLSource(ctx, wID, GL_DEFAULT_ID); err == nil {
+		cl.GLSource = null.NewValue(gl, true)
 	}
 
-	if s.cfg.Forgejo != nil {
-		cl.FJSource = null.NewValue(FJSource{
-			BaseURL: s.cfg.Forgejo.BaseURL,
-			Project: s.cfg.Forgejo.Project,
-			Path:    s.cfg.Forgejo.Path,
-			Ref:     s.cfg.Forgejo.Ref,
-			Token:   s.cfg.Forgejo.Token,
-		}, true)
+	if fj, err := s.GetFJSource(ctx, wID, FJ_DEFAULT_ID); err == nil {
+		cl.FJSource = null.NewValue(fj, true)
+	}
+
+	if git, err := s.GetGitSource(ctx, wID, GIT_DEFAULT_ID); err == nil {
+		cl.GitSource = null.NewValue(git, true)
+	}
+
+	if u, err := s.GetURLSource(ctx, wID, URL_DEFAULT_ID); err == nil {
+		cl.URLSource = null.NewValue(u, true)
+	}
+
+	if s3, err := s.GetS3Source(ctx, wID, S3_DEFAULT_ID); err == nil {
+		cl.S3Source = null.NewValue(s3, true)
 	}
 
 	// parse github source from config
@@ -131,6 +135,10 @@ func (s *configStore) ListChangelogs(ctx context.Context, wID WorkspaceID) ([]Ch
 	return []Changelog{cl}, nil
 }
 
+func (s *configStore) ListAllChangelogs(ctx context.Context) ([]Changelog, error) {
+	return s.ListChangelogs(ctx, WS_DEFAULT_ID)
+}
+
 func (s *configStore) DeleteChangelog(context.Context, WorkspaceID, ChangelogID) error {
 	return errs.NewError(errs.ErrBadRequest, errors.New("changelog deletion not allowed in local config mode"))
 }
@@ -139,6 +147,34 @@ func (s *configStore) SetChangelogGHSource(context.Context, WorkspaceID, Changel
 	return errs.NewError(errs.ErrBadRequest, errors.New("changeing changelog source not allowed in local config mode"))
 }
 
+func (s *configStore) SetChangelogGLSource(context.Context, WorkspaceID, ChangelogID, GLSourceID) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("changeing changelog source not allowed in local config mode"))
+}
+
+func (s *configStore) SetChangelogFJSource(context.Context, WorkspaceID, ChangelogID, FJSourceID) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("changeing changelog source not allowed in local config mode"))
+}
+
+func (s *configStore) SetChangelogLocalSource(context.Context, WorkspaceID, ChangelogID, LocalSourceID) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("changeing changelog source not allowed in local config mode"))
+}
+
+func (s *configStore) SetChangelogGitSource(context.Context, WorkspaceID, ChangelogID, GitSourceID) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("changeing changelog source not allowed in local config mode"))
+}
+
+func (s *configStore) SetChangelogURLSource(context.Context, WorkspaceID, ChangelogID, URLSourceID) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("changeing changelog source not allowed in local config mode"))
+}
+
+func (s *configStore) SetChangelogS3Source(context.Context, WorkspaceID, ChangelogID, S3SourceID) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("changeing changelog source not allowed in local config mode"))
+}
+
+func (s *configStore) SetChangelogSources(context.Context, WorkspaceID, ChangelogID, []string) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("changeing changelog source not allowed in local config mode"))
+}
+
 func (s *configStore) DeleteChangelogSource(context.Context, WorkspaceID, ChangelogID) error {
 	return errs.NewError(errs.ErrBadRequest, errors.New("changelog source deletion not allowed in local config mode"))
 }
@@ -168,6 +204,11 @@ func (s *configStore) GetGHSource(context.Context, WorkspaceID, GHSourceID) (GHS
 		Owner:       s.cfg.Github.Owner,
 		Repo:        s.cfg.Github.Repo,
 		Path:        s.cfg.Github.Path,
+		Ref:         s.cfg.Github.Ref,
+		BaseURL:     s.cfg.Github.BaseURL,
+		Releases:    s.cfg.Github.Releases,
+		Include:     s.cfg.Github.Include,
+		Exclude:     s.cfg.Github.Exclude,
 		WorkspaceID: WS_DEFAULT_ID,
 	}
 	if s.cfg.Github.Auth != nil {
@@ -176,6 +217,195 @@ func (s *configStore) GetGHSource(context.Context, WorkspaceID, GHSourceID) (GHS
 	return g, nil
 }
 
+func (s *configStore) CreateGLSource(context.Context, GLSource) (GLSource, error) {
+	return GLSource{}, errs.NewError(errs.ErrBadRequest, errors.New("gitlab source creation not allowed in local config mode"))
+}
+
+func (s *configStore) DeleteGLSource(context.Context, WorkspaceID, GLSourceID) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("gitlab source deletion not allowed in local config mode"))
+}
+
+func (s *configStore) ListGLSources(ctx context.Context, wID WorkspaceID) ([]GLSource, error) {
+	g, err := s.GetGLSource(ctx, wID, GL_DEFAULT_ID)
+	if err != nil {
+		return []GLSource{}, err
+	}
+	return []GLSource{g}, nil
+}
+
+func (s *configStore) GetGLSource(context.Context, WorkspaceID, GLSourceID) (GLSource, error) {
+	if s.cfg.Gitlab == nil {
+		return GLSource{}, errs.NewError(errs.ErrNotFound, errors.New("gitlab source not found"))
+	}
+	return GLSource{
+		ID:          GL_DEFAULT_ID,
+		WorkspaceID: WS_DEFAULT_ID,
+		BaseURL:     s.cfg.Gitlab.BaseURL,
+		Project:     s.cfg.Gitlab.Project,
+		Path:        s.cfg.Gitlab.Path,
+		Ref:         s.cfg.Gitlab.Ref,
+		Token:       s.cfg.Gitlab.Token,
+		Releases:    s.cfg.Gitlab.Releases,
+		Include:     s.cfg.Gitlab.Include,
+		Exclude:     s.cfg.Gitlab.Exclude,
+	}, nil
+}
+
+func (s *configStore) CreateFJSource(context.Context, FJSource) (FJSource, error) {
+	return FJSource{}, errs.NewError(errs.ErrBadRequest, errors.New("forgejo source creation not allowed in local config mode"))
+}
+
+func (s *configStore) DeleteFJSource(context.Context, WorkspaceID, FJSourceID) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("forgejo source deletion not allowed in local config mode"))
+}
+
+func (s *configStore) ListFJSources(ctx context.Context, wID WorkspaceID) ([]FJSource, error) {
+	f, err := s.GetFJSource(ctx, wID, FJ_DEFAULT_ID)
+	if err != nil {
+		return []FJSource{}, err
+	}
+	return []FJSource{f}, nil
+}
+
+func (s *configStore) GetFJSource(context.Context, WorkspaceID, FJSourceID) (FJSource, error) {
+	if s.cfg.Forgejo == nil {
+		return FJSource{}, errs.NewError(errs.ErrNotFound, errors.New("forgejo source not found"))
+	}
+	return FJSource{
+		ID:          FJ_DEFAULT_ID,
+		WorkspaceID: WS_DEFAULT_ID,
+		BaseURL:     s.cfg.Forgejo.BaseURL,
+		Project:     s.cfg.Forgejo.Project,
+		Path:        s.cfg.Forgejo.Path,
+		Ref:         s.cfg.Forgejo.Ref,
+		Token:       s.cfg.Forgejo.Token,
+		Releases:    s.cfg.Forgejo.Releases,
+		Include:     s.cfg.Forgejo.Include,
+		Exclude:     s.cfg.Forgejo.Exclude,
+	}, nil
+}
+
+func (s *configStore) CreateLocalSource(context.Context, LocalSource) (LocalSource, error) {
+	return LocalSource{}, errs.NewError(errs.ErrBadRequest, errors.New("local source creation not allowed in local config mode"))
+}
+
+func (s *configStore) DeleteLocalSource(context.Context, WorkspaceID, LocalSourceID) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("local source deletion not allowed in local config mode"))
+}
+
+func (s *configStore) ListLocalSources(ctx context.Context, wID WorkspaceID) ([]LocalSource, error) {
+	l, err := s.GetLocalSource(ctx, wID, LC_DEFAULT_ID)
+	if err != nil {
+		return []LocalSource{}, err
+	}
+	return []LocalSource{l}, nil
+}
+
+func (s *configStore) GetLocalSource(context.Context, WorkspaceID, LocalSourceID) (LocalSource, error) {
+	if s.cfg.Local == nil {
+		return LocalSource{}, errs.NewError(errs.ErrNotFound, errors.New("local source not found"))
+	}
+	return LocalSource{
+		ID:          LC_DEFAULT_ID,
+		WorkspaceID: WS_DEFAULT_ID,
+		Path:        s.cfg.Local.FilesPath,
+		Include:     s.cfg.Local.Include,
+		Exclude:     s.cfg.Local.Exclude,
+	}, nil
+}
+
+func (s *configStore) CreateGitSource(context.Context, GitSource) (GitSource, error) {
+	return GitSource{}, errs.NewError(errs.ErrBadRequest, errors.New("git source creation not allowed in local config mode"))
+}
+
+func (s *configStore) DeleteGitSource(context.Context, WorkspaceID, GitSourceID) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("git source deletion not allowed in local config mode"))
+}
+
+func (s *configStore) ListGitSources(ctx context.Context, wID WorkspaceID) ([]GitSource, error) {
+	g, err := s.GetGitSource(ctx, wID, GIT_DEFAULT_ID)
+	if err != nil {
+		return []GitSource{}, err
+	}
+	return []GitSource{g}, nil
+}
+
+func (s *configStore) GetGitSource(context.Context, WorkspaceID, GitSourceID) (GitSource, error) {
+	if s.cfg.Git == nil || s.cfg.Git.URL == "" {
+		return GitSource{}, errs.NewError(errs.ErrNotFound, errors.New("git source not found"))
+	}
+	return GitSource{
+		ID:                  GIT_DEFAULT_ID,
+		WorkspaceID:         WS_DEFAULT_ID,
+		URL:                 s.cfg.Git.URL,
+		Ref:                 s.cfg.Git.Ref,
+		Path:                s.cfg.Git.Path,
+		ConventionalCommits: s.cfg.Git.ConventionalCommits,
+		Include:             s.cfg.Git.Include,
+		Exclude:             s.cfg.Git.Exclude,
+	}, nil
+}
+
+func (s *configStore) CreateURLSource(context.Context, URLSource) (URLSource, error) {
+	return URLSource{}, errs.NewError(errs.ErrBadRequest, errors.New("url source creation not allowed in local config mode"))
+}
+
+func (s *configStore) DeleteURLSource(context.Context, WorkspaceID, URLSourceID) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("url source deletion not allowed in local config mode"))
+}
+
+func (s *configStore) ListURLSources(ctx context.Context, wID WorkspaceID) ([]URLSource, error) {
+	u, err := s.GetURLSource(ctx, wID, URL_DEFAULT_ID)
+	if err != nil {
+		return []URLSource{}, err
+	}
+	return []URLSource{u}, nil
+}
+
+func (s *configStore) GetURLSource(context.Context, WorkspaceID, URLSourceID) (URLSource, error) {
+	if s.cfg.URL == nil || s.cfg.URL.URL == "" {
+		return URLSource{}, errs.NewError(errs.ErrNotFound, errors.New("url source not found"))
+	}
+	return URLSource{
+		ID:          URL_DEFAULT_ID,
+		WorkspaceID: WS_DEFAULT_ID,
+		URL:         s.cfg.URL.URL,
+		Manifest:    s.cfg.URL.Manifest,
+	}, nil
+}
+
+func (s *configStore) CreateS3Source(context.Context, S3Source) (S3Source, error) {
+	return S3Source{}, errs.NewError(errs.ErrBadRequest, errors.New("s3 source creation not allowed in local config mode"))
+}
+
+func (s *configStore) DeleteS3Source(context.Context, WorkspaceID, S3SourceID) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("s3 source deletion not allowed in local config mode"))
+}
+
+func (s *configStore) ListS3Sources(ctx context.Context, wID WorkspaceID) ([]S3Source, error) {
+	s3, err := s.GetS3Source(ctx, wID, S3_DEFAULT_ID)
+	if err != nil {
+		return []S3Source{}, err
+	}
+	return []S3Source{s3}, nil
+}
+
+func (s *configStore) GetS3Source(context.Context, WorkspaceID, S3SourceID) (S3Source, error) {
+	if s.cfg.S3 == nil || s.cfg.S3.Bucket == "" {
+		return S3Source{}, errs.NewError(errs.ErrNotFound, errors.New("s3 source not found"))
+	}
+	return S3Source{
+		ID:              S3_DEFAULT_ID,
+		WorkspaceID:     WS_DEFAULT_ID,
+		Endpoint:        s.cfg.S3.Endpoint,
+		Region:          s.cfg.S3.Region,
+		Bucket:          s.cfg.S3.Bucket,
+		Prefix:          s.cfg.S3.Prefix,
+		AccessKeyID:     s.cfg.S3.AccessKeyID,
+		SecretAccessKey: s.cfg.S3.SecretAccessKey,
+	}, nil
+}
+
 func (s *configStore) SaveWorkspace(context.Context, Workspace) (Workspace, error) {
 	return Workspace{}, errs.NewError(errs.ErrBadRequest, errors.New("workspace creation not allowed in local config mode"))
 }
@@ -195,3 +425,95 @@ func (s *configStore) GetWorkspaceIDByToken(ctx context.Context, token string) (
 func (s *configStore) ListWorkspacesChangelogCount(ctx context.Context) ([]WorkspaceChangelogCount, error) {
 	return []WorkspaceChangelogCount{}, errs.NewError(errs.ErrBadRequest, errors.New("list workspaces and changelog count not supported in local config mode"))
 }
+
+func (s *configStore) CreateSubscriber(context.Context, Subscriber) (Subscriber, error) {
+	return Subscriber{}, errs.NewError(errs.ErrBadRequest, errors.New("subscriptions not allowed in local config mode"))
+}
+
+func (s *configStore) GetSubscriber(context.Context, SubscriberID) (Subscriber, error) {
+	return Subscriber{}, errs.NewError(errs.ErrNotFound, errors.New("subscriber not found"))
+}
+
+func (s *configStore) GetSubscriberByEmail(context.Context, WorkspaceID, ChangelogID, string) (Subscriber, error) {
+	return Subscriber{}, errs.NewError(errs.ErrNotFound, errors.New("subscriber not found"))
+}
+
+func (s *configStore) ConfirmSubscriber(context.Context, string) (Subscriber, error) {
+	return Subscriber{}, errs.NewError(errs.ErrBadRequest, errors.New("subscriptions not allowed in local config mode"))
+}
+
+func (s *configStore) ListConfirmedSubscribers(context.Context, WorkspaceID, ChangelogID) ([]Subscriber, error) {
+	return []Subscriber{}, nil
+}
+
+func (s *configStore) DeleteSubscriber(context.Context, SubscriberID) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("subscriptions not allowed in local config mode"))
+}
+
+func (s *configStore) ListDispatchedReleaseNotes(context.Context, WorkspaceID, ChangelogID) ([]string, error) {
+	return []string{}, nil
+}
+
+func (s *configStore) SaveDispatchedReleaseNotes(context.Context, WorkspaceID, ChangelogID, []string) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("subscriptions not allowed in local config mode"))
+}
+
+func (s *configStore) SaveGHInstallation(context.Context, GHInstallation) (GHInstallation, error) {
+	return GHInstallation{}, errs.NewError(errs.ErrBadRequest, errors.New("github app installations not allowed in local config mode"))
+}
+
+func (s *configStore) GetGHInstallation(context.Context, WorkspaceID, int64) (GHInstallation, error) {
+	return GHInstallation{}, errs.NewError(errs.ErrNotFound, errors.New("github installation not found"))
+}
+
+func (s *configStore) ListGHInstallations(context.Context, WorkspaceID) ([]GHInstallation, error) {
+	return []GHInstallation{}, nil
+}
+
+func (s *configStore) DeleteGHInstallation(context.Context, int64) error {
+	return nil
+}
+
+func (s *configStore) CreateWebhook(context.Context, Webhook) (Webhook, error) {
+	return Webhook{}, errs.NewError(errs.ErrBadRequest, errors.New("webhooks not allowed in local config mode"))
+}
+
+func (s *configStore) GetWebhook(context.Context, WorkspaceID, WebhookID) (Webhook, error) {
+	return Webhook{}, errs.NewError(errs.ErrNotFound, errors.New("webhook not found"))
+}
+
+func (s *configStore) ListWebhooks(context.Context, WorkspaceID, ChangelogID) ([]Webhook, error) {
+	return []Webhook{}, nil
+}
+
+func (s *configStore) ListAllWebhooks(context.Context) ([]Webhook, error) {
+	return []Webhook{}, nil
+}
+
+func (s *configStore) DeleteWebhook(context.Context, WorkspaceID, WebhookID) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("webhooks not allowed in local config mode"))
+}
+
+func (s *configStore) CreateWebhookDelivery(context.Context, WebhookDelivery) (WebhookDelivery, error) {
+	return WebhookDelivery{}, errs.NewError(errs.ErrBadRequest, errors.New("webhooks not allowed in local config mode"))
+}
+
+func (s *configStore) ListWebhookDeliveries(context.Context, WebhookID, int) ([]WebhookDelivery, error) {
+	return []WebhookDelivery{}, nil
+}
+
+func (s *configStore) ListDeliveredReleaseNotes(context.Context, WebhookID) ([]string, error) {
+	return []string{}, nil
+}
+
+func (s *configStore) ListWebhookSources(context.Context, WebhookID) ([]string, error) {
+	return []string{}, nil
+}
+
+func (s *configStore) SaveWebhookSource(context.Context, WebhookID, string, []string) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("webhooks not allowed in local config mode"))
+}
+
+func (s *configStore) ListSkippedReleaseNotes(context.Context, WebhookID) ([]string, error) {
+	return []string{}, nil
+}
@@ -2,6 +2,7 @@ package store
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/jonashiltl/openchangelog/internal/errs"
@@ -12,6 +13,15 @@ const (
 	wid_prefix   = "ws"
 	cid_prefix   = "cl"
 	ghid_prefix  = "gh"
+	glid_prefix  = "gl"
+	fjid_prefix  = "fj"
+	lcid_prefix  = "lc"
+	gitid_prefix = "git"
+	urlid_prefix = "url"
+	s3id_prefix  = "s3"
+	sid_prefix   = "sub"
+	whid_prefix  = "wh"
+	whdid_prefix = "whd"
 	id_separator = "_"
 )
 
@@ -109,3 +119,229 @@ func IsGHID(id string) bool {
 func (i GHSourceID) String() string {
 	return string(i)
 }
+
+type GLSourceID string
+
+func NewGLID() GLSourceID {
+	return GLSourceID(glid_prefix + id_separator + xid.New().String())
+}
+
+func ParseGLID(id string) (GLSourceID, error) {
+	if id == GL_DEFAULT_ID.String() {
+		return GL_DEFAULT_ID, nil
+	}
+	if err := parseSourceID(id, glid_prefix, "gitlab"); err != nil {
+		return "", err
+	}
+	return GLSourceID(id), nil
+}
+
+func IsGLID(id string) bool {
+	return strings.HasPrefix(id, glid_prefix+id_separator)
+}
+
+func (i GLSourceID) String() string {
+	return string(i)
+}
+
+type FJSourceID string
+
+func NewFJID() FJSourceID {
+	return FJSourceID(fjid_prefix + id_separator + xid.New().String())
+}
+
+func ParseFJID(id string) (FJSourceID, error) {
+	if id == FJ_DEFAULT_ID.String() {
+		return FJ_DEFAULT_ID, nil
+	}
+	if err := parseSourceID(id, fjid_prefix, "forgejo"); err != nil {
+		return "", err
+	}
+	return FJSourceID(id), nil
+}
+
+func IsFJID(id string) bool {
+	return strings.HasPrefix(id, fjid_prefix+id_separator)
+}
+
+func (i FJSourceID) String() string {
+	return string(i)
+}
+
+type LocalSourceID string
+
+func NewLocalID() LocalSourceID {
+	return LocalSourceID(lcid_prefix + id_separator + xid.New().String())
+}
+
+func ParseLocalID(id string) (LocalSourceID, error) {
+	if id == LC_DEFAULT_ID.String() {
+		return LC_DEFAULT_ID, nil
+	}
+	if err := parseSourceID(id, lcid_prefix, "local"); err != nil {
+		return "", err
+	}
+	return LocalSourceID(id), nil
+}
+
+func IsLocalID(id string) bool {
+	return strings.HasPrefix(id, lcid_prefix+id_separator)
+}
+
+func (i LocalSourceID) String() string {
+	return string(i)
+}
+
+type SubscriberID string
+
+func NewSubscriberID() SubscriberID {
+	return SubscriberID(sid_prefix + id_separator + xid.New().String())
+}
+
+var errSubscriberFormat = errs.NewError(errs.ErrBadRequest, errors.New("wrong subscriber id format"))
+
+func ParseSubscriberID(id string) (SubscriberID, error) {
+	parts := strings.Split(id, id_separator)
+	if len(parts) != 2 {
+		return "", errSubscriberFormat
+	}
+	if parts[0] != sid_prefix {
+		return "", errs.NewError(errs.ErrBadRequest, errors.New("invalid subscriber id prefix"))
+	}
+	_, err := xid.FromString(parts[1])
+	if err != nil {
+		return "", errSubscriberFormat
+	}
+	return SubscriberID(id), nil
+}
+
+func (i SubscriberID) String() string {
+	return string(i)
+}
+
+type GitSourceID string
+
+func NewGitID() GitSourceID {
+	return GitSourceID(gitid_prefix + id_separator + xid.New().String())
+}
+
+func ParseGitID(id string) (GitSourceID, error) {
+	if id == GIT_DEFAULT_ID.String() {
+		return GIT_DEFAULT_ID, nil
+	}
+	if err := parseSourceID(id, gitid_prefix, "git"); err != nil {
+		return "", err
+	}
+	return GitSourceID(id), nil
+}
+
+func IsGitID(id string) bool {
+	return strings.HasPrefix(id, gitid_prefix+id_separator)
+}
+
+func (i GitSourceID) String() string {
+	return string(i)
+}
+
+type URLSourceID string
+
+func NewURLID() URLSourceID {
+	return URLSourceID(urlid_prefix + id_separator + xid.New().String())
+}
+
+func ParseURLID(id string) (URLSourceID, error) {
+	if id == URL_DEFAULT_ID.String() {
+		return URL_DEFAULT_ID, nil
+	}
+	if err := parseSourceID(id, urlid_prefix, "url"); err != nil {
+		return "", err
+	}
+	return URLSourceID(id), nil
+}
+
+func IsURLID(id string) bool {
+	return strings.HasPrefix(id, urlid_prefix+id_separator)
+}
+
+func (i URLSourceID) String() string {
+	return string(i)
+}
+
+type S3SourceID string
+
+func NewS3ID() S3SourceID {
+	return S3SourceID(s3id_prefix + id_separator + xid.New().String())
+}
+
+func ParseS3ID(id string) (S3SourceID, error) {
+	if id == S3_DEFAULT_ID.String() {
+		return S3_DEFAULT_ID, nil
+	}
+	if err := parseSourceID(id, s3id_prefix, "s3"); err != nil {
+		return "", err
+	}
+	return S3SourceID(id), nil
+}
+
+func IsS3ID(id string) bool {
+	return strings.HasPrefix(id, s3id_prefix+id_separator)
+}
+
+func (i S3SourceID) String() string {
+	return string(i)
+}
+
+// Validates that id has the format <prefix>_<xid>.
+// kind is the humanized source type used in error messages.
+func parseSourceID(id, prefix, kind string) error {
+	errFormat := errs.NewError(errs.ErrBadRequest, fmt.Errorf("wrong %s source id format", kind))
+	parts := strings.Split(id, id_separator)
+	if len(parts) != 2 {
+		return errFormat
+	}
+	if parts[0] != prefix {
+		return errs.NewError(errs.ErrBadRequest, fmt.Errorf("invalid %s source id prefix", prefix))
+	}
+	_, err := xid.FromString(parts[1])
+	if err != nil {
+		return errFormat
+	}
+	return nil
+}
+
+type WebhookID string
+
+func NewWebhookID() WebhookID {
+	return WebhookID(whid_prefix + id_separator + xid.New().String())
+}
+
+var errWebhookFormat = errs.NewError(errs.ErrBadRequest, errors.New("wrong webhook id format"))
+
+func ParseWebhookID(id string) (WebhookID, error) {
+	parts := strings.Split(id, id_separator)
+	if len(parts) != 2 {
+		return "", errWebhookFormat
+	}
+	if parts[0] != whid_prefix {
+		return "", errs.NewError(errs.ErrBadRequest, errors.New("invalid webhook id prefix"))
+	}
+	_, err := xid.FromString(parts[1])
+	if err != nil {
+		return "", errWebhookFormat
+	}
+	return WebhookID(id), nil
+}
+
+func (i WebhookID) String() string {
+	return string(i)
+}
+
+type WebhookDeliveryID string
+
+func NewWebhookDeliveryID() WebhookDeliveryID {
+	return WebhookDeliveryID(whdid_prefix + id_separator + xid.New().String())
+}
+
+func (i WebhookDeliveryID) String() string {
+	return string(i)
+}
@@ -0,0 +1,54 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io/fs"
+
+	"github.com/pressly/goose/v3"
+)
+
+// A Migrator applies the embedded goose migrations to a sqlite database.
+// Applied versions are recorded in the goose_db_version table, which is compatible with the goose cli.
+type Migrator struct {
+	p *goose.Provider
+}
+
+func NewMigrator(conn string, migrations fs.FS) (*Migrator, error) {
+	db, err := sql.Open("sqlite3", conn)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := goose.NewProvider(goose.DialectSQLite3, db, migrations)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Migrator{p: p}, nil
+}
+
+// Applies all pending migrations, returns the applied ones.
+func (m *Migrator) Up(ctx context.Context) ([]*goose.MigrationResult, error) {
+	return m.p.Up(ctx)
+}
+
+// Rolls back the most recently applied migration.
+// Returns nil if there is no migration left to roll back.
+func (m *Migrator) Down(ctx context.Context) (*goose.MigrationResult, error) {
+	res, err := m.p.Down(ctx)
+	if errors.Is(err, goose.ErrNoNextVersion) {
+		return nil, nil
+	}
+	return res, err
+}
+
+// Returns the state of every known migration, ordered by version.
+func (m *Migrator) Status(ctx context.Context) ([]*goose.MigrationStatus, error) {
+	return m.p.Status(ctx)
+}
+
+func (m *Migrator) Close() error {
+	return m.p.Close()
+}
@@ -0,0 +1,76 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/jonashiltl/openchangelog/migrations"
+	"github.com/pressly/goose/v3"
+)
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+	conn := fmt.Sprintf("file:%s?_foreign_keys=on", filepath.Join(t.TempDir(), "test.db"))
+
+	m, err := NewMigrator(conn, migrations.FS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	res, err := m.Up(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) == 0 {
+		t.Fatal("expected migrations to be applied")
+	}
+
+	// applying again must be a noop
+	res, err = m.Up(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 0 {
+		t.Errorf("expected no migrations to be applied, got %d", len(res))
+	}
+
+	status, err := m.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range status {
+		if s.State != goose.StateApplied {
+			t.Errorf("expected %s to be applied, got %s", s.Source.Path, s.State)
+		}
+	}
+
+	down, err := m.Down(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := status[len(status)-1]
+	if down.Source.Version != last.Source.Version {
+		t.Errorf("expected version %d to be rolled back, got %d", last.Source.Version, down.Source.Version)
+	}
+
+	res, err = m.Up(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 1 {
+		t.Errorf("expected 1 migration to be applied, got %d", len(res))
+	}
+
+	// the store must work on the migrated schema
+	s, err := NewSQLiteStore(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.SaveWorkspace(ctx, Workspace{ID: NewWID(), Name: "test", Token: NewToken()})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -33,22 +33,183 @@ type changelog struct {
 	HideRssIcon   int64
 }
 
+type changelogFjSource struct {
+	ID              apitypes.NullString
+	WorkspaceID     apitypes.NullString
+	BaseUrl         apitypes.NullString
+	Project         apitypes.NullString
+	Path            apitypes.NullString
+	Ref             apitypes.NullString
+	Token           apitypes.NullString
+	Releases        sql.NullInt64
+	IncludePatterns apitypes.NullString
+	ExcludePatterns apitypes.NullString
+}
+
+type changelogGitSource struct {
+	ID                  apitypes.NullString
+	WorkspaceID         apitypes.NullString
+	Url                 apitypes.NullString
+	Ref                 apitypes.NullString
+	Path                apitypes.NullString
+	ConventionalCommits sql.NullInt64
+	IncludePatterns     apitypes.NullString
+	ExcludePatterns     apitypes.NullString
+}
+
+type changelogGlSource struct {
+	ID              apitypes.NullString
+	WorkspaceID     apitypes.NullString
+	BaseUrl         apitypes.NullString
+	Project         apitypes.NullString
+	Path            apitypes.NullString
+	Ref             apitypes.NullString
+	Token           apitypes.NullString
+	Releases        sql.NullInt64
+	IncludePatterns apitypes.NullString
+	ExcludePatterns apitypes.NullString
+}
+
+type changelogLocalSource struct {
+	ID              apitypes.NullString
+	WorkspaceID     apitypes.NullString
+	Path            apitypes.NullString
+	IncludePatterns apitypes.NullString
+	ExcludePatterns apitypes.NullString
+}
+
+type changelogS3Source struct {
+	ID              apitypes.NullString
+	WorkspaceID     apitypes.NullString
+	Endpoint        apitypes.NullString
+	Region          apitypes.NullString
+	Bucket          apitypes.NullString
+	Prefix          apitypes.NullString
+	AccessKeyID     apitypes.NullString
+	SecretAccessKey apitypes.NullString
+}
+
 type changelogSource struct {
-	ID             apitypes.NullString
-	WorkspaceID    apitypes.NullString
-	Owner          apitypes.NullString
-	Repo           apitypes.NullString
-	Path           apitypes.NullString
-	InstallationID sql.NullInt64
+	ID              apitypes.NullString
+	WorkspaceID     apitypes.NullString
+	Owner           apitypes.NullString
+	Repo            apitypes.NullString
+	Path            apitypes.NullString
+	InstallationID  sql.NullInt64
+	Releases        sql.NullInt64
+	IncludePatterns apitypes.NullString
+	ExcludePatterns apitypes.NullString
+	BaseUrl         apitypes.NullString
+	Ref             apitypes.NullString
 }
 
-type ghSource struct {
-	ID             string
+type changelogUrlSource struct {
+	ID          apitypes.NullString
+	WorkspaceID apitypes.NullString
+	Url         apitypes.NullString
+	Manifest    sql.NullInt64
+}
+
+type combinedSource struct {
+	WorkspaceID string
+	ChangelogID string
+	SourceID    string
+	Position    int64
+}
+
+type dispatchedReleaseNote struct {
+	WorkspaceID   string
+	ChangelogID   string
+	ReleaseNoteID string
+	DispatchedAt  int64
+}
+
+type fjSource struct {
+	ID              string
+	WorkspaceID     string
+	BaseUrl         string
+	Project         string
+	Path            string
+	Ref             string
+	Token           string
+	Releases        int64
+	IncludePatterns string
+	ExcludePatterns string
+}
+
+type ghInstallation struct {
 	WorkspaceID    string
-	Owner          string
-	Repo           string
-	Path           string
 	InstallationID int64
+	Account        string
+	CreatedAt      int64
+}
+
+type ghSource struct {
+	ID              string
+	WorkspaceID     string
+	Owner           string
+	Repo            string
+	Path            string
+	InstallationID  int64
+	Releases        int64
+	IncludePatterns string
+	ExcludePatterns string
+	BaseUrl         string
+	Ref             string
+}
+
+type gitSource struct {
+	ID                  string
+	WorkspaceID         string
+	Url                 string
+	Ref                 string
+	Path                string
+	ConventionalCommits int64
+	IncludePatterns     string
+	ExcludePatterns     string
+}
+
+type glSource struct {
+	ID              string
+	WorkspaceID     string
+	BaseUrl         string
+	Project         string
+	Path            string
+	Ref             string
+	Token           string
+	Releases        int64
+	IncludePatterns string
+	ExcludePatterns string
+}
+
+type localSource struct {
+	ID              string
+	WorkspaceID     string
+	Path            string
+	IncludePatterns string
+	ExcludePatterns string
+}
+
+type s3Source struct {
+	ID              string
+	WorkspaceID     string
+	Endpoint        string
+	Region          string
+	Bucket          string
+	Prefix          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+type subscriber struct {
+	ID           string
+	WorkspaceID  string
+	ChangelogID  string
+	Email        string
+	ChangelogUrl string
+	ConfirmToken apitypes.NullString
+	ConfirmedAt  sql.NullInt64
+	CreatedAt    int64
 }
 
 type token struct {
@@ -56,6 +217,43 @@ type token struct {
 	WorkspaceID string
 }
 
+type urlSource struct {
+	ID          string
+	WorkspaceID string
+	Url         string
+	Manifest    int64
+}
+
+type webhook struct {
+	ID          string
+	WorkspaceID string
+	ChangelogID string
+	Url         string
+	Secret      string
+	CreatedAt   int64
+}
+
+type webhookDelivery struct {
+	ID            string
+	WebhookID     string
+	ReleaseNoteID string
+	Event         string
+	Attempt       int64
+	StatusCode    int64
+	Error         apitypes.NullString
+	CreatedAt     int64
+}
+
+type webhookSkippedReleaseNote struct {
+	WebhookID     string
+	ReleaseNoteID string
+}
+
+type webhookSource struct {
+	WebhookID string
+	SourceID  string
+}
+
 type workspace struct {
 	ID   string
 	Name string
@@ -62,23 +62,52 @@ DELETE FROM changelogs
 WHERE workspace_id = ? AND id = ?;
 
 -- name: getChangelog :one
-SELECT sqlc.embed(c), sqlc.embed(cs)
+SELECT sqlc.embed(c), sqlc.embed(cs), sqlc.embed(gls), sqlc.embed(fjs), sqlc.embed(lcs), sqlc.embed(gits), sqlc.embed(urls), sqlc.embed(s3s)
 FROM changelogs c
 LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
+LEFT JOIN changelog_gl_source gls ON c.workspace_id = gls.workspace_id AND c.source_id = gls.id
+LEFT JOIN changelog_fj_source fjs ON c.workspace_id = fjs.workspace_id AND c.source_id = fjs.id
+LEFT JOIN changelog_local_source lcs ON c.workspace_id = lcs.workspace_id AND c.source_id = lcs.id
+LEFT JOIN changelog_git_source gits ON c.workspace_id = gits.workspace_id AND c.source_id = gits.id
+LEFT JOIN changelog_url_source urls ON c.workspace_id = urls.workspace_id AND c.source_id = urls.id
+LEFT JOIN changelog_s3_source s3s ON c.workspace_id = s3s.workspace_id AND c.source_id = s3s.id
 WHERE c.workspace_id = ? AND c.id = ?;
 
 -- name: getChangelogByDomainOrSubdomain :one
-SELECT sqlc.embed(c), sqlc.embed(cs)
+SELECT sqlc.embed(c), sqlc.embed(cs), sqlc.embed(gls), sqlc.embed(fjs), sqlc.embed(lcs), sqlc.embed(gits), sqlc.embed(urls), sqlc.embed(s3s)
 FROM changelogs c
 LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
+LEFT JOIN changelog_gl_source gls ON c.workspace_id = gls.workspace_id AND c.source_id = gls.id
+LEFT JOIN changelog_fj_source fjs ON c.workspace_id = fjs.workspace_id AND c.source_id = fjs.id
+LEFT JOIN changelog_local_source lcs ON c.workspace_id = lcs.workspace_id AND c.source_id = lcs.id
+LEFT JOIN changelog_git_source gits ON c.workspace_id = gits.workspace_id AND c.source_id = gits.id
+LEFT JOIN changelog_url_source urls ON c.workspace_id = urls.workspace_id AND c.source_id = urls.id
+LEFT JOIN changelog_s3_source s3s ON c.workspace_id = s3s.workspace_id AND c.source_id = s3s.id
 -- first search by domain, if not found by subdomain
 WHERE c.domain = ? OR c.subdomain = ?
 LIMIT 1;
 
+-- name: listAllChangelogs :many
+SELECT sqlc.embed(c), sqlc.embed(cs), sqlc.embed(gls), sqlc.embed(fjs), sqlc.embed(lcs), sqlc.embed(gits), sqlc.embed(urls), sqlc.embed(s3s)
+FROM changelogs c
+LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
+LEFT JOIN changelog_gl_source gls ON c.workspace_id = gls.workspace_id AND c.source_id = gls.id
+LEFT JOIN changelog_fj_source fjs ON c.workspace_id = fjs.workspace_id AND c.source_id = fjs.id
+LEFT JOIN changelog_local_source lcs ON c.workspace_id = lcs.workspace_id AND c.source_id = lcs.id
+LEFT JOIN changelog_git_source gits ON c.workspace_id = gits.workspace_id AND c.source_id = gits.id
+LEFT JOIN changelog_url_source urls ON c.workspace_id = urls.workspace_id AND c.source_id = urls.id
+LEFT JOIN changelog_s3_source s3s ON c.workspace_id = s3s.workspace_id AND c.source_id = s3s.id;
+
 -- name: listChangelogs :many
-SELECT sqlc.embed(c), sqlc.embed(cs)
+SELECT sqlc.embed(c), sqlc.embed(cs), sqlc.embed(gls), sqlc.embed(fjs), sqlc.embed(lcs), sqlc.embed(gits), sqlc.embed(urls), sqlc.embed(s3s)
 FROM changelogs c
 LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
+LEFT JOIN changelog_gl_source gls ON c.workspace_id = gls.workspace_id AND c.source_id = gls.id
+LEFT JOIN changelog_fj_source fjs ON c.workspace_id = fjs.workspace_id AND c.source_id = fjs.id
+LEFT JOIN changelog_local_source lcs ON c.workspace_id = lcs.workspace_id AND c.source_id = lcs.id
+LEFT JOIN changelog_git_source gits ON c.workspace_id = gits.workspace_id AND c.source_id = gits.id
+LEFT JOIN changelog_url_source urls ON c.workspace_id = urls.workspace_id AND c.source_id = urls.id
+LEFT JOIN changelog_s3_source s3s ON c.workspace_id = s3s.workspace_id AND c.source_id = s3s.id
 WHERE c.workspace_id = ?;
 
 -- name: updateChangelog :one
@@ -113,10 +142,38 @@ UPDATE changelogs
 SET source_id = NULL
 WHERE workspace_id = ? AND id = ?;
 
+-- name: deleteCombinedSourceByID :exec
+DELETE FROM combined_sources
+WHERE workspace_id = ? AND source_id = ?;
+
+-- name: detachChangelogSource :exec
+UPDATE changelogs
+SET source_id = (
+    SELECT cs.source_id FROM combined_sources cs
+    WHERE cs.workspace_id = changelogs.workspace_id AND cs.changelog_id = changelogs.id
+    ORDER BY cs.position
+    LIMIT 1
+)
+WHERE workspace_id = sqlc.arg(workspace_id) AND source_id = sqlc.arg(source_id);
+
+-- name: listCombinedSourceIDs :many
+SELECT source_id FROM combined_sources
+WHERE workspace_id = ? AND changelog_id = ?
+ORDER BY position;
+
+-- name: createCombinedSource :exec
+INSERT INTO combined_sources (
+    workspace_id, changelog_id, source_id, position
+) VALUES (?, ?, ?, ?);
+
+-- name: deleteCombinedSources :exec
+DELETE FROM combined_sources
+WHERE workspace_id = ? AND changelog_id = ?;
+
 -- name: createGHSource :one
 INSERT INTO gh_sources (
-    id, workspace_id, owner, repo, path, installation_id
-) VALUES (?, ?, ?, ?, ?, ?)
+    id, workspace_id, owner, repo, path, installation_id, releases, include_patterns, exclude_patterns, base_url, ref
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 RETURNING *;
 
 -- name: listGHSources :many
@@ -131,9 +188,237 @@ WHERE workspace_id = ? AND id = ?;
 DELETE FROM gh_sources
 WHERE workspace_id = ? AND id = ?;
 
+-- name: listGHSourcesByInstallation :many
+SELECT * FROM gh_sources
+WHERE installation_id = ?;
+
+-- name: saveGHInstallation :one
+INSERT INTO gh_installations (
+    workspace_id, installation_id, account
+) VALUES (?, ?, ?)
+ON CONFLICT (workspace_id, installation_id) DO UPDATE SET account = excluded.account
+RETURNING *;
+
+-- name: getGHInstallation :one
+SELECT * FROM gh_installations
+WHERE workspace_id = ? AND installation_id = ?;
+
+-- name: listGHInstallations :many
+SELECT * FROM gh_installations
+WHERE workspace_id = ?
+ORDER BY created_at;
+
+-- name: deleteGHInstallation :exec
+DELETE FROM gh_installations
+WHERE installation_id = ?;
+
+-- name: createGLSource :one
+INSERT INTO gl_sources (
+    id, workspace_id, base_url, project, path, ref, token, releases, include_patterns, exclude_patterns
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: listGLSources :many
+SELECT * FROM gl_sources
+WHERE workspace_id = ?;
+
+-- name: getGLSource :one
+SELECT * FROM gl_sources
+WHERE workspace_id = ? AND id = ?;
+
+-- name: deleteGLSource :exec
+DELETE FROM gl_sources
+WHERE workspace_id = ? AND id = ?;
+
+-- name: createFJSource :one
+INSERT INTO fj_sources (
+    id, workspace_id, base_url, project, path, ref, token, releases, include_patterns, exclude_patterns
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: listFJSources :many
+SELECT * FROM fj_sources
+WHERE workspace_id = ?;
+
+-- name: getFJSource :one
+SELECT * FROM fj_sources
+WHERE workspace_id = ? AND id = ?;
+
+-- name: deleteFJSource :exec
+DELETE FROM fj_sources
+WHERE workspace_id = ? AND id = ?;
+
+-- name: createLocalSource :one
+INSERT INTO local_sources (
+    id, workspace_id, path, include_patterns, exclude_patterns
+) VALUES (?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: listLocalSources :many
+SELECT * FROM local_sources
+WHERE workspace_id = ?;
+
+-- name: getLocalSource :one
+SELECT * FROM local_sources
+WHERE workspace_id = ? AND id = ?;
+
+-- name: deleteLocalSource :exec
+DELETE FROM local_sources
+WHERE workspace_id = ? AND id = ?;
+
+-- name: createGitSource :one
+INSERT INTO git_sources (
+    id, workspace_id, url, ref, path, conventional_commits, include_patterns, exclude_patterns
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: listGitSources :many
+SELECT * FROM git_sources
+WHERE workspace_id = ?;
+
+-- name: getGitSource :one
+SELECT * FROM git_sources
+WHERE workspace_id = ? AND id = ?;
+
+-- name: deleteGitSource :exec
+DELETE FROM git_sources
+WHERE workspace_id = ? AND id = ?;
+
+-- name: createURLSource :one
+INSERT INTO url_sources (
+    id, workspace_id, url, manifest
+) VALUES (?, ?, ?, ?)
+RETURNING *;
+
+-- name: listURLSources :many
+SELECT * FROM url_sources
+WHERE workspace_id = ?;
+
+-- name: getURLSource :one
+SELECT * FROM url_sources
+WHERE workspace_id = ? AND id = ?;
+
+-- name: deleteURLSource :exec
+DELETE FROM url_sources
+WHERE workspace_id = ? AND id = ?;
+
+-- name: createS3Source :one
+INSERT INTO s3_sources (
+    id, workspace_id, endpoint, region, bucket, prefix, access_key_id, secret_access_key
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: listS3Sources :many
+SELECT * FROM s3_sources
+WHERE workspace_id = ?;
+
+-- name: getS3Source :one
+SELECT * FROM s3_sources
+WHERE workspace_id = ? AND id = ?;
+
+-- name: deleteS3Source :exec
+DELETE FROM s3_sources
+WHERE workspace_id = ? AND id = ?;
+
 -- name: listWorkspacesChangelogCount :many
 SELECT sqlc.embed(w), COUNT(c.id) AS changelog_count
 FROM workspaces w
 LEFT JOIN changelogs c ON w.id = c.workspace_id
 GROUP BY w.id, w.name
 ORDER BY changelog_count DESC;
+
+-- name: createSubscriber :one
+INSERT INTO subscribers (
+    id, workspace_id, changelog_id, email, changelog_url, confirm_token
+) VALUES (?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: getSubscriber :one
+SELECT * FROM subscribers
+WHERE id = ?;
+
+-- name: getSubscriberByEmail :one
+SELECT * FROM subscribers
+WHERE workspace_id = ? AND changelog_id = ? AND email = ?;
+
+-- name: confirmSubscriber :one
+UPDATE subscribers
+SET confirmed_at = unixepoch('now'), confirm_token = NULL
+WHERE confirm_token = ?
+RETURNING *;
+
+-- name: listConfirmedSubscribers :many
+SELECT * FROM subscribers
+WHERE workspace_id = ? AND changelog_id = ? AND confirmed_at IS NOT NULL;
+
+-- name: deleteSubscriber :exec
+DELETE FROM subscribers
+WHERE id = ?;
+
+-- name: listDispatchedReleaseNotes :many
+SELECT release_note_id FROM dispatched_release_notes
+WHERE workspace_id = ? AND changelog_id = ?;
+
+-- name: createDispatchedReleaseNote :exec
+INSERT INTO dispatched_release_notes (
+    workspace_id, changelog_id, release_note_id
+) VALUES (?, ?, ?)
+ON CONFLICT DO NOTHING;
+
+-- name: createWebhook :one
+INSERT INTO webhooks (
+    id, workspace_id, changelog_id, url, secret
+) VALUES (?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: getWebhook :one
+SELECT * FROM webhooks
+WHERE workspace_id = ? AND id = ?;
+
+-- name: listWebhooks :many
+SELECT * FROM webhooks
+WHERE workspace_id = ? AND changelog_id = ?
+ORDER BY created_at;
+
+-- name: listAllWebhooks :many
+SELECT * FROM webhooks;
+
+-- name: deleteWebhook :exec
+DELETE FROM webhooks
+WHERE workspace_id = ? AND id = ?;
+
+-- name: createWebhookDelivery :one
+INSERT INTO webhook_deliveries (
+    id, webhook_id, release_note_id, event, attempt, status_code, error
+) VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: listWebhookDeliveries :many
+SELECT * FROM webhook_deliveries
+WHERE webhook_id = ?
+ORDER BY created_at DESC, id DESC
+LIMIT ?;
+
+-- name: listDeliveredReleaseNotes :many
+SELECT DISTINCT release_note_id FROM webhook_deliveries
+WHERE webhook_id = ?;
+
+-- name: listWebhookSources :many
+SELECT source_id FROM webhook_sources
+WHERE webhook_id = ?;
+
+-- name: createWebhookSource :exec
+INSERT INTO webhook_sources (
+    webhook_id, source_id
+) VALUES (?, ?)
+ON CONFLICT DO NOTHING;
+
+-- name: listWebhookSkippedReleaseNotes :many
+SELECT release_note_id FROM webhook_skipped_release_notes
+WHERE webhook_id = ?;
+
+-- name: createWebhookSkippedReleaseNote :exec
+INSERT INTO webhook_skipped_release_notes (
+    webhook_id, release_note_id
+) VALUES (?, ?)
+ON CONFLICT DO NOTHING;
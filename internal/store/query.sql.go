@@ -12,6 +12,29 @@ import (
 	"github.com/jonashiltl/openchangelog/apitypes"
 )
 
+const confirmSubscriber = `-- name: confirmSubscriber :one
+UPDATE subscribers
+SET confirmed_at = unixepoch('now'), confirm_token = NULL
+WHERE confirm_token = ?
+RETURNING id, workspace_id, changelog_id, email, changelog_url, confirm_token, confirmed_at, created_at
+`
+
+func (q *Queries) confirmSubscriber(ctx context.Context, confirmToken apitypes.NullString) (subscriber, error) {
+	row := q.db.QueryRowContext(ctx, confirmSubscriber, confirmToken)
+	var i subscriber
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.ChangelogID,
+		&i.Email,
+		&i.ChangelogUrl,
+		&i.ConfirmToken,
+		&i.ConfirmedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const createChangelog = `-- name: createChangelog :one
 INSERT INTO changelogs (
     workspace_id,
@@ -104,20 +127,115 @@ func (q *Queries) createChangelog(ctx context.Context, arg createChangelogParams
 	return i, err
 }
 
+const createCombinedSource = `-- name: createCombinedSource :exec
+INSERT INTO combined_sources (
+    workspace_id, changelog_id, source_id, position
+) VALUES (?, ?, ?, ?)
+`
+
+type createCombinedSourceParams struct {
+	WorkspaceID string
+	ChangelogID string
+	SourceID    string
+	Position    int64
+}
+
+func (q *Queries) createCombinedSource(ctx context.Context, arg createCombinedSourceParams) error {
+	_, err := q.db.ExecContext(ctx, createCombinedSource,
+		arg.WorkspaceID,
+		arg.ChangelogID,
+		arg.SourceID,
+		arg.Position,
+	)
+	return err
+}
+
+const createDispatchedReleaseNote = `-- name: createDispatchedReleaseNote :exec
+INSERT INTO dispatched_release_notes (
+    workspace_id, changelog_id, release_note_id
+) VALUES (?, ?, ?)
+ON CONFLICT DO NOTHING
+`
+
+type createDispatchedReleaseNoteParams struct {
+	WorkspaceID   string
+	ChangelogID   string
+	ReleaseNoteID string
+}
+
+func (q *Queries) createDispatchedReleaseNote(ctx context.Context, arg createDispatchedReleaseNoteParams) error {
+	_, err := q.db.ExecContext(ctx, createDispatchedReleaseNote, arg.WorkspaceID, arg.ChangelogID, arg.ReleaseNoteID)
+	return err
+}
+
+const createFJSource = `-- name: createFJSource :one
+INSERT INTO fj_sources (
+    id, workspace_id, base_url, project, path, ref, token, releases, include_patterns, exclude_patterns
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, workspace_id, base_url, project, path, ref, token, releases, include_patterns, exclude_patterns
+`
+
+type createFJSourceParams struct {
+	ID              string
+	WorkspaceID     string
+	BaseUrl         string
+	Project         string
+	Path            string
+	Ref             string
+	Token           string
+	Releases        int64
+	IncludePatterns string
+	ExcludePatterns string
+}
+
+func (q *Queries) createFJSource(ctx context.Context, arg createFJSourceParams) (fjSource, error) {
+	row := q.db.QueryRowContext(ctx, createFJSource,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.BaseUrl,
+		arg.Project,
+		arg.Path,
+		arg.Ref,
+		arg.Token,
+		arg.Releases,
+		arg.IncludePatterns,
+		arg.ExcludePatterns,
+	)
+	var i fjSource
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.BaseUrl,
+		&i.Project,
+		&i.Path,
+		&i.Ref,
+		&i.Token,
+		&i.Releases,
+		&i.IncludePatterns,
+		&i.ExcludePatterns,
+	)
+	return i, err
+}
+
 const createGHSource = `-- name: createGHSource :one
 INSERT INTO gh_sources (
-    id, workspace_id, owner, repo, path, installation_id
-) VALUES (?, ?, ?, ?, ?, ?)
-RETURNING id, workspace_id, owner, repo, path, installation_id
+    id, workspace_id, owner, repo, path, installation_id, releases, include_patterns, exclude_patterns, base_url, ref
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, workspace_id, owner, repo, path, installation_id, releases, include_patterns, exclude_patterns, base_url, ref
 `
 
 type createGHSourceParams struct {
-	ID             string
-	WorkspaceID    string
-	Owner          string
-	Repo           string
-	Path           string
-	InstallationID int64
+	ID              string
+	WorkspaceID     string
+	Owner           string
+	Repo            string
+	Path            string
+	InstallationID  int64
+	Releases        int64
+	IncludePatterns string
+	ExcludePatterns string
+	BaseUrl         string
+	Ref             string
 }
 
 func (q *Queries) createGHSource(ctx context.Context, arg createGHSourceParams) (ghSource, error) {
@@ -128,6 +246,11 @@ func (q *Queries) createGHSource(ctx context.Context, arg createGHSourceParams)
 		arg.Repo,
 		arg.Path,
 		arg.InstallationID,
+		arg.Releases,
+		arg.IncludePatterns,
+		arg.ExcludePatterns,
+		arg.BaseUrl,
+		arg.Ref,
 	)
 	var i ghSource
 	err := row.Scan(
@@ -137,6 +260,219 @@ func (q *Queries) createGHSource(ctx context.Context, arg createGHSourceParams)
 		&i.Repo,
 		&i.Path,
 		&i.InstallationID,
+		&i.Releases,
+		&i.IncludePatterns,
+		&i.ExcludePatterns,
+		&i.BaseUrl,
+		&i.Ref,
+	)
+	return i, err
+}
+
+const createGLSource = `-- name: createGLSource :one
+INSERT INTO gl_sources (
+    id, workspace_id, base_url, project, path, ref, token, releases, include_patterns, exclude_patterns
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, workspace_id, base_url, project, path, ref, token, releases, include_patterns, exclude_patterns
+`
+
+type createGLSourceParams struct {
+	ID              string
+	WorkspaceID     string
+	BaseUrl         string
+	Project         string
+	Path            string
+	Ref             string
+	Token           string
+	Releases        int64
+	IncludePatterns string
+	ExcludePatterns string
+}
+
+func (q *Queries) createGLSource(ctx context.Context, arg createGLSourceParams) (glSource, error) {
+	row := q.db.QueryRowContext(ctx, createGLSource,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.BaseUrl,
+		arg.Project,
+		arg.Path,
+		arg.Ref,
+		arg.Token,
+		arg.Releases,
+		arg.IncludePatterns,
+		arg.ExcludePatterns,
+	)
+	var i glSource
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.BaseUrl,
+		&i.Project,
+		&i.Path,
+		&i.Ref,
+		&i.Token,
+		&i.Releases,
+		&i.IncludePatterns,
+		&i.ExcludePatterns,
+	)
+	return i, err
+}
+
+const createGitSource = `-- name: createGitSource :one
+INSERT INTO git_sources (
+    id, workspace_id, url, ref, path, conventional_commits, include_patterns, exclude_patterns
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, workspace_id, url, ref, path, conventional_commits, include_patterns, exclude_patterns
+`
+
+type createGitSourceParams struct {
+	ID                  string
+	WorkspaceID         string
+	Url                 string
+	Ref                 string
+	Path                string
+	ConventionalCommits int64
+	IncludePatterns     string
+	ExcludePatterns     string
+}
+
+func (q *Queries) createGitSource(ctx context.Context, arg createGitSourceParams) (gitSource, error) {
+	row := q.db.QueryRowContext(ctx, createGitSource,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.Url,
+		arg.Ref,
+		arg.Path,
+		arg.ConventionalCommits,
+		arg.IncludePatterns,
+		arg.ExcludePatterns,
+	)
+	var i gitSource
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.Url,
+		&i.Ref,
+		&i.Path,
+		&i.ConventionalCommits,
+		&i.IncludePatterns,
+		&i.ExcludePatterns,
+	)
+	return i, err
+}
+
+const createLocalSource = `-- name: createLocalSource :one
+INSERT INTO local_sources (
+    id, workspace_id, path, include_patterns, exclude_patterns
+) VALUES (?, ?, ?, ?, ?)
+RETURNING id, workspace_id, path, include_patterns, exclude_patterns
+`
+
+type createLocalSourceParams struct {
+	ID              string
+	WorkspaceID     string
+	Path            string
+	IncludePatterns string
+	ExcludePatterns string
+}
+
+func (q *Queries) createLocalSource(ctx context.Context, arg createLocalSourceParams) (localSource, error) {
+	row := q.db.QueryRowContext(ctx, createLocalSource,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.Path,
+		arg.IncludePatterns,
+		arg.ExcludePatterns,
+	)
+	var i localSource
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.Path,
+		&i.IncludePatterns,
+		&i.ExcludePatterns,
+	)
+	return i, err
+}
+
+const createS3Source = `-- name: createS3Source :one
+INSERT INTO s3_sources (
+    id, workspace_id, endpoint, region, bucket, prefix, access_key_id, secret_access_key
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, workspace_id, endpoint, region, bucket, prefix, access_key_id, secret_access_key
+`
+
+type createS3SourceParams struct {
+	ID              string
+	WorkspaceID     string
+	Endpoint        string
+	Region          string
+	Bucket          string
+	Prefix          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+func (q *Queries) createS3Source(ctx context.Context, arg createS3SourceParams) (s3Source, error) {
+	row := q.db.QueryRowContext(ctx, createS3Source,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.Endpoint,
+		arg.Region,
+		arg.Bucket,
+		arg.Prefix,
+		arg.AccessKeyID,
+		arg.SecretAccessKey,
+	)
+	var i s3Source
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.Endpoint,
+		&i.Region,
+		&i.Bucket,
+		&i.Prefix,
+		&i.AccessKeyID,
+		&i.SecretAccessKey,
+	)
+	return i, err
+}
+
+const createSubscriber = `-- name: createSubscriber :one
+INSERT INTO subscribers (
+    id, workspace_id, changelog_id, email, changelog_url, confirm_token
+) VALUES (?, ?, ?, ?, ?, ?)
+RETURNING id, workspace_id, changelog_id, email, changelog_url, confirm_token, confirmed_at, created_at
+`
+
+type createSubscriberParams struct {
+	ID           string
+	WorkspaceID  string
+	ChangelogID  string
+	Email        string
+	ChangelogUrl string
+	ConfirmToken apitypes.NullString
+}
+
+func (q *Queries) createSubscriber(ctx context.Context, arg createSubscriberParams) (subscriber, error) {
+	row := q.db.QueryRowContext(ctx, createSubscriber,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.ChangelogID,
+		arg.Email,
+		arg.ChangelogUrl,
+		arg.ConfirmToken,
+	)
+	var i subscriber
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.ChangelogID,
+		&i.Email,
+		&i.ChangelogUrl,
+		&i.ConfirmToken,
+		&i.ConfirmedAt,
+		&i.CreatedAt,
 	)
 	return i, err
 }
@@ -159,6 +495,147 @@ func (q *Queries) createToken(ctx context.Context, arg createTokenParams) error
 	return err
 }
 
+const createURLSource = `-- name: createURLSource :one
+INSERT INTO url_sources (
+    id, workspace_id, url, manifest
+) VALUES (?, ?, ?, ?)
+RETURNING id, workspace_id, url, manifest
+`
+
+type createURLSourceParams struct {
+	ID          string
+	WorkspaceID string
+	Url         string
+	Manifest    int64
+}
+
+func (q *Queries) createURLSource(ctx context.Context, arg createURLSourceParams) (urlSource, error) {
+	row := q.db.QueryRowContext(ctx, createURLSource,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.Url,
+		arg.Manifest,
+	)
+	var i urlSource
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.Url,
+		&i.Manifest,
+	)
+	return i, err
+}
+
+const createWebhook = `-- name: createWebhook :one
+INSERT INTO webhooks (
+    id, workspace_id, changelog_id, url, secret
+) VALUES (?, ?, ?, ?, ?)
+RETURNING id, workspace_id, changelog_id, url, secret, created_at
+`
+
+type createWebhookParams struct {
+	ID          string
+	WorkspaceID string
+	ChangelogID string
+	Url         string
+	Secret      string
+}
+
+func (q *Queries) createWebhook(ctx context.Context, arg createWebhookParams) (webhook, error) {
+	row := q.db.QueryRowContext(ctx, createWebhook,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.ChangelogID,
+		arg.Url,
+		arg.Secret,
+	)
+	var i webhook
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.ChangelogID,
+		&i.Url,
+		&i.Secret,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createWebhookDelivery = `-- name: createWebhookDelivery :one
+INSERT INTO webhook_deliveries (
+    id, webhook_id, release_note_id, event, attempt, status_code, error
+) VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING id, webhook_id, release_note_id, event, attempt, status_code, error, created_at
+`
+
+type createWebhookDeliveryParams struct {
+	ID            string
+	WebhookID     string
+	ReleaseNoteID string
+	Event         string
+	Attempt       int64
+	StatusCode    int64
+	Error         apitypes.NullString
+}
+
+func (q *Queries) createWebhookDelivery(ctx context.Context, arg createWebhookDeliveryParams) (webhookDelivery, error) {
+	row := q.db.QueryRowContext(ctx, createWebhookDelivery,
+		arg.ID,
+		arg.WebhookID,
+		arg.ReleaseNoteID,
+		arg.Event,
+		arg.Attempt,
+		arg.StatusCode,
+		arg.Error,
+	)
+	var i webhookDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.WebhookID,
+		&i.ReleaseNoteID,
+		&i.Event,
+		&i.Attempt,
+		&i.StatusCode,
+		&i.Error,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createWebhookSkippedReleaseNote = `-- name: createWebhookSkippedReleaseNote :exec
+INSERT INTO webhook_skipped_release_notes (
+    webhook_id, release_note_id
+) VALUES (?, ?)
+ON CONFLICT DO NOTHING
+`
+
+type createWebhookSkippedReleaseNoteParams struct {
+	WebhookID     string
+	ReleaseNoteID string
+}
+
+func (q *Queries) createWebhookSkippedReleaseNote(ctx context.Context, arg createWebhookSkippedReleaseNoteParams) error {
+	_, err := q.db.ExecContext(ctx, createWebhookSkippedReleaseNote, arg.WebhookID, arg.ReleaseNoteID)
+	return err
+}
+
+const createWebhookSource = `-- name: createWebhookSource :exec
+INSERT INTO webhook_sources (
+    webhook_id, source_id
+) VALUES (?, ?)
+ON CONFLICT DO NOTHING
+`
+
+type createWebhookSourceParams struct {
+	WebhookID string
+	SourceID  string
+}
+
+func (q *Queries) createWebhookSource(ctx context.Context, arg createWebhookSourceParams) error {
+	_, err := q.db.ExecContext(ctx, createWebhookSource, arg.WebhookID, arg.SourceID)
+	return err
+}
+
 const deleteChangelog = `-- name: deleteChangelog :exec
 DELETE FROM changelogs
 WHERE workspace_id = ? AND id = ?
@@ -190,6 +667,61 @@ func (q *Queries) deleteChangelogSource(ctx context.Context, arg deleteChangelog
 	return err
 }
 
+const deleteCombinedSourceByID = `-- name: deleteCombinedSourceByID :exec
+DELETE FROM combined_sources
+WHERE workspace_id = ? AND source_id = ?
+`
+
+type deleteCombinedSourceByIDParams struct {
+	WorkspaceID string
+	SourceID    string
+}
+
+func (q *Queries) deleteCombinedSourceByID(ctx context.Context, arg deleteCombinedSourceByIDParams) error {
+	_, err := q.db.ExecContext(ctx, deleteCombinedSourceByID, arg.WorkspaceID, arg.SourceID)
+	return err
+}
+
+const deleteCombinedSources = `-- name: deleteCombinedSources :exec
+DELETE FROM combined_sources
+WHERE workspace_id = ? AND changelog_id = ?
+`
+
+type deleteCombinedSourcesParams struct {
+	WorkspaceID string
+	ChangelogID string
+}
+
+func (q *Queries) deleteCombinedSources(ctx context.Context, arg deleteCombinedSourcesParams) error {
+	_, err := q.db.ExecContext(ctx, deleteCombinedSources, arg.WorkspaceID, arg.ChangelogID)
+	return err
+}
+
+const deleteFJSource = `-- name: deleteFJSource :exec
+DELETE FROM fj_sources
+WHERE workspace_id = ? AND id = ?
+`
+
+type deleteFJSourceParams struct {
+	WorkspaceID string
+	ID          string
+}
+
+func (q *Queries) deleteFJSource(ctx context.Context, arg deleteFJSourceParams) error {
+	_, err := q.db.ExecContext(ctx, deleteFJSource, arg.WorkspaceID, arg.ID)
+	return err
+}
+
+const deleteGHInstallation = `-- name: deleteGHInstallation :exec
+DELETE FROM gh_installations
+WHERE installation_id = ?
+`
+
+func (q *Queries) deleteGHInstallation(ctx context.Context, installationID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteGHInstallation, installationID)
+	return err
+}
+
 const deleteGHSource = `-- name: deleteGHSource :exec
 DELETE FROM gh_sources
 WHERE workspace_id = ? AND id = ?
@@ -205,44 +737,177 @@ func (q *Queries) deleteGHSource(ctx context.Context, arg deleteGHSourceParams)
 	return err
 }
 
-const deleteWorkspace = `-- name: deleteWorkspace :exec
-DELETE FROM workspaces
-WHERE id = ?
+const deleteGLSource = `-- name: deleteGLSource :exec
+DELETE FROM gl_sources
+WHERE workspace_id = ? AND id = ?
 `
 
-func (q *Queries) deleteWorkspace(ctx context.Context, id string) error {
-	_, err := q.db.ExecContext(ctx, deleteWorkspace, id)
+type deleteGLSourceParams struct {
+	WorkspaceID string
+	ID          string
+}
+
+func (q *Queries) deleteGLSource(ctx context.Context, arg deleteGLSourceParams) error {
+	_, err := q.db.ExecContext(ctx, deleteGLSource, arg.WorkspaceID, arg.ID)
 	return err
 }
 
-const getChangelog = `-- name: getChangelog :one
-SELECT c.id, c.workspace_id, c.subdomain, c.title, c.subtitle, c.source_id, c.logo_src, c.logo_link, c.logo_alt, c.logo_height, c.logo_width, c.created_at, c.domain, c.color_scheme, c.hide_powered_by, c.protected, c.password_hash, c.analytics, c.searchable, c.hide_rss_icon, cs.id, cs.workspace_id, cs.owner, cs.repo, cs.path, cs.installation_id
-FROM changelogs c
-LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
-WHERE c.workspace_id = ? AND c.id = ?
+const deleteGitSource = `-- name: deleteGitSource :exec
+DELETE FROM git_sources
+WHERE workspace_id = ? AND id = ?
 `
 
-type getChangelogParams struct {
+type deleteGitSourceParams struct {
 	WorkspaceID string
 	ID          string
 }
 
-type getChangelogRow struct {
-	changelog       changelog
-	ChangelogSource changelogSource
+func (q *Queries) deleteGitSource(ctx context.Context, arg deleteGitSourceParams) error {
+	_, err := q.db.ExecContext(ctx, deleteGitSource, arg.WorkspaceID, arg.ID)
+	return err
 }
 
-func (q *Queries) getChangelog(ctx context.Context, arg getChangelogParams) (getChangelogRow, error) {
-	row := q.db.QueryRowContext(ctx, getChangelog, arg.WorkspaceID, arg.ID)
-	var i getChangelogRow
-	err := row.Scan(
-		&i.changelog.ID,
-		&i.changelog.WorkspaceID,
-		&i.changelog.Subdomain,
-		&i.changelog.Title,
-		&i.changelog.Subtitle,
-		&i.changelog.SourceID,
-		&i.changelog.LogoSrc,
+const deleteLocalSource = `-- name: deleteLocalSource :exec
+DELETE FROM local_sources
+WHERE workspace_id = ? AND id = ?
+`
+
+type deleteLocalSourceParams struct {
+	WorkspaceID string
+	ID          string
+}
+
+func (q *Queries) deleteLocalSource(ctx context.Context, arg deleteLocalSourceParams) error {
+	_, err := q.db.ExecContext(ctx, deleteLocalSource, arg.WorkspaceID, arg.ID)
+	return err
+}
+
+const deleteS3Source = `-- name: deleteS3Source :exec
+DELETE FROM s3_sources
+WHERE workspace_id = ? AND id = ?
+`
+
+type deleteS3SourceParams struct {
+	WorkspaceID string
+	ID          string
+}
+
+func (q *Queries) deleteS3Source(ctx context.Context, arg deleteS3SourceParams) error {
+	_, err := q.db.ExecContext(ctx, deleteS3Source, arg.WorkspaceID, arg.ID)
+	return err
+}
+
+const deleteSubscriber = `-- name: deleteSubscriber :exec
+DELETE FROM subscribers
+WHERE id = ?
+`
+
+func (q *Queries) deleteSubscriber(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, deleteSubscriber, id)
+	return err
+}
+
+const deleteURLSource = `-- name: deleteURLSource :exec
+DELETE FROM url_sources
+WHERE workspace_id = ? AND id = ?
+`
+
+type deleteURLSourceParams struct {
+	WorkspaceID string
+	ID          string
+}
+
+func (q *Queries) deleteURLSource(ctx context.Context, arg deleteURLSourceParams) error {
+	_, err := q.db.ExecContext(ctx, deleteURLSource, arg.WorkspaceID, arg.ID)
+	return err
+}
+
+const deleteWebhook = `-- name: deleteWebhook :exec
+DELETE FROM webhooks
+WHERE workspace_id = ? AND id = ?
+`
+
+type deleteWebhookParams struct {
+	WorkspaceID string
+	ID          string
+}
+
+func (q *Queries) deleteWebhook(ctx context.Context, arg deleteWebhookParams) error {
+	_, err := q.db.ExecContext(ctx, deleteWebhook, arg.WorkspaceID, arg.ID)
+	return err
+}
+
+const deleteWorkspace = `-- name: deleteWorkspace :exec
+DELETE FROM workspaces
+WHERE id = ?
+`
+
+func (q *Queries) deleteWorkspace(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, deleteWorkspace, id)
+	return err
+}
+
+const detachChangelogSource = `-- name: detachChangelogSource :exec
+UPDATE changelogs
+SET source_id = (
+    SELECT cs.source_id FROM combined_sources cs
+    WHERE cs.workspace_id = changelogs.workspace_id AND cs.changelog_id = changelogs.id
+    ORDER BY cs.position
+    LIMIT 1
+)
+WHERE workspace_id = ? AND source_id = ?
+`
+
+type detachChangelogSourceParams struct {
+	WorkspaceID string
+	SourceID    apitypes.NullString
+}
+
+func (q *Queries) detachChangelogSource(ctx context.Context, arg detachChangelogSourceParams) error {
+	_, err := q.db.ExecContext(ctx, detachChangelogSource, arg.WorkspaceID, arg.SourceID)
+	return err
+}
+
+const getChangelog = `-- name: getChangelog :one
+SELECT c.id, c.workspace_id, c.subdomain, c.title, c.subtitle, c.source_id, c.logo_src, c.logo_link, c.logo_alt, c.logo_height, c.logo_width, c.created_at, c.domain, c.color_scheme, c.hide_powered_by, c.protected, c.password_hash, c.analytics, c.searchable, c.hide_rss_icon, cs.id, cs.workspace_id, cs.owner, cs.repo, cs.path, cs.installation_id, cs.releases, cs.include_patterns, cs.exclude_patterns, cs.base_url, cs.ref, gls.id, gls.workspace_id, gls.base_url, gls.project, gls.path, gls.ref, gls.token, gls.releases, gls.include_patterns, gls.exclude_patterns, fjs.id, fjs.workspace_id, fjs.base_url, fjs.project, fjs.path, fjs.ref, fjs.token, fjs.releases, fjs.include_patterns, fjs.exclude_patterns, lcs.id, lcs.workspace_id, lcs.path, lcs.include_patterns, lcs.exclude_patterns, gits.id, gits.workspace_id, gits.url, gits.ref, gits.path, gits.conventional_commits, gits.include_patterns, gits.exclude_patterns, urls.id, urls.workspace_id, urls.url, urls.manifest, s3s.id, s3s.workspace_id, s3s.endpoint, s3s.region, s3s.bucket, s3s.prefix, s3s.access_key_id, s3s.secret_access_key
+FROM changelogs c
+LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
+LEFT JOIN changelog_gl_source gls ON c.workspace_id = gls.workspace_id AND c.source_id = gls.id
+LEFT JOIN changelog_fj_source fjs ON c.workspace_id = fjs.workspace_id AND c.source_id = fjs.id
+LEFT JOIN changelog_local_source lcs ON c.workspace_id = lcs.workspace_id AND c.source_id = lcs.id
+LEFT JOIN changelog_git_source gits ON c.workspace_id = gits.workspace_id AND c.source_id = gits.id
+LEFT JOIN changelog_url_source urls ON c.workspace_id = urls.workspace_id AND c.source_id = urls.id
+LEFT JOIN changelog_s3_source s3s ON c.workspace_id = s3s.workspace_id AND c.source_id = s3s.id
+WHERE c.workspace_id = ? AND c.id = ?
+`
+
+type getChangelogParams struct {
+	WorkspaceID string
+	ID          string
+}
+
+type getChangelogRow struct {
+	changelog            changelog
+	ChangelogSource      changelogSource
+	ChangelogGlSource    changelogGlSource
+	ChangelogFjSource    changelogFjSource
+	ChangelogLocalSource changelogLocalSource
+	ChangelogGitSource   changelogGitSource
+	ChangelogUrlSource   changelogUrlSource
+	ChangelogS3Source    changelogS3Source
+}
+
+func (q *Queries) getChangelog(ctx context.Context, arg getChangelogParams) (getChangelogRow, error) {
+	row := q.db.QueryRowContext(ctx, getChangelog, arg.WorkspaceID, arg.ID)
+	var i getChangelogRow
+	err := row.Scan(
+		&i.changelog.ID,
+		&i.changelog.WorkspaceID,
+		&i.changelog.Subdomain,
+		&i.changelog.Title,
+		&i.changelog.Subtitle,
+		&i.changelog.SourceID,
+		&i.changelog.LogoSrc,
 		&i.changelog.LogoLink,
 		&i.changelog.LogoAlt,
 		&i.changelog.LogoHeight,
@@ -262,14 +927,70 @@ func (q *Queries) getChangelog(ctx context.Context, arg getChangelogParams) (get
 		&i.ChangelogSource.Repo,
 		&i.ChangelogSource.Path,
 		&i.ChangelogSource.InstallationID,
+		&i.ChangelogSource.Releases,
+		&i.ChangelogSource.IncludePatterns,
+		&i.ChangelogSource.ExcludePatterns,
+		&i.ChangelogSource.BaseUrl,
+		&i.ChangelogSource.Ref,
+		&i.ChangelogGlSource.ID,
+		&i.ChangelogGlSource.WorkspaceID,
+		&i.ChangelogGlSource.BaseUrl,
+		&i.ChangelogGlSource.Project,
+		&i.ChangelogGlSource.Path,
+		&i.ChangelogGlSource.Ref,
+		&i.ChangelogGlSource.Token,
+		&i.ChangelogGlSource.Releases,
+		&i.ChangelogGlSource.IncludePatterns,
+		&i.ChangelogGlSource.ExcludePatterns,
+		&i.ChangelogFjSource.ID,
+		&i.ChangelogFjSource.WorkspaceID,
+		&i.ChangelogFjSource.BaseUrl,
+		&i.ChangelogFjSource.Project,
+		&i.ChangelogFjSource.Path,
+		&i.ChangelogFjSource.Ref,
+		&i.ChangelogFjSource.Token,
+		&i.ChangelogFjSource.Releases,
+		&i.ChangelogFjSource.IncludePatterns,
+		&i.ChangelogFjSource.ExcludePatterns,
+		&i.ChangelogLocalSource.ID,
+		&i.ChangelogLocalSource.WorkspaceID,
+		&i.ChangelogLocalSource.Path,
+		&i.ChangelogLocalSource.IncludePatterns,
+		&i.ChangelogLocalSource.ExcludePatterns,
+		&i.ChangelogGitSource.ID,
+		&i.ChangelogGitSource.WorkspaceID,
+		&i.ChangelogGitSource.Url,
+		&i.ChangelogGitSource.Ref,
+		&i.ChangelogGitSource.Path,
+		&i.ChangelogGitSource.ConventionalCommits,
+		&i.ChangelogGitSource.IncludePatterns,
+		&i.ChangelogGitSource.ExcludePatterns,
+		&i.ChangelogUrlSource.ID,
+		&i.ChangelogUrlSource.WorkspaceID,
+		&i.ChangelogUrlSource.Url,
+		&i.ChangelogUrlSource.Manifest,
+		&i.ChangelogS3Source.ID,
+		&i.ChangelogS3Source.WorkspaceID,
+		&i.ChangelogS3Source.Endpoint,
+		&i.ChangelogS3Source.Region,
+		&i.ChangelogS3Source.Bucket,
+		&i.ChangelogS3Source.Prefix,
+		&i.ChangelogS3Source.AccessKeyID,
+		&i.ChangelogS3Source.SecretAccessKey,
 	)
 	return i, err
 }
 
 const getChangelogByDomainOrSubdomain = `-- name: getChangelogByDomainOrSubdomain :one
-SELECT c.id, c.workspace_id, c.subdomain, c.title, c.subtitle, c.source_id, c.logo_src, c.logo_link, c.logo_alt, c.logo_height, c.logo_width, c.created_at, c.domain, c.color_scheme, c.hide_powered_by, c.protected, c.password_hash, c.analytics, c.searchable, c.hide_rss_icon, cs.id, cs.workspace_id, cs.owner, cs.repo, cs.path, cs.installation_id
+SELECT c.id, c.workspace_id, c.subdomain, c.title, c.subtitle, c.source_id, c.logo_src, c.logo_link, c.logo_alt, c.logo_height, c.logo_width, c.created_at, c.domain, c.color_scheme, c.hide_powered_by, c.protected, c.password_hash, c.analytics, c.searchable, c.hide_rss_icon, cs.id, cs.workspace_id, cs.owner, cs.repo, cs.path, cs.installation_id, cs.releases, cs.include_patterns, cs.exclude_patterns, cs.base_url, cs.ref, gls.id, gls.workspace_id, gls.base_url, gls.project, gls.path, gls.ref, gls.token, gls.releases, gls.include_patterns, gls.exclude_patterns, fjs.id, fjs.workspace_id, fjs.base_url, fjs.project, fjs.path, fjs.ref, fjs.token, fjs.releases, fjs.include_patterns, fjs.exclude_patterns, lcs.id, lcs.workspace_id, lcs.path, lcs.include_patterns, lcs.exclude_patterns, gits.id, gits.workspace_id, gits.url, gits.ref, gits.path, gits.conventional_commits, gits.include_patterns, gits.exclude_patterns, urls.id, urls.workspace_id, urls.url, urls.manifest, s3s.id, s3s.workspace_id, s3s.endpoint, s3s.region, s3s.bucket, s3s.prefix, s3s.access_key_id, s3s.secret_access_key
 FROM changelogs c
 LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
+LEFT JOIN changelog_gl_source gls ON c.workspace_id = gls.workspace_id AND c.source_id = gls.id
+LEFT JOIN changelog_fj_source fjs ON c.workspace_id = fjs.workspace_id AND c.source_id = fjs.id
+LEFT JOIN changelog_local_source lcs ON c.workspace_id = lcs.workspace_id AND c.source_id = lcs.id
+LEFT JOIN changelog_git_source gits ON c.workspace_id = gits.workspace_id AND c.source_id = gits.id
+LEFT JOIN changelog_url_source urls ON c.workspace_id = urls.workspace_id AND c.source_id = urls.id
+LEFT JOIN changelog_s3_source s3s ON c.workspace_id = s3s.workspace_id AND c.source_id = s3s.id
 WHERE c.domain = ? OR c.subdomain = ?
 LIMIT 1
 `
@@ -280,8 +1001,14 @@ type getChangelogByDomainOrSubdomainParams struct {
 }
 
 type getChangelogByDomainOrSubdomainRow struct {
-	changelog       changelog
-	ChangelogSource changelogSource
+	changelog            changelog
+	ChangelogSource      changelogSource
+	ChangelogGlSource    changelogGlSource
+	ChangelogFjSource    changelogFjSource
+	ChangelogLocalSource changelogLocalSource
+	ChangelogGitSource   changelogGitSource
+	ChangelogUrlSource   changelogUrlSource
+	ChangelogS3Source    changelogS3Source
 }
 
 // first search by domain, if not found by subdomain
@@ -315,12 +1042,112 @@ func (q *Queries) getChangelogByDomainOrSubdomain(ctx context.Context, arg getCh
 		&i.ChangelogSource.Repo,
 		&i.ChangelogSource.Path,
 		&i.ChangelogSource.InstallationID,
+		&i.ChangelogSource.Releases,
+		&i.ChangelogSource.IncludePatterns,
+		&i.ChangelogSource.ExcludePatterns,
+		&i.ChangelogSource.BaseUrl,
+		&i.ChangelogSource.Ref,
+		&i.ChangelogGlSource.ID,
+		&i.ChangelogGlSource.WorkspaceID,
+		&i.ChangelogGlSource.BaseUrl,
+		&i.ChangelogGlSource.Project,
+		&i.ChangelogGlSource.Path,
+		&i.ChangelogGlSource.Ref,
+		&i.ChangelogGlSource.Token,
+		&i.ChangelogGlSource.Releases,
+		&i.ChangelogGlSource.IncludePatterns,
+		&i.ChangelogGlSource.ExcludePatterns,
+		&i.ChangelogFjSource.ID,
+		&i.ChangelogFjSource.WorkspaceID,
+		&i.ChangelogFjSource.BaseUrl,
+		&i.ChangelogFjSource.Project,
+		&i.ChangelogFjSource.Path,
+		&i.ChangelogFjSource.Ref,
+		&i.ChangelogFjSource.Token,
+		&i.ChangelogFjSource.Releases,
+		&i.ChangelogFjSource.IncludePatterns,
+		&i.ChangelogFjSource.ExcludePatterns,
+		&i.ChangelogLocalSource.ID,
+		&i.ChangelogLocalSource.WorkspaceID,
+		&i.ChangelogLocalSource.Path,
+		&i.ChangelogLocalSource.IncludePatterns,
+		&i.ChangelogLocalSource.ExcludePatterns,
+		&i.ChangelogGitSource.ID,
+		&i.ChangelogGitSource.WorkspaceID,
+		&i.ChangelogGitSource.Url,
+		&i.ChangelogGitSource.Ref,
+		&i.ChangelogGitSource.Path,
+		&i.ChangelogGitSource.ConventionalCommits,
+		&i.ChangelogGitSource.IncludePatterns,
+		&i.ChangelogGitSource.ExcludePatterns,
+		&i.ChangelogUrlSource.ID,
+		&i.ChangelogUrlSource.WorkspaceID,
+		&i.ChangelogUrlSource.Url,
+		&i.ChangelogUrlSource.Manifest,
+		&i.ChangelogS3Source.ID,
+		&i.ChangelogS3Source.WorkspaceID,
+		&i.ChangelogS3Source.Endpoint,
+		&i.ChangelogS3Source.Region,
+		&i.ChangelogS3Source.Bucket,
+		&i.ChangelogS3Source.Prefix,
+		&i.ChangelogS3Source.AccessKeyID,
+		&i.ChangelogS3Source.SecretAccessKey,
+	)
+	return i, err
+}
+
+const getFJSource = `-- name: getFJSource :one
+SELECT id, workspace_id, base_url, project, path, ref, token, releases, include_patterns, exclude_patterns FROM fj_sources
+WHERE workspace_id = ? AND id = ?
+`
+
+type getFJSourceParams struct {
+	WorkspaceID string
+	ID          string
+}
+
+func (q *Queries) getFJSource(ctx context.Context, arg getFJSourceParams) (fjSource, error) {
+	row := q.db.QueryRowContext(ctx, getFJSource, arg.WorkspaceID, arg.ID)
+	var i fjSource
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.BaseUrl,
+		&i.Project,
+		&i.Path,
+		&i.Ref,
+		&i.Token,
+		&i.Releases,
+		&i.IncludePatterns,
+		&i.ExcludePatterns,
+	)
+	return i, err
+}
+
+const getGHInstallation = `-- name: getGHInstallation :one
+SELECT workspace_id, installation_id, account, created_at FROM gh_installations
+WHERE workspace_id = ? AND installation_id = ?
+`
+
+type getGHInstallationParams struct {
+	WorkspaceID    string
+	InstallationID int64
+}
+
+func (q *Queries) getGHInstallation(ctx context.Context, arg getGHInstallationParams) (ghInstallation, error) {
+	row := q.db.QueryRowContext(ctx, getGHInstallation, arg.WorkspaceID, arg.InstallationID)
+	var i ghInstallation
+	err := row.Scan(
+		&i.WorkspaceID,
+		&i.InstallationID,
+		&i.Account,
+		&i.CreatedAt,
 	)
 	return i, err
 }
 
 const getGHSource = `-- name: getGHSource :one
-SELECT id, workspace_id, owner, repo, path, installation_id FROM gh_sources
+SELECT id, workspace_id, owner, repo, path, installation_id, releases, include_patterns, exclude_patterns, base_url, ref FROM gh_sources
 WHERE workspace_id = ? AND id = ?
 `
 
@@ -339,6 +1166,162 @@ func (q *Queries) getGHSource(ctx context.Context, arg getGHSourceParams) (ghSou
 		&i.Repo,
 		&i.Path,
 		&i.InstallationID,
+		&i.Releases,
+		&i.IncludePatterns,
+		&i.ExcludePatterns,
+		&i.BaseUrl,
+		&i.Ref,
+	)
+	return i, err
+}
+
+const getGLSource = `-- name: getGLSource :one
+SELECT id, workspace_id, base_url, project, path, ref, token, releases, include_patterns, exclude_patterns FROM gl_sources
+WHERE workspace_id = ? AND id = ?
+`
+
+type getGLSourceParams struct {
+	WorkspaceID string
+	ID          string
+}
+
+func (q *Queries) getGLSource(ctx context.Context, arg getGLSourceParams) (glSource, error) {
+	row := q.db.QueryRowContext(ctx, getGLSource, arg.WorkspaceID, arg.ID)
+	var i glSource
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.BaseUrl,
+		&i.Project,
+		&i.Path,
+		&i.Ref,
+		&i.Token,
+		&i.Releases,
+		&i.IncludePatterns,
+		&i.ExcludePatterns,
+	)
+	return i, err
+}
+
+const getGitSource = `-- name: getGitSource :one
+SELECT id, workspace_id, url, ref, path, conventional_commits, include_patterns, exclude_patterns FROM git_sources
+WHERE workspace_id = ? AND id = ?
+`
+
+type getGitSourceParams struct {
+	WorkspaceID string
+	ID          string
+}
+
+func (q *Queries) getGitSource(ctx context.Context, arg getGitSourceParams) (gitSource, error) {
+	row := q.db.QueryRowContext(ctx, getGitSource, arg.WorkspaceID, arg.ID)
+	var i gitSource
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.Url,
+		&i.Ref,
+		&i.Path,
+		&i.ConventionalCommits,
+		&i.IncludePatterns,
+		&i.ExcludePatterns,
+	)
+	return i, err
+}
+
+const getLocalSource = `-- name: getLocalSource :one
+SELECT id, workspace_id, path, include_patterns, exclude_patterns FROM local_sources
+WHERE workspace_id = ? AND id = ?
+`
+
+type getLocalSourceParams struct {
+	WorkspaceID string
+	ID          string
+}
+
+func (q *Queries) getLocalSource(ctx context.Context, arg getLocalSourceParams) (localSource, error) {
+	row := q.db.QueryRowContext(ctx, getLocalSource, arg.WorkspaceID, arg.ID)
+	var i localSource
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.Path,
+		&i.IncludePatterns,
+		&i.ExcludePatterns,
+	)
+	return i, err
+}
+
+const getS3Source = `-- name: getS3Source :one
+SELECT id, workspace_id, endpoint, region, bucket, prefix, access_key_id, secret_access_key FROM s3_sources
+WHERE workspace_id = ? AND id = ?
+`
+
+type getS3SourceParams struct {
+	WorkspaceID string
+	ID          string
+}
+
+func (q *Queries) getS3Source(ctx context.Context, arg getS3SourceParams) (s3Source, error) {
+	row := q.db.QueryRowContext(ctx, getS3Source, arg.WorkspaceID, arg.ID)
+	var i s3Source
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.Endpoint,
+		&i.Region,
+		&i.Bucket,
+		&i.Prefix,
+		&i.AccessKeyID,
+		&i.SecretAccessKey,
+	)
+	return i, err
+}
+
+const getSubscriber = `-- name: getSubscriber :one
+SELECT id, workspace_id, changelog_id, email, changelog_url, confirm_token, confirmed_at, created_at FROM subscribers
+WHERE id = ?
+`
+
+func (q *Queries) getSubscriber(ctx context.Context, id string) (subscriber, error) {
+	row := q.db.QueryRowContext(ctx, getSubscriber, id)
+	var i subscriber
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.ChangelogID,
+		&i.Email,
+		&i.ChangelogUrl,
+		&i.ConfirmToken,
+		&i.ConfirmedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getSubscriberByEmail = `-- name: getSubscriberByEmail :one
+SELECT id, workspace_id, changelog_id, email, changelog_url, confirm_token, confirmed_at, created_at FROM subscribers
+WHERE workspace_id = ? AND changelog_id = ? AND email = ?
+`
+
+type getSubscriberByEmailParams struct {
+	WorkspaceID string
+	ChangelogID string
+	Email       string
+}
+
+func (q *Queries) getSubscriberByEmail(ctx context.Context, arg getSubscriberByEmailParams) (subscriber, error) {
+	row := q.db.QueryRowContext(ctx, getSubscriberByEmail, arg.WorkspaceID, arg.ChangelogID, arg.Email)
+	var i subscriber
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.ChangelogID,
+		&i.Email,
+		&i.ChangelogUrl,
+		&i.ConfirmToken,
+		&i.ConfirmedAt,
+		&i.CreatedAt,
 	)
 	return i, err
 }
@@ -370,6 +1353,52 @@ func (q *Queries) getTokenByWorkspace(ctx context.Context, workspaceID string) (
 	return i, err
 }
 
+const getURLSource = `-- name: getURLSource :one
+SELECT id, workspace_id, url, manifest FROM url_sources
+WHERE workspace_id = ? AND id = ?
+`
+
+type getURLSourceParams struct {
+	WorkspaceID string
+	ID          string
+}
+
+func (q *Queries) getURLSource(ctx context.Context, arg getURLSourceParams) (urlSource, error) {
+	row := q.db.QueryRowContext(ctx, getURLSource, arg.WorkspaceID, arg.ID)
+	var i urlSource
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.Url,
+		&i.Manifest,
+	)
+	return i, err
+}
+
+const getWebhook = `-- name: getWebhook :one
+SELECT id, workspace_id, changelog_id, url, secret, created_at FROM webhooks
+WHERE workspace_id = ? AND id = ?
+`
+
+type getWebhookParams struct {
+	WorkspaceID string
+	ID          string
+}
+
+func (q *Queries) getWebhook(ctx context.Context, arg getWebhookParams) (webhook, error) {
+	row := q.db.QueryRowContext(ctx, getWebhook, arg.WorkspaceID, arg.ID)
+	var i webhook
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.ChangelogID,
+		&i.Url,
+		&i.Secret,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const getWorkspace = `-- name: getWorkspace :one
 SELECT w.id, w.name, t."key", t.workspace_id
 FROM workspaces w
@@ -394,27 +1423,38 @@ func (q *Queries) getWorkspace(ctx context.Context, id string) (getWorkspaceRow,
 	return i, err
 }
 
-const listChangelogs = `-- name: listChangelogs :many
-SELECT c.id, c.workspace_id, c.subdomain, c.title, c.subtitle, c.source_id, c.logo_src, c.logo_link, c.logo_alt, c.logo_height, c.logo_width, c.created_at, c.domain, c.color_scheme, c.hide_powered_by, c.protected, c.password_hash, c.analytics, c.searchable, c.hide_rss_icon, cs.id, cs.workspace_id, cs.owner, cs.repo, cs.path, cs.installation_id
+const listAllChangelogs = `-- name: listAllChangelogs :many
+SELECT c.id, c.workspace_id, c.subdomain, c.title, c.subtitle, c.source_id, c.logo_src, c.logo_link, c.logo_alt, c.logo_height, c.logo_width, c.created_at, c.domain, c.color_scheme, c.hide_powered_by, c.protected, c.password_hash, c.analytics, c.searchable, c.hide_rss_icon, cs.id, cs.workspace_id, cs.owner, cs.repo, cs.path, cs.installation_id, cs.releases, cs.include_patterns, cs.exclude_patterns, cs.base_url, cs.ref, gls.id, gls.workspace_id, gls.base_url, gls.project, gls.path, gls.ref, gls.token, gls.releases, gls.include_patterns, gls.exclude_patterns, fjs.id, fjs.workspace_id, fjs.base_url, fjs.project, fjs.path, fjs.ref, fjs.token, fjs.releases, fjs.include_patterns, fjs.exclude_patterns, lcs.id, lcs.workspace_id, lcs.path, lcs.include_patterns, lcs.exclude_patterns, gits.id, gits.workspace_id, gits.url, gits.ref, gits.path, gits.conventional_commits, gits.include_patterns, gits.exclude_patterns, urls.id, urls.workspace_id, urls.url, urls.manifest, s3s.id, s3s.workspace_id, s3s.endpoint, s3s.region, s3s.bucket, s3s.prefix, s3s.access_key_id, s3s.secret_access_key
 FROM changelogs c
 LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
-WHERE c.workspace_id = ?
+LEFT JOIN changelog_gl_source gls ON c.workspace_id = gls.workspace_id AND c.source_id = gls.id
+LEFT JOIN changelog_fj_source fjs ON c.workspace_id = fjs.workspace_id AND c.source_id = fjs.id
+LEFT JOIN changelog_local_source lcs ON c.workspace_id = lcs.workspace_id AND c.source_id = lcs.id
+LEFT JOIN changelog_git_source gits ON c.workspace_id = gits.workspace_id AND c.source_id = gits.id
+LEFT JOIN changelog_url_source urls ON c.workspace_id = urls.workspace_id AND c.source_id = urls.id
+LEFT JOIN changelog_s3_source s3s ON c.workspace_id = s3s.workspace_id AND c.source_id = s3s.id
 `
 
-type listChangelogsRow struct {
-	changelog       changelog
-	ChangelogSource changelogSource
+type listAllChangelogsRow struct {
+	changelog            changelog
+	ChangelogSource      changelogSource
+	ChangelogGlSource    changelogGlSource
+	ChangelogFjSource    changelogFjSource
+	ChangelogLocalSource changelogLocalSource
+	ChangelogGitSource   changelogGitSource
+	ChangelogUrlSource   changelogUrlSource
+	ChangelogS3Source    changelogS3Source
 }
 
-func (q *Queries) listChangelogs(ctx context.Context, workspaceID string) ([]listChangelogsRow, error) {
-	rows, err := q.db.QueryContext(ctx, listChangelogs, workspaceID)
+func (q *Queries) listAllChangelogs(ctx context.Context) ([]listAllChangelogsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAllChangelogs)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []listChangelogsRow
+	var items []listAllChangelogsRow
 	for rows.Next() {
-		var i listChangelogsRow
+		var i listAllChangelogsRow
 		if err := rows.Scan(
 			&i.changelog.ID,
 			&i.changelog.WorkspaceID,
@@ -442,6 +1482,56 @@ func (q *Queries) listChangelogs(ctx context.Context, workspaceID string) ([]lis
 			&i.ChangelogSource.Repo,
 			&i.ChangelogSource.Path,
 			&i.ChangelogSource.InstallationID,
+			&i.ChangelogSource.Releases,
+			&i.ChangelogSource.IncludePatterns,
+			&i.ChangelogSource.ExcludePatterns,
+			&i.ChangelogSource.BaseUrl,
+			&i.ChangelogSource.Ref,
+			&i.ChangelogGlSource.ID,
+			&i.ChangelogGlSource.WorkspaceID,
+			&i.ChangelogGlSource.BaseUrl,
+			&i.ChangelogGlSource.Project,
+			&i.ChangelogGlSource.Path,
+			&i.ChangelogGlSource.Ref,
+			&i.ChangelogGlSource.Token,
+			&i.ChangelogGlSource.Releases,
+			&i.ChangelogGlSource.IncludePatterns,
+			&i.ChangelogGlSource.ExcludePatterns,
+			&i.ChangelogFjSource.ID,
+			&i.ChangelogFjSource.WorkspaceID,
+			&i.ChangelogFjSource.BaseUrl,
+			&i.ChangelogFjSource.Project,
+			&i.ChangelogFjSource.Path,
+			&i.ChangelogFjSource.Ref,
+			&i.ChangelogFjSource.Token,
+			&i.ChangelogFjSource.Releases,
+			&i.ChangelogFjSource.IncludePatterns,
+			&i.ChangelogFjSource.ExcludePatterns,
+			&i.ChangelogLocalSource.ID,
+			&i.ChangelogLocalSource.WorkspaceID,
+			&i.ChangelogLocalSource.Path,
+			&i.ChangelogLocalSource.IncludePatterns,
+			&i.ChangelogLocalSource.ExcludePatterns,
+			&i.ChangelogGitSource.ID,
+			&i.ChangelogGitSource.WorkspaceID,
+			&i.ChangelogGitSource.Url,
+			&i.ChangelogGitSource.Ref,
+			&i.ChangelogGitSource.Path,
+			&i.ChangelogGitSource.ConventionalCommits,
+			&i.ChangelogGitSource.IncludePatterns,
+			&i.ChangelogGitSource.ExcludePatterns,
+			&i.ChangelogUrlSource.ID,
+			&i.ChangelogUrlSource.WorkspaceID,
+			&i.ChangelogUrlSource.Url,
+			&i.ChangelogUrlSource.Manifest,
+			&i.ChangelogS3Source.ID,
+			&i.ChangelogS3Source.WorkspaceID,
+			&i.ChangelogS3Source.Endpoint,
+			&i.ChangelogS3Source.Region,
+			&i.ChangelogS3Source.Bucket,
+			&i.ChangelogS3Source.Prefix,
+			&i.ChangelogS3Source.AccessKeyID,
+			&i.ChangelogS3Source.SecretAccessKey,
 		); err != nil {
 			return nil, err
 		}
@@ -456,27 +1546,26 @@ func (q *Queries) listChangelogs(ctx context.Context, workspaceID string) ([]lis
 	return items, nil
 }
 
-const listGHSources = `-- name: listGHSources :many
-SELECT id, workspace_id, owner, repo, path, installation_id FROM gh_sources
-WHERE workspace_id = ?
+const listAllWebhooks = `-- name: listAllWebhooks :many
+SELECT id, workspace_id, changelog_id, url, secret, created_at FROM webhooks
 `
 
-func (q *Queries) listGHSources(ctx context.Context, workspaceID string) ([]ghSource, error) {
-	rows, err := q.db.QueryContext(ctx, listGHSources, workspaceID)
+func (q *Queries) listAllWebhooks(ctx context.Context) ([]webhook, error) {
+	rows, err := q.db.QueryContext(ctx, listAllWebhooks)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ghSource
+	var items []webhook
 	for rows.Next() {
-		var i ghSource
+		var i webhook
 		if err := rows.Scan(
 			&i.ID,
 			&i.WorkspaceID,
-			&i.Owner,
-			&i.Repo,
-			&i.Path,
-			&i.InstallationID,
+			&i.ChangelogID,
+			&i.Url,
+			&i.Secret,
+			&i.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -491,12 +1580,747 @@ func (q *Queries) listGHSources(ctx context.Context, workspaceID string) ([]ghSo
 	return items, nil
 }
 
-const listWorkspacesChangelogCount = `-- name: listWorkspacesChangelogCount :many
-SELECT w.id, w.name, COUNT(c.id) AS changelog_count
-FROM workspaces w
-LEFT JOIN changelogs c ON w.id = c.workspace_id
-GROUP BY w.id, w.name
-ORDER BY changelog_count DESC
+const listChangelogs = `-- name: listChangelogs :many
+SELECT c.id, c.workspace_id, c.subdomain, c.title, c.subtitle, c.source_id, c.logo_src, c.logo_link, c.logo_alt, c.logo_height, c.logo_width, c.created_at, c.domain, c.color_scheme, c.hide_powered_by, c.protected, c.password_hash, c.analytics, c.searchable, c.hide_rss_icon, cs.id, cs.workspace_id, cs.owner, cs.repo, cs.path, cs.installation_id, cs.releases, cs.include_patterns, cs.exclude_patterns, cs.base_url, cs.ref, gls.id, gls.workspace_id, gls.base_url, gls.project, gls.path, gls.ref, gls.token, gls.releases, gls.include_patterns, gls.exclude_patterns, fjs.id, fjs.workspace_id, fjs.base_url, fjs.project, fjs.path, fjs.ref, fjs.token, fjs.releases, fjs.include_patterns, fjs.exclude_patterns, lcs.id, lcs.workspace_id, lcs.path, lcs.include_patterns, lcs.exclude_patterns, gits.id, gits.workspace_id, gits.url, gits.ref, gits.path, gits.conventional_commits, gits.include_patterns, gits.exclude_patterns, urls.id, urls.workspace_id, urls.url, urls.manifest, s3s.id, s3s.workspace_id, s3s.endpoint, s3s.region, s3s.bucket, s3s.prefix, s3s.access_key_id, s3s.secret_access_key
+FROM changelogs c
+LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
+LEFT JOIN changelog_gl_source gls ON c.workspace_id = gls.workspace_id AND c.source_id = gls.id
+LEFT JOIN changelog_fj_source fjs ON c.workspace_id = fjs.workspace_id AND c.source_id = fjs.id
+LEFT JOIN changelog_local_source lcs ON c.workspace_id = lcs.workspace_id AND c.source_id = lcs.id
+LEFT JOIN changelog_git_source gits ON c.workspace_id = gits.workspace_id AND c.source_id = gits.id
+LEFT JOIN changelog_url_source urls ON c.workspace_id = urls.workspace_id AND c.source_id = urls.id
+LEFT JOIN changelog_s3_source s3s ON c.workspace_id = s3s.workspace_id AND c.source_id = s3s.id
+WHERE c.workspace_id = ?
+`
+
+type listChangelogsRow struct {
+	changelog            changelog
+	ChangelogSource      changelogSource
+	ChangelogGlSource    changelogGlSource
+	ChangelogFjSource    changelogFjSource
+	ChangelogLocalSource changelogLocalSource
+	ChangelogGitSource   changelogGitSource
+	ChangelogUrlSource   changelogUrlSource
+	ChangelogS3Source    changelogS3Source
+}
+
+func (q *Queries) listChangelogs(ctx context.Context, workspaceID string) ([]listChangelogsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listChangelogs, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []listChangelogsRow
+	for rows.Next() {
+		var i listChangelogsRow
+		if err := rows.Scan(
+			&i.changelog.ID,
+			&i.changelog.WorkspaceID,
+			&i.changelog.Subdomain,
+			&i.changelog.Title,
+			&i.changelog.Subtitle,
+			&i.changelog.SourceID,
+			&i.changelog.LogoSrc,
+			&i.changelog.LogoLink,
+			&i.changelog.LogoAlt,
+			&i.changelog.LogoHeight,
+			&i.changelog.LogoWidth,
+			&i.changelog.CreatedAt,
+			&i.changelog.Domain,
+			&i.changelog.ColorScheme,
+			&i.changelog.HidePoweredBy,
+			&i.changelog.Protected,
+			&i.changelog.PasswordHash,
+			&i.changelog.Analytics,
+			&i.changelog.Searchable,
+			&i.changelog.HideRssIcon,
+			&i.ChangelogSource.ID,
+			&i.ChangelogSource.WorkspaceID,
+			&i.ChangelogSource.Owner,
+			&i.ChangelogSource.Repo,
+			&i.ChangelogSource.Path,
+			&i.ChangelogSource.InstallationID,
+			&i.ChangelogSource.Releases,
+			&i.ChangelogSource.IncludePatterns,
+			&i.ChangelogSource.ExcludePatterns,
+			&i.ChangelogSource.BaseUrl,
+			&i.ChangelogSource.Ref,
+			&i.ChangelogGlSource.ID,
+			&i.ChangelogGlSource.WorkspaceID,
+			&i.ChangelogGlSource.BaseUrl,
+			&i.ChangelogGlSource.Project,
+			&i.ChangelogGlSource.Path,
+			&i.ChangelogGlSource.Ref,
+			&i.ChangelogGlSource.Token,
+			&i.ChangelogGlSource.Releases,
+			&i.ChangelogGlSource.IncludePatterns,
+			&i.ChangelogGlSource.ExcludePatterns,
+			&i.ChangelogFjSource.ID,
+			&i.ChangelogFjSource.WorkspaceID,
+			&i.ChangelogFjSource.BaseUrl,
+			&i.ChangelogFjSource.Project,
+			&i.ChangelogFjSource.Path,
+			&i.ChangelogFjSource.Ref,
+			&i.ChangelogFjSource.Token,
+			&i.ChangelogFjSource.Releases,
+			&i.ChangelogFjSource.IncludePatterns,
+			&i.ChangelogFjSource.ExcludePatterns,
+			&i.ChangelogLocalSource.ID,
+			&i.ChangelogLocalSource.WorkspaceID,
+			&i.ChangelogLocalSource.Path,
+			&i.ChangelogLocalSource.IncludePatterns,
+			&i.ChangelogLocalSource.ExcludePatterns,
+			&i.ChangelogGitSource.ID,
+			&i.ChangelogGitSource.WorkspaceID,
+			&i.ChangelogGitSource.Url,
+			&i.ChangelogGitSource.Ref,
+			&i.ChangelogGitSource.Path,
+			&i.ChangelogGitSource.ConventionalCommits,
+			&i.ChangelogGitSource.IncludePatterns,
+			&i.ChangelogGitSource.ExcludePatterns,
+			&i.ChangelogUrlSource.ID,
+			&i.ChangelogUrlSource.WorkspaceID,
+			&i.ChangelogUrlSource.Url,
+			&i.ChangelogUrlSource.Manifest,
+			&i.ChangelogS3Source.ID,
+			&i.ChangelogS3Source.WorkspaceID,
+			&i.ChangelogS3Source.Endpoint,
+			&i.ChangelogS3Source.Region,
+			&i.ChangelogS3Source.Bucket,
+			&i.ChangelogS3Source.Prefix,
+			&i.ChangelogS3Source.AccessKeyID,
+			&i.ChangelogS3Source.SecretAccessKey,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCombinedSourceIDs = `-- name: listCombinedSourceIDs :many
+SELECT source_id FROM combined_sources
+WHERE workspace_id = ? AND changelog_id = ?
+ORDER BY position
+`
+
+type listCombinedSourceIDsParams struct {
+	WorkspaceID string
+	ChangelogID string
+}
+
+func (q *Queries) listCombinedSourceIDs(ctx context.Context, arg listCombinedSourceIDsParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listCombinedSourceIDs, arg.WorkspaceID, arg.ChangelogID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var source_id string
+		if err := rows.Scan(&source_id); err != nil {
+			return nil, err
+		}
+		items = append(items, source_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listConfirmedSubscribers = `-- name: listConfirmedSubscribers :many
+SELECT id, workspace_id, changelog_id, email, changelog_url, confirm_token, confirmed_at, created_at FROM subscribers
+WHERE workspace_id = ? AND changelog_id = ? AND confirmed_at IS NOT NULL
+`
+
+type listConfirmedSubscribersParams struct {
+	WorkspaceID string
+	ChangelogID string
+}
+
+func (q *Queries) listConfirmedSubscribers(ctx context.Context, arg listConfirmedSubscribersParams) ([]subscriber, error) {
+	rows, err := q.db.QueryContext(ctx, listConfirmedSubscribers, arg.WorkspaceID, arg.ChangelogID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []subscriber
+	for rows.Next() {
+		var i subscriber
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.ChangelogID,
+			&i.Email,
+			&i.ChangelogUrl,
+			&i.ConfirmToken,
+			&i.ConfirmedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDeliveredReleaseNotes = `-- name: listDeliveredReleaseNotes :many
+SELECT DISTINCT release_note_id FROM webhook_deliveries
+WHERE webhook_id = ?
+`
+
+func (q *Queries) listDeliveredReleaseNotes(ctx context.Context, webhookID string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listDeliveredReleaseNotes, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var release_note_id string
+		if err := rows.Scan(&release_note_id); err != nil {
+			return nil, err
+		}
+		items = append(items, release_note_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDispatchedReleaseNotes = `-- name: listDispatchedReleaseNotes :many
+SELECT release_note_id FROM dispatched_release_notes
+WHERE workspace_id = ? AND changelog_id = ?
+`
+
+type listDispatchedReleaseNotesParams struct {
+	WorkspaceID string
+	ChangelogID string
+}
+
+func (q *Queries) listDispatchedReleaseNotes(ctx context.Context, arg listDispatchedReleaseNotesParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listDispatchedReleaseNotes, arg.WorkspaceID, arg.ChangelogID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var release_note_id string
+		if err := rows.Scan(&release_note_id); err != nil {
+			return nil, err
+		}
+		items = append(items, release_note_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFJSources = `-- name: listFJSources :many
+SELECT id, workspace_id, base_url, project, path, ref, token, releases, include_patterns, exclude_patterns FROM fj_sources
+WHERE workspace_id = ?
+`
+
+func (q *Queries) listFJSources(ctx context.Context, workspaceID string) ([]fjSource, error) {
+	rows, err := q.db.QueryContext(ctx, listFJSources, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []fjSource
+	for rows.Next() {
+		var i fjSource
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.BaseUrl,
+			&i.Project,
+			&i.Path,
+			&i.Ref,
+			&i.Token,
+			&i.Releases,
+			&i.IncludePatterns,
+			&i.ExcludePatterns,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGHInstallations = `-- name: listGHInstallations :many
+SELECT workspace_id, installation_id, account, created_at FROM gh_installations
+WHERE workspace_id = ?
+ORDER BY created_at
+`
+
+func (q *Queries) listGHInstallations(ctx context.Context, workspaceID string) ([]ghInstallation, error) {
+	rows, err := q.db.QueryContext(ctx, listGHInstallations, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ghInstallation
+	for rows.Next() {
+		var i ghInstallation
+		if err := rows.Scan(
+			&i.WorkspaceID,
+			&i.InstallationID,
+			&i.Account,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGHSources = `-- name: listGHSources :many
+SELECT id, workspace_id, owner, repo, path, installation_id, releases, include_patterns, exclude_patterns, base_url, ref FROM gh_sources
+WHERE workspace_id = ?
+`
+
+func (q *Queries) listGHSources(ctx context.Context, workspaceID string) ([]ghSource, error) {
+	rows, err := q.db.QueryContext(ctx, listGHSources, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ghSource
+	for rows.Next() {
+		var i ghSource
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.Owner,
+			&i.Repo,
+			&i.Path,
+			&i.InstallationID,
+			&i.Releases,
+			&i.IncludePatterns,
+			&i.ExcludePatterns,
+			&i.BaseUrl,
+			&i.Ref,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGHSourcesByInstallation = `-- name: listGHSourcesByInstallation :many
+SELECT id, workspace_id, owner, repo, path, installation_id, releases, include_patterns, exclude_patterns, base_url, ref FROM gh_sources
+WHERE installation_id = ?
+`
+
+func (q *Queries) listGHSourcesByInstallation(ctx context.Context, installationID int64) ([]ghSource, error) {
+	rows, err := q.db.QueryContext(ctx, listGHSourcesByInstallation, installationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ghSource
+	for rows.Next() {
+		var i ghSource
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.Owner,
+			&i.Repo,
+			&i.Path,
+			&i.InstallationID,
+			&i.Releases,
+			&i.IncludePatterns,
+			&i.ExcludePatterns,
+			&i.BaseUrl,
+			&i.Ref,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGLSources = `-- name: listGLSources :many
+SELECT id, workspace_id, base_url, project, path, ref, token, releases, include_patterns, exclude_patterns FROM gl_sources
+WHERE workspace_id = ?
+`
+
+func (q *Queries) listGLSources(ctx context.Context, workspaceID string) ([]glSource, error) {
+	rows, err := q.db.QueryContext(ctx, listGLSources, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []glSource
+	for rows.Next() {
+		var i glSource
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.BaseUrl,
+			&i.Project,
+			&i.Path,
+			&i.Ref,
+			&i.Token,
+			&i.Releases,
+			&i.IncludePatterns,
+			&i.ExcludePatterns,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGitSources = `-- name: listGitSources :many
+SELECT id, workspace_id, url, ref, path, conventional_commits, include_patterns, exclude_patterns FROM git_sources
+WHERE workspace_id = ?
+`
+
+func (q *Queries) listGitSources(ctx context.Context, workspaceID string) ([]gitSource, error) {
+	rows, err := q.db.QueryContext(ctx, listGitSources, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []gitSource
+	for rows.Next() {
+		var i gitSource
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.Url,
+			&i.Ref,
+			&i.Path,
+			&i.ConventionalCommits,
+			&i.IncludePatterns,
+			&i.ExcludePatterns,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLocalSources = `-- name: listLocalSources :many
+SELECT id, workspace_id, path, include_patterns, exclude_patterns FROM local_sources
+WHERE workspace_id = ?
+`
+
+func (q *Queries) listLocalSources(ctx context.Context, workspaceID string) ([]localSource, error) {
+	rows, err := q.db.QueryContext(ctx, listLocalSources, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []localSource
+	for rows.Next() {
+		var i localSource
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.Path,
+			&i.IncludePatterns,
+			&i.ExcludePatterns,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listS3Sources = `-- name: listS3Sources :many
+SELECT id, workspace_id, endpoint, region, bucket, prefix, access_key_id, secret_access_key FROM s3_sources
+WHERE workspace_id = ?
+`
+
+func (q *Queries) listS3Sources(ctx context.Context, workspaceID string) ([]s3Source, error) {
+	rows, err := q.db.QueryContext(ctx, listS3Sources, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []s3Source
+	for rows.Next() {
+		var i s3Source
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.Endpoint,
+			&i.Region,
+			&i.Bucket,
+			&i.Prefix,
+			&i.AccessKeyID,
+			&i.SecretAccessKey,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listURLSources = `-- name: listURLSources :many
+SELECT id, workspace_id, url, manifest FROM url_sources
+WHERE workspace_id = ?
+`
+
+func (q *Queries) listURLSources(ctx context.Context, workspaceID string) ([]urlSource, error) {
+	rows, err := q.db.QueryContext(ctx, listURLSources, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []urlSource
+	for rows.Next() {
+		var i urlSource
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.Url,
+			&i.Manifest,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookDeliveries = `-- name: listWebhookDeliveries :many
+SELECT id, webhook_id, release_note_id, event, attempt, status_code, error, created_at FROM webhook_deliveries
+WHERE webhook_id = ?
+ORDER BY created_at DESC, id DESC
+LIMIT ?
+`
+
+type listWebhookDeliveriesParams struct {
+	WebhookID string
+	Limit     int64
+}
+
+func (q *Queries) listWebhookDeliveries(ctx context.Context, arg listWebhookDeliveriesParams) ([]webhookDelivery, error) {
+	rows, err := q.db.QueryContext(ctx, listWebhookDeliveries, arg.WebhookID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []webhookDelivery
+	for rows.Next() {
+		var i webhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.WebhookID,
+			&i.ReleaseNoteID,
+			&i.Event,
+			&i.Attempt,
+			&i.StatusCode,
+			&i.Error,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookSkippedReleaseNotes = `-- name: listWebhookSkippedReleaseNotes :many
+SELECT release_note_id FROM webhook_skipped_release_notes
+WHERE webhook_id = ?
+`
+
+func (q *Queries) listWebhookSkippedReleaseNotes(ctx context.Context, webhookID string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listWebhookSkippedReleaseNotes, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var release_note_id string
+		if err := rows.Scan(&release_note_id); err != nil {
+			return nil, err
+		}
+		items = append(items, release_note_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookSources = `-- name: listWebhookSources :many
+SELECT source_id FROM webhook_sources
+WHERE webhook_id = ?
+`
+
+func (q *Queries) listWebhookSources(ctx context.Context, webhookID string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listWebhookSources, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var source_id string
+		if err := rows.Scan(&source_id); err != nil {
+			return nil, err
+		}
+		items = append(items, source_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhooks = `-- name: listWebhooks :many
+SELECT id, workspace_id, changelog_id, url, secret, created_at FROM webhooks
+WHERE workspace_id = ? AND changelog_id = ?
+ORDER BY created_at
+`
+
+type listWebhooksParams struct {
+	WorkspaceID string
+	ChangelogID string
+}
+
+func (q *Queries) listWebhooks(ctx context.Context, arg listWebhooksParams) ([]webhook, error) {
+	rows, err := q.db.QueryContext(ctx, listWebhooks, arg.WorkspaceID, arg.ChangelogID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []webhook
+	for rows.Next() {
+		var i webhook
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.ChangelogID,
+			&i.Url,
+			&i.Secret,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWorkspacesChangelogCount = `-- name: listWorkspacesChangelogCount :many
+SELECT w.id, w.name, COUNT(c.id) AS changelog_count
+FROM workspaces w
+LEFT JOIN changelogs c ON w.id = c.workspace_id
+GROUP BY w.id, w.name
+ORDER BY changelog_count DESC
 `
 
 type listWorkspacesChangelogCountRow struct {
@@ -527,6 +2351,32 @@ func (q *Queries) listWorkspacesChangelogCount(ctx context.Context) ([]listWorks
 	return items, nil
 }
 
+const saveGHInstallation = `-- name: saveGHInstallation :one
+INSERT INTO gh_installations (
+    workspace_id, installation_id, account
+) VALUES (?, ?, ?)
+ON CONFLICT (workspace_id, installation_id) DO UPDATE SET account = excluded.account
+RETURNING workspace_id, installation_id, account, created_at
+`
+
+type saveGHInstallationParams struct {
+	WorkspaceID    string
+	InstallationID int64
+	Account        string
+}
+
+func (q *Queries) saveGHInstallation(ctx context.Context, arg saveGHInstallationParams) (ghInstallation, error) {
+	row := q.db.QueryRowContext(ctx, saveGHInstallation, arg.WorkspaceID, arg.InstallationID, arg.Account)
+	var i ghInstallation
+	err := row.Scan(
+		&i.WorkspaceID,
+		&i.InstallationID,
+		&i.Account,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const saveWorkspace = `-- name: saveWorkspace :one
 INSERT INTO workspaces (
     id, name
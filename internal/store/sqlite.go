@@ -14,7 +14,15 @@ import (
 	"github.com/guregu/null/v5"
 )
 
-func (cl changelog) toExported(source changelogSource) Changelog {
+func (cl changelog) toExported(
+	source changelogSource,
+	gl changelogGlSource,
+	fj changelogFjSource,
+	lc changelogLocalSource,
+	git changelogGitSource,
+	u changelogUrlSource,
+	s3 changelogS3Source,
+) Changelog {
 	c := Changelog{
 		WorkspaceID:   WorkspaceID(cl.WorkspaceID),
 		ID:            ChangelogID(cl.ID),
@@ -46,6 +54,86 @@ func (cl changelog) toExported(source changelogSource) Changelog {
 			Repo:           source.Repo.V(),
 			Path:           source.Path.V(),
 			InstallationID: source.InstallationID.Int64,
+			BaseURL:        source.BaseUrl.V(),
+			Ref:            source.Ref.V(),
+			Releases:       source.Releases.Int64 == 1,
+			Include:        splitPatterns(source.IncludePatterns.V()),
+			Exclude:        splitPatterns(source.ExcludePatterns.V()),
+		}, true)
+	}
+
+	if gl.ID.IsValid() && gl.WorkspaceID.IsValid() {
+		c.GLSource = null.NewValue(GLSource{
+			ID:          GLSourceID(gl.ID.V()),
+			WorkspaceID: WorkspaceID(gl.WorkspaceID.V()),
+			BaseURL:     gl.BaseUrl.V(),
+			Project:     gl.Project.V(),
+			Path:        gl.Path.V(),
+			Ref:         gl.Ref.V(),
+			Token:       gl.Token.V(),
+			Releases:    gl.Releases.Int64 == 1,
+			Include:     splitPatterns(gl.IncludePatterns.V()),
+			Exclude:     splitPatterns(gl.ExcludePatterns.V()),
+		}, true)
+	}
+
+	if fj.ID.IsValid() && fj.WorkspaceID.IsValid() {
+		c.FJSource = null.NewValue(FJSource{
+			ID:          FJSourceID(fj.ID.V()),
+			WorkspaceID: WorkspaceID(fj.WorkspaceID.V()),
+			BaseURL:     fj.BaseUrl.V(),
+			Project:     fj.Project.V(),
+			Path:        fj.Path.V(),
+			Ref:         fj.Ref.V(),
+			Token:       fj.Token.V(),
+			Releases:    fj.Releases.Int64 == 1,
+			Include:     splitPatterns(fj.IncludePatterns.V()),
+			Exclude:     splitPatterns(fj.ExcludePatterns.V()),
+		}, true)
+	}
+
+	if lc.ID.IsValid() && lc.WorkspaceID.IsValid() {
+		c.LocalSource = null.NewValue(LocalSource{
+			ID:          LocalSourceID(lc.ID.V()),
+			WorkspaceID: WorkspaceID(lc.WorkspaceID.V()),
+			Path:        lc.Path.V(),
+			Include:     splitPatterns(lc.IncludePatterns.V()),
+			Exclude:     splitPatterns(lc.ExcludePatterns.V()),
+		}, true)
+	}
+
+	if git.ID.IsValid() && git.WorkspaceID.IsValid() {
+		c.GitSource = null.NewValue(GitSource{
+			ID:                  GitSourceID(git.ID.V()),
+			WorkspaceID:         WorkspaceID(git.WorkspaceID.V()),
+			URL:                 git.Url.V(),
+			Ref:                 git.Ref.V(),
+			Path:                git.Path.V(),
+			ConventionalCommits: git.ConventionalCommits.Int64 == 1,
+			Include:             splitPatterns(git.IncludePatterns.V()),
+			Exclude:             splitPatterns(git.ExcludePatterns.V()),
+		}, true)
+	}
+
+	if u.ID.IsValid() && u.WorkspaceID.IsValid() {
+		c.URLSource = null.NewValue(URLSource{
+			ID:          URLSourceID(u.ID.V()),
+			WorkspaceID: WorkspaceID(u.WorkspaceID.V()),
+			URL:         u.Url.V(),
+			Manifest:    u.Manifest.Int64 == 1,
+		}, true)
+	}
+
+	if s3.ID.IsValid() && s3.WorkspaceID.IsValid() {
+		c.S3Source = null.NewValue(S3Source{
+			ID:              S3SourceID(s3.ID.V()),
+			WorkspaceID:     WorkspaceID(s3.WorkspaceID.V()),
+			Endpoint:        s3.Endpoint.V(),
+			Region:          s3.Region.V(),
+			Bucket:          s3.Bucket.V(),
+			Prefix:          s3.Prefix.V(),
+			AccessKeyID:     s3.AccessKeyID.V(),
+			SecretAccessKey: s3.SecretAccessKey.V(),
 		}, true)
 	}
 	return c
@@ -59,6 +147,126 @@ func (gh ghSource) toExported() GHSource {
 		Repo:           gh.Repo,
 		Path:           gh.Path,
 		InstallationID: gh.InstallationID,
+		BaseURL:        gh.BaseUrl,
+		Ref:            gh.Ref,
+		Releases:       gh.Releases == 1,
+		Include:        splitPatterns(gh.IncludePatterns),
+		Exclude:        splitPatterns(gh.ExcludePatterns),
+	}
+}
+
+func (gl glSource) toExported() GLSource {
+	return GLSource{
+		ID:          GLSourceID(gl.ID),
+		WorkspaceID: WorkspaceID(gl.WorkspaceID),
+		BaseURL:     gl.BaseUrl,
+		Project:     gl.Project,
+		Path:        gl.Path,
+		Ref:         gl.Ref,
+		Token:       gl.Token,
+		Releases:    gl.Releases == 1,
+		Include:     splitPatterns(gl.IncludePatterns),
+		Exclude:     splitPatterns(gl.ExcludePatterns),
+	}
+}
+
+func (fj fjSource) toExported() FJSource {
+	return FJSource{
+		ID:          FJSourceID(fj.ID),
+		WorkspaceID: WorkspaceID(fj.WorkspaceID),
+		BaseURL:     fj.BaseUrl,
+		Project:     fj.Project,
+		Path:        fj.Path,
+		Ref:         fj.Ref,
+		Token:       fj.Token,
+		Releases:    fj.Releases == 1,
+		Include:     splitPatterns(fj.IncludePatterns),
+		Exclude:     splitPatterns(fj.ExcludePatterns),
+	}
+}
+
+func (lc localSource) toExported() LocalSource {
+	return LocalSource{
+		ID:          LocalSourceID(lc.ID),
+		WorkspaceID: WorkspaceID(lc.WorkspaceID),
+		Path:        lc.Path,
+		Include:     splitPatterns(lc.IncludePatterns),
+		Exclude:     splitPatterns(lc.ExcludePatterns),
+	}
+}
+
+func (git gitSource) toExported() GitSource {
+	return GitSource{
+		ID:                  GitSourceID(git.ID),
+		WorkspaceID:         WorkspaceID(git.WorkspaceID),
+		URL:                 git.Url,
+		Ref:                 git.Ref,
+		Path:                git.Path,
+		ConventionalCommits: git.ConventionalCommits == 1,
+		Include:             splitPatterns(git.IncludePatterns),
+		Exclude:             splitPatterns(git.ExcludePatterns),
+	}
+}
+
+func (u urlSource) toExported() URLSource {
+	return URLSource{
+		ID:          URLSourceID(u.ID),
+		WorkspaceID: WorkspaceID(u.WorkspaceID),
+		URL:         u.Url,
+		Manifest:    u.Manifest == 1,
+	}
+}
+
+func (s3 s3Source) toExported() S3Source {
+	return S3Source{
+		ID:              S3SourceID(s3.ID),
+		WorkspaceID:     WorkspaceID(s3.WorkspaceID),
+		Endpoint:        s3.Endpoint,
+		Region:          s3.Region,
+		Bucket:          s3.Bucket,
+		Prefix:          s3.Prefix,
+		AccessKeyID:     s3.AccessKeyID,
+		SecretAccessKey: s3.SecretAccessKey,
+	}
+}
+
+func (sub subscriber) toExported() Subscriber {
+	s := Subscriber{
+		ID:           SubscriberID(sub.ID),
+		WorkspaceID:  WorkspaceID(sub.WorkspaceID),
+		ChangelogID:  ChangelogID(sub.ChangelogID),
+		Email:        sub.Email,
+		ChangelogURL: sub.ChangelogUrl,
+		ConfirmToken: sub.ConfirmToken.V(),
+		CreatedAt:    time.Unix(sub.CreatedAt, 0),
+	}
+	if sub.ConfirmedAt.Valid {
+		s.ConfirmedAt = time.Unix(sub.ConfirmedAt.Int64, 0)
+	}
+	return s
+}
+
+func (wh webhook) toExported() Webhook {
+	return Webhook{
+		ID:          WebhookID(wh.ID),
+		WorkspaceID: WorkspaceID(wh.WorkspaceID),
+		ChangelogID: ChangelogID(wh.ChangelogID),
+		URL:         wh.Url,
+		Secret:      wh.Secret,
+		CreatedAt:   time.Unix(wh.CreatedAt, 0),
+	}
+}
+
+func (d webhookDelivery) toExported() WebhookDelivery {
+	return WebhookDelivery{
+		ID:            WebhookDeliveryID(d.ID),
+		WebhookID:     WebhookID(d.WebhookID),
+		ReleaseNoteID: d.ReleaseNoteID,
+		Event:         d.Event,
+		Attempt:       int(d.Attempt),
+		StatusCode:    int(d.StatusCode),
+		Error:         d.Error.V(),
+		CreatedAt:     time.Unix(d.CreatedAt, 0),
 	}
 }
 
@@ -107,7 +315,7 @@ func (s *sqlite) CreateChangelog(ctx context.Context, cl Changelog) (Changelog,
 	}
 
 	// TODO get source
-	return c.toExported(changelogSource{}), nil
+	return c.toExported(changelogSource{}, changelogGlSource{}, changelogFjSource{}, changelogLocalSource{}, changelogGitSource{}, changelogUrlSource{}, changelogS3Source{}), nil
 }
 
 var errNoChangelog = errs.NewError(errs.ErrNotFound, errors.New("changelog not found"))
@@ -124,7 +332,7 @@ func (s *sqlite) GetChangelog(ctx context.Context, wID WorkspaceID, cID Changelo
 		return Changelog{}, err
 	}
 
-	return cl.changelog.toExported(cl.ChangelogSource), nil
+	return s.withCombinedSources(ctx, cl.changelog.toExported(cl.ChangelogSource, cl.ChangelogGlSource, cl.ChangelogFjSource, cl.ChangelogLocalSource, cl.ChangelogGitSource, cl.ChangelogUrlSource, cl.ChangelogS3Source))
 }
 
 func (s *sqlite) GetChangelogByDomainOrSubdomain(ctx context.Context, domain Domain, subdomain Subdomain) (Changelog, error) {
@@ -139,7 +347,7 @@ func (s *sqlite) GetChangelogByDomainOrSubdomain(ctx context.Context, domain Dom
 		return Changelog{}, err
 	}
 
-	return cl.changelog.toExported(cl.ChangelogSource), nil
+	return s.withCombinedSources(ctx, cl.changelog.toExported(cl.ChangelogSource, cl.ChangelogGlSource, cl.ChangelogFjSource, cl.ChangelogLocalSource, cl.ChangelogGitSource, cl.ChangelogUrlSource, cl.ChangelogS3Source))
 }
 
 func (s *sqlite) ListChangelogs(ctx context.Context, wID WorkspaceID) ([]Changelog, error) {
@@ -153,7 +361,29 @@ func (s *sqlite) ListChangelogs(ctx context.Context, wID WorkspaceID) ([]Changel
 
 	res := make([]Changelog, len(cls))
 	for i, cl := range cls {
-		res[i] = cl.changelog.toExported(cl.ChangelogSource)
+		res[i], err = s.withCombinedSources(ctx, cl.changelog.toExported(cl.ChangelogSource, cl.ChangelogGlSource, cl.ChangelogFjSource, cl.ChangelogLocalSource, cl.ChangelogGitSource, cl.ChangelogUrlSource, cl.ChangelogS3Source))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (s *sqlite) ListAllChangelogs(ctx context.Context) ([]Changelog, error) {
+	cls, err := s.q.listAllChangelogs(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return make([]Changelog, 0), nil
+		}
+		return nil, err
+	}
+
+	res := make([]Changelog, len(cls))
+	for i, cl := range cls {
+		res[i], err = s.withCombinedSources(ctx, cl.changelog.toExported(cl.ChangelogSource, cl.ChangelogGlSource, cl.ChangelogFjSource, cl.ChangelogLocalSource, cl.ChangelogGitSource, cl.ChangelogUrlSource, cl.ChangelogS3Source))
+		if err != nil {
+			return nil, err
+		}
 	}
 	return res, nil
 }
@@ -175,6 +405,20 @@ func boolToInt(b bool) int64 {
 	return i
 }
 
+// Joins the glob patterns to store them comma separated,
+// patterns can't contain commas, see source.ValidatePatterns.
+func joinPatterns(patterns []string) string {
+	return strings.Join(patterns, ",")
+}
+
+// Splits the comma separated glob patterns stored by joinPatterns.
+func splitPatterns(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
 func (s *sqlite) UpdateChangelog(ctx context.Context, wID WorkspaceID, cID ChangelogID, args UpdateChangelogArgs) (Changelog, error) {
 	// does not update string fields if they are zero value
 	_, err := s.q.updateChangelog(ctx, updateChangelogParams{
@@ -240,6 +484,9 @@ func formatUnqueConstraint(err error) error {
 	if strings.Contains(err.Error(), "UNIQUE constraint failed: changelogs.domain") {
 		return errs.NewBadRequest(errors.New("domain already taken, please try again with a different one"))
 	}
+	if strings.Contains(err.Error(), "UNIQUE constraint failed: combined_sources") {
+		return errs.NewBadRequest(errors.New("a source can only be added once to a changelog"))
+	}
 	return err
 }
 
@@ -251,18 +498,169 @@ func (s *sqlite) DeleteChangelog(ctx context.Context, wID WorkspaceID, cID Chang
 }
 
 func (s *sqlite) SetChangelogGHSource(ctx context.Context, wID WorkspaceID, cID ChangelogID, ghID GHSourceID) error {
-	return s.q.setChangelogSource(ctx, setChangelogSourceParams{
-		SourceID:    apitypes.NewString(ghID.String()),
+	return s.SetChangelogSources(ctx, wID, cID, []string{ghID.String()})
+}
+
+func (s *sqlite) SetChangelogGLSource(ctx context.Context, wID WorkspaceID, cID ChangelogID, glID GLSourceID) error {
+	return s.SetChangelogSources(ctx, wID, cID, []string{glID.String()})
+}
+
+func (s *sqlite) SetChangelogFJSource(ctx context.Context, wID WorkspaceID, cID ChangelogID, fjID FJSourceID) error {
+	return s.SetChangelogSources(ctx, wID, cID, []string{fjID.String()})
+}
+
+func (s *sqlite) SetChangelogLocalSource(ctx context.Context, wID WorkspaceID, cID ChangelogID, lcID LocalSourceID) error {
+	return s.SetChangelogSources(ctx, wID, cID, []string{lcID.String()})
+}
+
+func (s *sqlite) SetChangelogGitSource(ctx context.Context, wID WorkspaceID, cID ChangelogID, gitID GitSourceID) error {
+	return s.SetChangelogSources(ctx, wID, cID, []string{gitID.String()})
+}
+
+func (s *sqlite) SetChangelogURLSource(ctx context.Context, wID WorkspaceID, cID ChangelogID, uID URLSourceID) error {
+	return s.SetChangelogSources(ctx, wID, cID, []string{uID.String()})
+}
+
+func (s *sqlite) SetChangelogS3Source(ctx context.Context, wID WorkspaceID, cID ChangelogID, s3ID S3SourceID) error {
+	return s.SetChangelogSources(ctx, wID, cID, []string{s3ID.String()})
+}
+
+// The first source is the source of the changelog, the ordered list is only stored if multiple sources are combined.
+func (s *sqlite) SetChangelogSources(ctx context.Context, wID WorkspaceID, cID ChangelogID, sourceIDs []string) error {
+	if len(sourceIDs) == 0 {
+		return s.DeleteChangelogSource(ctx, wID, cID)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	q := s.q.WithTx(tx)
+
+	err = q.deleteCombinedSources(ctx, deleteCombinedSourcesParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = q.setChangelogSource(ctx, setChangelogSourceParams{
+		SourceID:    apitypes.NewString(sourceIDs[0]),
 		WorkspaceID: wID.String(),
 		ID:          cID.String(),
 	})
+	if err != nil {
+		return err
+	}
+
+	if len(sourceIDs) > 1 {
+		for i, id := range sourceIDs {
+			err = q.createCombinedSource(ctx, createCombinedSourceParams{
+				WorkspaceID: wID.String(),
+				ChangelogID: cID.String(),
+				SourceID:    id,
+				Position:    int64(i),
+			})
+			if err != nil {
+				return formatUnqueConstraint(err)
+			}
+		}
+	}
+	return tx.Commit()
 }
 
 func (s *sqlite) DeleteChangelogSource(ctx context.Context, wID WorkspaceID, cID ChangelogID) error {
-	return s.q.deleteChangelogSource(ctx, deleteChangelogSourceParams{
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	q := s.q.WithTx(tx)
+
+	err = q.deleteCombinedSources(ctx, deleteCombinedSourcesParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = q.deleteChangelogSource(ctx, deleteChangelogSourceParams{
 		WorkspaceID: wID.String(),
 		ID:          cID.String(),
 	})
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Sets the sources of cl if it combines multiple sources.
+func (s *sqlite) withCombinedSources(ctx context.Context, cl Changelog) (Changelog, error) {
+	ids, err := s.q.listCombinedSourceIDs(ctx, listCombinedSourceIDsParams{
+		WorkspaceID: cl.WorkspaceID.String(),
+		ChangelogID: cl.ID.String(),
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return Changelog{}, err
+	}
+
+	for _, id := range ids {
+		cs, found, err := s.getChangelogSource(ctx, cl.WorkspaceID, id)
+		if err != nil {
+			return Changelog{}, err
+		}
+		// sources deleted after they were combined are skipped
+		if found {
+			cl.Sources = append(cl.Sources, cs)
+		}
+	}
+	return cl, nil
+}
+
+// Returns the source with the id, false if it doesn't exist.
+func (s *sqlite) getChangelogSource(ctx context.Context, wID WorkspaceID, id string) (ChangelogSource, bool, error) {
+	var cs ChangelogSource
+	var err error
+	switch {
+	case IsGHID(id):
+		var row ghSource
+		row, err = s.q.getGHSource(ctx, getGHSourceParams{WorkspaceID: wID.String(), ID: id})
+		cs.GHSource = null.NewValue(row.toExported(), err == nil)
+	case IsGLID(id):
+		var row glSource
+		row, err = s.q.getGLSource(ctx, getGLSourceParams{WorkspaceID: wID.String(), ID: id})
+		cs.GLSource = null.NewValue(row.toExported(), err == nil)
+	case IsFJID(id):
+		var row fjSource
+		row, err = s.q.getFJSource(ctx, getFJSourceParams{WorkspaceID: wID.String(), ID: id})
+		cs.FJSource = null.NewValue(row.toExported(), err == nil)
+	case IsLocalID(id):
+		var row localSource
+		row, err = s.q.getLocalSource(ctx, getLocalSourceParams{WorkspaceID: wID.String(), ID: id})
+		cs.LocalSource = null.NewValue(row.toExported(), err == nil)
+	case IsGitID(id):
+		var row gitSource
+		row, err = s.q.getGitSource(ctx, getGitSourceParams{WorkspaceID: wID.String(), ID: id})
+		cs.GitSource = null.NewValue(row.toExported(), err == nil)
+	case IsURLID(id):
+		var row urlSource
+		row, err = s.q.getURLSource(ctx, getURLSourceParams{WorkspaceID: wID.String(), ID: id})
+		cs.URLSource = null.NewValue(row.toExported(), err == nil)
+	case IsS3ID(id):
+		var row s3Source
+		row, err = s.q.getS3Source(ctx, getS3SourceParams{WorkspaceID: wID.String(), ID: id})
+		cs.S3Source = null.NewValue(row.toExported(), err == nil)
+	default:
+		return cs, false, nil
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return cs, false, nil
+	}
+	return cs, err == nil, err
 }
 
 func (s *sqlite) SaveWorkspace(ctx context.Context, ws Workspace) (Workspace, error) {
@@ -332,12 +730,17 @@ func (s *sqlite) DeleteWorkspace(ctx context.Context, wID WorkspaceID) error {
 
 func (s *sqlite) CreateGHSource(ctx context.Context, gh GHSource) (GHSource, error) {
 	row, err := s.q.createGHSource(ctx, createGHSourceParams{
-		WorkspaceID:    gh.WorkspaceID.String(),
-		ID:             gh.ID.String(),
-		Owner:          gh.Owner,
-		Repo:           gh.Repo,
-		Path:           gh.Path,
-		InstallationID: gh.InstallationID,
+		WorkspaceID:     gh.WorkspaceID.String(),
+		ID:              gh.ID.String(),
+		Owner:           gh.Owner,
+		Repo:            gh.Repo,
+		Path:            gh.Path,
+		InstallationID:  gh.InstallationID,
+		Releases:        boolToInt(gh.Releases),
+		IncludePatterns: joinPatterns(gh.Include),
+		ExcludePatterns: joinPatterns(gh.Exclude),
+		BaseUrl:         gh.BaseURL,
+		Ref:             gh.Ref,
 	})
 	if err != nil {
 		return GHSource{}, err
@@ -379,23 +782,712 @@ func (s *sqlite) ListGHSources(ctx context.Context, wID WorkspaceID) ([]GHSource
 	return sources, nil
 }
 
-func (s *sqlite) ListWorkspacesChangelogCount(ctx context.Context) ([]WorkspaceChangelogCount, error) {
-	rows, err := s.q.listWorkspacesChangelogCount(ctx)
+func (i ghInstallation) toExported() GHInstallation {
+	return GHInstallation{
+		WorkspaceID: WorkspaceID(i.WorkspaceID),
+		ID:          i.InstallationID,
+		Account:     i.Account,
+		CreatedAt:   time.Unix(i.CreatedAt, 0),
+	}
+}
+
+func (s *sqlite) SaveGHInstallation(ctx context.Context, i GHInstallation) (GHInstallation, error) {
+	row, err := s.q.saveGHInstallation(ctx, saveGHInstallationParams{
+		WorkspaceID:    i.WorkspaceID.String(),
+		InstallationID: i.ID,
+		Account:        i.Account,
+	})
+	if err != nil {
+		return GHInstallation{}, err
+	}
+	return row.toExported(), nil
+}
+
+func (s *sqlite) GetGHInstallation(ctx context.Context, wID WorkspaceID, installationID int64) (GHInstallation, error) {
+	row, err := s.q.getGHInstallation(ctx, getGHInstallationParams{
+		WorkspaceID:    wID.String(),
+		InstallationID: installationID,
+	})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return make([]WorkspaceChangelogCount, 0), nil
+			return GHInstallation{}, errs.NewError(errs.ErrNotFound, errors.New("github installation not found"))
+		}
+		return GHInstallation{}, err
+	}
+	return row.toExported(), nil
+}
+
+func (s *sqlite) ListGHInstallations(ctx context.Context, wID WorkspaceID) ([]GHInstallation, error) {
+	rows, err := s.q.listGHInstallations(ctx, wID.String())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return make([]GHInstallation, 0), nil
 		}
 		return nil, err
 	}
-	res := make([]WorkspaceChangelogCount, len(rows))
+
+	installations := make([]GHInstallation, len(rows))
 	for i, row := range rows {
-		res[i] = WorkspaceChangelogCount{
-			Workspace: Workspace{
-				ID:   WorkspaceID(row.workspace.ID),
-				Name: row.workspace.Name,
-			},
-			ChangelogCount: row.ChangelogCount,
+		installations[i] = row.toExported()
+	}
+	return installations, nil
+}
+
+// The sources are kept, so they can be reattached once the app is installed again.
+func (s *sqlite) DeleteGHInstallation(ctx context.Context, installationID int64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	q := s.q.WithTx(tx)
+
+	sources, err := q.listGHSourcesByInstallation(ctx, installationID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	for _, gh := range sources {
+		err = q.deleteCombinedSourceByID(ctx, deleteCombinedSourceByIDParams{
+			WorkspaceID: gh.WorkspaceID,
+			SourceID:    gh.ID,
+		})
+		if err != nil {
+			return err
+		}
+		// changelogs combining multiple sources fall back to their next source
+		err = q.detachChangelogSource(ctx, detachChangelogSourceParams{
+			WorkspaceID: gh.WorkspaceID,
+			SourceID:    apitypes.NewString(gh.ID),
+		})
+		if err != nil {
+			return err
 		}
 	}
-	return res, nil
+
+	err = q.deleteGHInstallation(ctx, installationID)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *sqlite) CreateGLSource(ctx context.Context, gl GLSource) (GLSource, error) {
+	row, err := s.q.createGLSource(ctx, createGLSourceParams{
+		ID:              gl.ID.String(),
+		WorkspaceID:     gl.WorkspaceID.String(),
+		BaseUrl:         gl.BaseURL,
+		Project:         gl.Project,
+		Path:            gl.Path,
+		Ref:             gl.Ref,
+		Token:           gl.Token,
+		Releases:        boolToInt(gl.Releases),
+		IncludePatterns: joinPatterns(gl.Include),
+		ExcludePatterns: joinPatterns(gl.Exclude),
+	})
+	if err != nil {
+		return GLSource{}, err
+	}
+	return row.toExported(), nil
+}
+
+func (s *sqlite) DeleteGLSource(ctx context.Context, wID WorkspaceID, glID GLSourceID) error {
+	return s.q.deleteGLSource(ctx, deleteGLSourceParams{
+		WorkspaceID: wID.String(),
+		ID:          glID.String(),
+	})
+}
+
+func (s *sqlite) GetGLSource(ctx context.Context, wID WorkspaceID, glID GLSourceID) (GLSource, error) {
+	row, err := s.q.getGLSource(ctx, getGLSourceParams{
+		WorkspaceID: wID.String(),
+		ID:          glID.String(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return GLSource{}, errs.NewError(errs.ErrNotFound, errors.New("gitlab source not found"))
+		}
+		return GLSource{}, err
+	}
+	return row.toExported(), nil
+}
+
+func (s *sqlite) ListGLSources(ctx context.Context, wID WorkspaceID) ([]GLSource, error) {
+	rows, err := s.q.listGLSources(ctx, wID.String())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return make([]GLSource, 0), nil
+		}
+		return nil, err
+	}
+
+	sources := make([]GLSource, len(rows))
+	for i, row := range rows {
+		sources[i] = row.toExported()
+	}
+	return sources, nil
+}
+
+func (s *sqlite) CreateFJSource(ctx context.Context, fj FJSource) (FJSource, error) {
+	row, err := s.q.createFJSource(ctx, createFJSourceParams{
+		ID:              fj.ID.String(),
+		WorkspaceID:     fj.WorkspaceID.String(),
+		BaseUrl:         fj.BaseURL,
+		Project:         fj.Project,
+		Path:            fj.Path,
+		Ref:             fj.Ref,
+		Token:           fj.Token,
+		Releases:        boolToInt(fj.Releases),
+		IncludePatterns: joinPatterns(fj.Include),
+		ExcludePatterns: joinPatterns(fj.Exclude),
+	})
+	if err != nil {
+		return FJSource{}, err
+	}
+	return row.toExported(), nil
+}
+
+func (s *sqlite) DeleteFJSource(ctx context.Context, wID WorkspaceID, fjID FJSourceID) error {
+	return s.q.deleteFJSource(ctx, deleteFJSourceParams{
+		WorkspaceID: wID.String(),
+		ID:          fjID.String(),
+	})
+}
+
+func (s *sqlite) GetFJSource(ctx context.Context, wID WorkspaceID, fjID FJSourceID) (FJSource, error) {
+	row, err := s.q.getFJSource(ctx, getFJSourceParams{
+		WorkspaceID: wID.String(),
+		ID:          fjID.String(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return FJSource{}, errs.NewError(errs.ErrNotFound, errors.New("forgejo source not found"))
+		}
+		return FJSource{}, err
+	}
+	return row.toExported(), nil
+}
+
+func (s *sqlite) ListFJSources(ctx context.Context, wID WorkspaceID) ([]FJSource, error) {
+	rows, err := s.q.listFJSources(ctx, wID.String())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return make([]FJSource, 0), nil
+		}
+		return nil, err
+	}
+
+	sources := make([]FJSource, len(rows))
+	for i, row := range rows {
+		sources[i] = row.toExported()
+	}
+	return sources, nil
+}
+
+func (s *sqlite) CreateLocalSource(ctx context.Context, lc LocalSource) (LocalSource, error) {
+	row, err := s.q.createLocalSource(ctx, createLocalSourceParams{
+		ID:              lc.ID.String(),
+		WorkspaceID:     lc.WorkspaceID.String(),
+		Path:            lc.Path,
+		IncludePatterns: joinPatterns(lc.Include),
+		ExcludePatterns: joinPatterns(lc.Exclude),
+	})
+	if err != nil {
+		return LocalSource{}, err
+	}
+	return row.toExported(), nil
+}
+
+func (s *sqlite) DeleteLocalSource(ctx context.Context, wID WorkspaceID, lcID LocalSourceID) error {
+	return s.q.deleteLocalSource(ctx, deleteLocalSourceParams{
+		WorkspaceID: wID.String(),
+		ID:          lcID.String(),
+	})
+}
+
+func (s *sqlite) GetLocalSource(ctx context.Context, wID WorkspaceID, lcID LocalSourceID) (LocalSource, error) {
+	row, err := s.q.getLocalSource(ctx, getLocalSourceParams{
+		WorkspaceID: wID.String(),
+		ID:          lcID.String(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return LocalSource{}, errs.NewError(errs.ErrNotFound, errors.New("local source not found"))
+		}
+		return LocalSource{}, err
+	}
+	return row.toExported(), nil
+}
+
+func (s *sqlite) ListLocalSources(ctx context.Context, wID WorkspaceID) ([]LocalSource, error) {
+	rows, err := s.q.listLocalSources(ctx, wID.String())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return make([]LocalSource, 0), nil
+		}
+		return nil, err
+	}
+
+	sources := make([]LocalSource, len(rows))
+	for i, row := range rows {
+		sources[i] = row.toExported()
+	}
+	return sources, nil
+}
+
+func (s *sqlite) CreateGitSource(ctx context.Context, git GitSource) (GitSource, error) {
+	row, err := s.q.createGitSource(ctx, createGitSourceParams{
+		ID:                  git.ID.String(),
+		WorkspaceID:         git.WorkspaceID.String(),
+		Url:                 git.URL,
+		Ref:                 git.Ref,
+		Path:                git.Path,
+		ConventionalCommits: boolToInt(git.ConventionalCommits),
+		IncludePatterns:     joinPatterns(git.Include),
+		ExcludePatterns:     joinPatterns(git.Exclude),
+	})
+	if err != nil {
+		return GitSource{}, err
+	}
+	return row.toExported(), nil
+}
+
+func (s *sqlite) DeleteGitSource(ctx context.Context, wID WorkspaceID, gitID GitSourceID) error {
+	return s.q.deleteGitSource(ctx, deleteGitSourceParams{
+		WorkspaceID: wID.String(),
+		ID:          gitID.String(),
+	})
+}
+
+func (s *sqlite) GetGitSource(ctx context.Context, wID WorkspaceID, gitID GitSourceID) (GitSource, error) {
+	row, err := s.q.getGitSource(ctx, getGitSourceParams{
+		WorkspaceID: wID.String(),
+		ID:          gitID.String(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return GitSource{}, errs.NewError(errs.ErrNotFound, errors.New("git source not found"))
+		}
+		return GitSource{}, err
+	}
+	return row.toExported(), nil
+}
+
+func (s *sqlite) ListGitSources(ctx context.Context, wID WorkspaceID) ([]GitSource, error) {
+	rows, err := s.q.listGitSources(ctx, wID.String())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return make([]GitSource, 0), nil
+		}
+		return nil, err
+	}
+
+	sources := make([]GitSource, len(rows))
+	for i, row := range rows {
+		sources[i] = row.toExported()
+	}
+	return sources, nil
+}
+
+func (s *sqlite) CreateURLSource(ctx context.Context, u URLSource) (URLSource, error) {
+	row, err := s.q.createURLSource(ctx, createURLSourceParams{
+		ID:          u.ID.String(),
+		WorkspaceID: u.WorkspaceID.String(),
+		Url:         u.URL,
+		Manifest:    boolToInt(u.Manifest),
+	})
+	if err != nil {
+		return URLSource{}, err
+	}
+	return row.toExported(), nil
+}
+
+func (s *sqlite) DeleteURLSource(ctx context.Context, wID WorkspaceID, uID URLSourceID) error {
+	return s.q.deleteURLSource(ctx, deleteURLSourceParams{
+		WorkspaceID: wID.String(),
+		ID:          uID.String(),
+	})
+}
+
+func (s *sqlite) GetURLSource(ctx context.Context, wID WorkspaceID, uID URLSourceID) (URLSource, error) {
+	row, err := s.q.getURLSource(ctx, getURLSourceParams{
+		WorkspaceID: wID.String(),
+		ID:          uID.String(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return URLSource{}, errs.NewError(errs.ErrNotFound, errors.New("url source not found"))
+		}
+		return URLSource{}, err
+	}
+	return row.toExported(), nil
+}
+
+func (s *sqlite) ListURLSources(ctx context.Context, wID WorkspaceID) ([]URLSource, error) {
+	rows, err := s.q.listURLSources(ctx, wID.String())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return make([]URLSource, 0), nil
+		}
+		return nil, err
+	}
+
+	sources := make([]URLSource, len(rows))
+	for i, row := range rows {
+		sources[i] = row.toExported()
+	}
+	return sources, nil
+}
+
+func (s *sqlite) CreateS3Source(ctx context.Context, s3 S3Source) (S3Source, error) {
+	row, err := s.q.createS3Source(ctx, createS3SourceParams{
+		ID:              s3.ID.String(),
+		WorkspaceID:     s3.WorkspaceID.String(),
+		Endpoint:        s3.Endpoint,
+		Region:          s3.Region,
+		Bucket:          s3.Bucket,
+		Prefix:          s3.Prefix,
+		AccessKeyID:     s3.AccessKeyID,
+		SecretAccessKey: s3.SecretAccessKey,
+	})
+	if err != nil {
+		return S3Source{}, err
+	}
+	return row.toExported(), nil
+}
+
+func (s *sqlite) DeleteS3Source(ctx context.Context, wID WorkspaceID, s3ID S3SourceID) error {
+	return s.q.deleteS3Source(ctx, deleteS3SourceParams{
+		WorkspaceID: wID.String(),
+		ID:          s3ID.String(),
+	})
+}
+
+func (s *sqlite) GetS3Source(ctx context.Context, wID WorkspaceID, s3ID S3SourceID) (S3Source, error) {
+	row, err := s.q.getS3Source(ctx, getS3SourceParams{
+		WorkspaceID: wID.String(),
+		ID:          s3ID.String(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return S3Source{}, errs.NewError(errs.ErrNotFound, errors.New("s3 source not found"))
+		}
+		return S3Source{}, err
+	}
+	return row.toExported(), nil
+}
+
+func (s *sqlite) ListS3Sources(ctx context.Context, wID WorkspaceID) ([]S3Source, error) {
+	rows, err := s.q.listS3Sources(ctx, wID.String())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return make([]S3Source, 0), nil
+		}
+		return nil, err
+	}
+
+	sources := make([]S3Source, len(rows))
+	for i, row := range rows {
+		sources[i] = row.toExported()
+	}
+	return sources, nil
+}
+
+func (s *sqlite) ListWorkspacesChangelogCount(ctx context.Context) ([]WorkspaceChangelogCount, error) {
+	rows, err := s.q.listWorkspacesChangelogCount(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return make([]WorkspaceChangelogCount, 0), nil
+		}
+		return nil, err
+	}
+	res := make([]WorkspaceChangelogCount, len(rows))
+	for i, row := range rows {
+		res[i] = WorkspaceChangelogCount{
+			Workspace: Workspace{
+				ID:   WorkspaceID(row.workspace.ID),
+				Name: row.workspace.Name,
+			},
+			ChangelogCount: row.ChangelogCount,
+		}
+	}
+	return res, nil
+}
+
+func (s *sqlite) CreateSubscriber(ctx context.Context, sub Subscriber) (Subscriber, error) {
+	row, err := s.q.createSubscriber(ctx, createSubscriberParams{
+		ID:           sub.ID.String(),
+		WorkspaceID:  sub.WorkspaceID.String(),
+		ChangelogID:  sub.ChangelogID.String(),
+		Email:        sub.Email,
+		ChangelogUrl: sub.ChangelogURL,
+		ConfirmToken: apitypes.NewString(sub.ConfirmToken),
+	})
+	if err != nil {
+		return Subscriber{}, err
+	}
+	return row.toExported(), nil
+}
+
+func (s *sqlite) GetSubscriber(ctx context.Context, sID SubscriberID) (Subscriber, error) {
+	row, err := s.q.getSubscriber(ctx, sID.String())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Subscriber{}, errs.NewError(errs.ErrNotFound, errors.New("subscriber not found"))
+		}
+		return Subscriber{}, err
+	}
+	return row.toExported(), nil
+}
+
+func (s *sqlite) GetSubscriberByEmail(ctx context.Context, wID WorkspaceID, cID ChangelogID, email string) (Subscriber, error) {
+	row, err := s.q.getSubscriberByEmail(ctx, getSubscriberByEmailParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		Email:       email,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Subscriber{}, errs.NewError(errs.ErrNotFound, errors.New("subscriber not found"))
+		}
+		return Subscriber{}, err
+	}
+	return row.toExported(), nil
+}
+
+func (s *sqlite) ConfirmSubscriber(ctx context.Context, token string) (Subscriber, error) {
+	row, err := s.q.confirmSubscriber(ctx, apitypes.NewString(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Subscriber{}, errs.NewError(errs.ErrNotFound, errors.New("invalid or already used confirmation link"))
+		}
+		return Subscriber{}, err
+	}
+	return row.toExported(), nil
+}
+
+func (s *sqlite) ListConfirmedSubscribers(ctx context.Context, wID WorkspaceID, cID ChangelogID) ([]Subscriber, error) {
+	rows, err := s.q.listConfirmedSubscribers(ctx, listConfirmedSubscribersParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return make([]Subscriber, 0), nil
+		}
+		return nil, err
+	}
+
+	subs := make([]Subscriber, len(rows))
+	for i, row := range rows {
+		subs[i] = row.toExported()
+	}
+	return subs, nil
+}
+
+func (s *sqlite) DeleteSubscriber(ctx context.Context, sID SubscriberID) error {
+	return s.q.deleteSubscriber(ctx, sID.String())
+}
+
+func (s *sqlite) ListDispatchedReleaseNotes(ctx context.Context, wID WorkspaceID, cID ChangelogID) ([]string, error) {
+	ids, err := s.q.listDispatchedReleaseNotes(ctx, listDispatchedReleaseNotesParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return make([]string, 0), nil
+		}
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (s *sqlite) SaveDispatchedReleaseNotes(ctx context.Context, wID WorkspaceID, cID ChangelogID, ids []string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	q := s.q.WithTx(tx)
+
+	for _, id := range ids {
+		err = q.createDispatchedReleaseNote(ctx, createDispatchedReleaseNoteParams{
+			WorkspaceID:   wID.String(),
+			ChangelogID:   cID.String(),
+			ReleaseNoteID: id,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (s *sqlite) CreateWebhook(ctx context.Context, wh Webhook) (Webhook, error) {
+	row, err := s.q.createWebhook(ctx, createWebhookParams{
+		ID:          wh.ID.String(),
+		WorkspaceID: wh.WorkspaceID.String(),
+		ChangelogID: wh.ChangelogID.String(),
+		Url:         wh.URL,
+		Secret:      wh.Secret,
+	})
+	if err != nil {
+		return Webhook{}, err
+	}
+	return row.toExported(), nil
+}
+
+func (s *sqlite) GetWebhook(ctx context.Context, wID WorkspaceID, whID WebhookID) (Webhook, error) {
+	row, err := s.q.getWebhook(ctx, getWebhookParams{
+		WorkspaceID: wID.String(),
+		ID:          whID.String(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Webhook{}, errs.NewError(errs.ErrNotFound, errors.New("webhook not found"))
+		}
+		return Webhook{}, err
+	}
+	return row.toExported(), nil
+}
+
+func (s *sqlite) ListWebhooks(ctx context.Context, wID WorkspaceID, cID ChangelogID) ([]Webhook, error) {
+	rows, err := s.q.listWebhooks(ctx, listWebhooksParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return make([]Webhook, 0), nil
+		}
+		return nil, err
+	}
+
+	whs := make([]Webhook, len(rows))
+	for i, row := range rows {
+		whs[i] = row.toExported()
+	}
+	return whs, nil
+}
+
+func (s *sqlite) ListAllWebhooks(ctx context.Context) ([]Webhook, error) {
+	rows, err := s.q.listAllWebhooks(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return make([]Webhook, 0), nil
+		}
+		return nil, err
+	}
+
+	whs := make([]Webhook, len(rows))
+	for i, row := range rows {
+		whs[i] = row.toExported()
+	}
+	return whs, nil
+}
+
+func (s *sqlite) DeleteWebhook(ctx context.Context, wID WorkspaceID, whID WebhookID) error {
+	return s.q.deleteWebhook(ctx, deleteWebhookParams{
+		WorkspaceID: wID.String(),
+		ID:          whID.String(),
+	})
+}
+
+func (s *sqlite) CreateWebhookDelivery(ctx context.Context, d WebhookDelivery) (WebhookDelivery, error) {
+	errMsg := apitypes.NewNullString()
+	if d.Error != "" {
+		errMsg = apitypes.NewString(d.Error)
+	}
+	row, err := s.q.createWebhookDelivery(ctx, createWebhookDeliveryParams{
+		ID:            d.ID.String(),
+		WebhookID:     d.WebhookID.String(),
+		ReleaseNoteID: d.ReleaseNoteID,
+		Event:         d.Event,
+		Attempt:       int64(d.Attempt),
+		StatusCode:    int64(d.StatusCode),
+		Error:         errMsg,
+	})
+	if err != nil {
+		return WebhookDelivery{}, err
+	}
+	return row.toExported(), nil
+}
+
+func (s *sqlite) ListWebhookDeliveries(ctx context.Context, whID WebhookID, limit int) ([]WebhookDelivery, error) {
+	rows, err := s.q.listWebhookDeliveries(ctx, listWebhookDeliveriesParams{
+		WebhookID: whID.String(),
+		Limit:     int64(limit),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return make([]WebhookDelivery, 0), nil
+		}
+		return nil, err
+	}
+
+	deliveries := make([]WebhookDelivery, len(rows))
+	for i, row := range rows {
+		deliveries[i] = row.toExported()
+	}
+	return deliveries, nil
+}
+
+func (s *sqlite) ListDeliveredReleaseNotes(ctx context.Context, whID WebhookID) ([]string, error) {
+	ids, err := s.q.listDeliveredReleaseNotes(ctx, whID.String())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return make([]string, 0), nil
+		}
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (s *sqlite) ListWebhookSources(ctx context.Context, whID WebhookID) ([]string, error) {
+	ids, err := s.q.listWebhookSources(ctx, whID.String())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return make([]string, 0), nil
+		}
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (s *sqlite) SaveWebhookSource(ctx context.Context, whID WebhookID, sourceID string, skipped []string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	q := s.q.WithTx(tx)
+
+	for _, id := range skipped {
+		err = q.createWebhookSkippedReleaseNote(ctx, createWebhookSkippedReleaseNoteParams{
+			WebhookID:     whID.String(),
+			ReleaseNoteID: id,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	err = q.createWebhookSource(ctx, createWebhookSourceParams{
+		WebhookID: whID.String(),
+		SourceID:  sourceID,
+	})
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *sqlite) ListSkippedReleaseNotes(ctx context.Context, whID WebhookID) ([]string, error) {
+	ids, err := s.q.listWebhookSkippedReleaseNotes(ctx, whID.String())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return make([]string, 0), nil
+		}
+		return nil, err
+	}
+	return ids, nil
 }
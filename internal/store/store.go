@@ -34,6 +34,23 @@ type Changelog struct {
 	GLSource      null.Value[GLSource]
 	FJSource      null.Value[FJSource]
 	LocalSource   null.Value[LocalSource]
+	GitSource     null.Value[GitSource]
+	URLSource     null.Value[URLSource]
+	S3Source      null.Value[S3Source]
+	// The ordered sources of a changelog combining multiple sources, the source fields above hold the first one.
+	// Empty if the changelog has a single source.
+	Sources []ChangelogSource
+}
+
+// One of the sources of a changelog combining multiple sources, exactly one of the fields is valid.
+type ChangelogSource struct {
+	GHSource    null.Value[GHSource]
+	GLSource    null.Value[GLSource]
+	FJSource    null.Value[FJSource]
+	LocalSource null.Value[LocalSource]
+	GitSource   null.Value[GitSource]
+	URLSource   null.Value[URLSource]
+	S3Source    null.Value[S3Source]
 }
 
 type Workspace struct {
@@ -49,26 +66,148 @@ type GHSource struct {
 	Repo           string
 	Path           string
 	InstallationID int64
+	// The url of a GitHub Enterprise Server, empty for github.com.
+	BaseURL string
+	// The branch, tag or commit to load from, empty for the default branch.
+	Ref string
+	// Load release notes from the GitHub releases instead of markdown files.
+	Releases bool
+	// Glob patterns selecting the files if Path is a directory.
+	Include []string
+	Exclude []string
+}
+
+// An installation of the GitHub App, made by a workspace through the setup flow.
+type GHInstallation struct {
+	WorkspaceID WorkspaceID
+	ID          int64
+	// The login of the user or organization the app is installed on.
+	Account   string
+	CreatedAt time.Time
 }
 
 type GLSource struct {
-	BaseURL string
-	Project string
-	Path    string
-	Ref     string
-	Token   string
+	ID          GLSourceID
+	WorkspaceID WorkspaceID
+	BaseURL     string
+	Project     string
+	Path        string
+	Ref         string
+	Token       string
+	// Load release notes from the GitLab releases instead of markdown files.
+	Releases bool
+	// Glob patterns selecting the files if Path is a directory.
+	Include []string
+	Exclude []string
 }
 
 type FJSource struct {
-	BaseURL string
-	Project string
-	Path    string
-	Ref     string
-	Token   string
+	ID          FJSourceID
+	WorkspaceID WorkspaceID
+	BaseURL     string
+	Project     string
+	Path        string
+	Ref         string
+	Token       string
+	// Load release notes from the Forgejo releases instead of markdown files.
+	Releases bool
+	// Glob patterns selecting the files if Path is a directory.
+	Include []string
+	Exclude []string
 }
 
 type LocalSource struct {
+	ID          LocalSourceID
+	WorkspaceID WorkspaceID
+	Path        string
+	// Glob patterns selecting the files if Path is a directory.
+	Include []string
+	Exclude []string
+}
+
+// A source reading release notes from any git repository.
+type GitSource struct {
+	ID          GitSourceID
+	WorkspaceID WorkspaceID
+	// The remote url, https, ssh or file.
+	URL string
+	// The branch, tag or commit, the default branch of the remote if empty.
+	Ref  string
 	Path string
+	// Generate the release notes from the tags and conventional commits instead of markdown files.
+	ConventionalCommits bool
+	// Glob patterns selecting the files if Path is a directory.
+	Include []string
+	Exclude []string
+}
+
+// A source fetching release notes over http(s), e.g. from a CDN or file server.
+type URLSource struct {
+	ID          URLSourceID
+	WorkspaceID WorkspaceID
+	URL         string
+	// The url points to a manifest listing the urls of the release notes, one per line.
+	Manifest bool
+}
+
+// A source reading release notes from the objects of a bucket in S3 compatible object storage.
+type S3Source struct {
+	ID          S3SourceID
+	WorkspaceID WorkspaceID
+	// The url of the S3 compatible endpoint.
+	Endpoint string
+	Region   string
+	Bucket   string
+	// The prefix of the markdown objects, or the key of a single markdown object.
+	Prefix          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// An email subscriber of a changelog.
+type Subscriber struct {
+	ID          SubscriberID
+	WorkspaceID WorkspaceID
+	ChangelogID ChangelogID
+	Email       string
+	// The url of the changelog the subscription was created on, used for links in emails.
+	ChangelogURL string
+	// Token of the double opt-in confirmation link, empty once confirmed.
+	ConfirmToken string
+	ConfirmedAt  time.Time
+	CreatedAt    time.Time
+}
+
+func (s Subscriber) IsConfirmed() bool {
+	return !s.ConfirmedAt.IsZero()
+}
+
+// A webhook which is called when release notes of a changelog are published.
+type Webhook struct {
+	ID          WebhookID
+	WorkspaceID WorkspaceID
+	ChangelogID ChangelogID
+	URL         string
+	// Signs the payloads sent to the webhook.
+	Secret    string
+	CreatedAt time.Time
+}
+
+// A single delivery attempt of a webhook event.
+type WebhookDelivery struct {
+	ID            WebhookDeliveryID
+	WebhookID     WebhookID
+	ReleaseNoteID string
+	Event         string
+	Attempt       int
+	// Zero if no response was received.
+	StatusCode int
+	Error      string
+	CreatedAt  time.Time
+}
+
+func (d WebhookDelivery) Success() bool {
+	return d.StatusCode >= 200 && d.StatusCode < 300
 }
 
 type UpdateChangelogArgs struct {
@@ -99,10 +238,20 @@ type Store interface {
 	GetChangelog(context.Context, WorkspaceID, ChangelogID) (Changelog, error)
 	GetChangelogByDomainOrSubdomain(ctx context.Context, domain Domain, subdomain Subdomain) (Changelog, error)
 	ListChangelogs(context.Context, WorkspaceID) ([]Changelog, error)
+	// Lists the changelogs of all workspaces.
+	ListAllChangelogs(context.Context) ([]Changelog, error)
 	CreateChangelog(context.Context, Changelog) (Changelog, error)
 	UpdateChangelog(context.Context, WorkspaceID, ChangelogID, UpdateChangelogArgs) (Changelog, error)
 	DeleteChangelog(context.Context, WorkspaceID, ChangelogID) error
 	SetChangelogGHSource(context.Context, WorkspaceID, ChangelogID, GHSourceID) error
+	SetChangelogGLSource(context.Context, WorkspaceID, ChangelogID, GLSourceID) error
+	SetChangelogFJSource(context.Context, WorkspaceID, ChangelogID, FJSourceID) error
+	SetChangelogLocalSource(context.Context, WorkspaceID, ChangelogID, LocalSourceID) error
+	SetChangelogGitSource(context.Context, WorkspaceID, ChangelogID, GitSourceID) error
+	SetChangelogURLSource(context.Context, WorkspaceID, ChangelogID, URLSourceID) error
+	SetChangelogS3Source(context.Context, WorkspaceID, ChangelogID, S3SourceID) error
+	// Combines the release notes of multiple sources in one changelog, sourceIDs are ordered.
+	SetChangelogSources(ctx context.Context, wID WorkspaceID, cID ChangelogID, sourceIDs []string) error
 	DeleteChangelogSource(context.Context, WorkspaceID, ChangelogID) error
 
 	// Workspace
@@ -119,4 +268,64 @@ type Store interface {
 	GetGHSource(context.Context, WorkspaceID, GHSourceID) (GHSource, error)
 	ListGHSources(context.Context, WorkspaceID) ([]GHSource, error)
 	DeleteGHSource(context.Context, WorkspaceID, GHSourceID) error
+	SaveGHInstallation(context.Context, GHInstallation) (GHInstallation, error)
+	GetGHInstallation(ctx context.Context, wID WorkspaceID, installationID int64) (GHInstallation, error)
+	ListGHInstallations(context.Context, WorkspaceID) ([]GHInstallation, error)
+	// Deletes the installation from all workspaces and detaches the GitHub sources using it from their changelogs.
+	DeleteGHInstallation(ctx context.Context, installationID int64) error
+	CreateGLSource(context.Context, GLSource) (GLSource, error)
+	GetGLSource(context.Context, WorkspaceID, GLSourceID) (GLSource, error)
+	ListGLSources(context.Context, WorkspaceID) ([]GLSource, error)
+	DeleteGLSource(context.Context, WorkspaceID, GLSourceID) error
+	CreateFJSource(context.Context, FJSource) (FJSource, error)
+	GetFJSource(context.Context, WorkspaceID, FJSourceID) (FJSource, error)
+	ListFJSources(context.Context, WorkspaceID) ([]FJSource, error)
+	DeleteFJSource(context.Context, WorkspaceID, FJSourceID) error
+	CreateLocalSource(context.Context, LocalSource) (LocalSource, error)
+	GetLocalSource(context.Context, WorkspaceID, LocalSourceID) (LocalSource, error)
+	ListLocalSources(context.Context, WorkspaceID) ([]LocalSource, error)
+	DeleteLocalSource(context.Context, WorkspaceID, LocalSourceID) error
+	CreateGitSource(context.Context, GitSource) (GitSource, error)
+	GetGitSource(context.Context, WorkspaceID, GitSourceID) (GitSource, error)
+	ListGitSources(context.Context, WorkspaceID) ([]GitSource, error)
+	DeleteGitSource(context.Context, WorkspaceID, GitSourceID) error
+	CreateURLSource(context.Context, URLSource) (URLSource, error)
+	GetURLSource(context.Context, WorkspaceID, URLSourceID) (URLSource, error)
+	ListURLSources(context.Context, WorkspaceID) ([]URLSource, error)
+	DeleteURLSource(context.Context, WorkspaceID, URLSourceID) error
+	CreateS3Source(context.Context, S3Source) (S3Source, error)
+	GetS3Source(context.Context, WorkspaceID, S3SourceID) (S3Source, error)
+	ListS3Sources(context.Context, WorkspaceID) ([]S3Source, error)
+	DeleteS3Source(context.Context, WorkspaceID, S3SourceID) error
+
+	// Subscriber
+	CreateSubscriber(context.Context, Subscriber) (Subscriber, error)
+	GetSubscriber(context.Context, SubscriberID) (Subscriber, error)
+	GetSubscriberByEmail(ctx context.Context, wID WorkspaceID, cID ChangelogID, email string) (Subscriber, error)
+	// Confirms the subscriber with the confirmation token.
+	ConfirmSubscriber(ctx context.Context, token string) (Subscriber, error)
+	ListConfirmedSubscribers(context.Context, WorkspaceID, ChangelogID) ([]Subscriber, error)
+	DeleteSubscriber(context.Context, SubscriberID) error
+	// Lists the ids of release notes subscribers were already notified about.
+	ListDispatchedReleaseNotes(context.Context, WorkspaceID, ChangelogID) ([]string, error)
+	SaveDispatchedReleaseNotes(ctx context.Context, wID WorkspaceID, cID ChangelogID, ids []string) error
+
+	// Webhook
+	CreateWebhook(context.Context, Webhook) (Webhook, error)
+	GetWebhook(context.Context, WorkspaceID, WebhookID) (Webhook, error)
+	ListWebhooks(context.Context, WorkspaceID, ChangelogID) ([]Webhook, error)
+	// Lists the webhooks of all workspaces.
+	ListAllWebhooks(context.Context) ([]Webhook, error)
+	DeleteWebhook(context.Context, WorkspaceID, WebhookID) error
+	CreateWebhookDelivery(context.Context, WebhookDelivery) (WebhookDelivery, error)
+	// Lists the latest deliveries of the webhook, newest first.
+	ListWebhookDeliveries(ctx context.Context, whID WebhookID, limit int) ([]WebhookDelivery, error)
+	// Lists the ids of release notes the webhook was called for.
+	ListDeliveredReleaseNotes(context.Context, WebhookID) ([]string, error)
+	// Lists the ids of the sources whose existing release notes were recorded for the webhook.
+	ListWebhookSources(context.Context, WebhookID) ([]string, error)
+	// Records the source for the webhook, together with the ids of it's existing release notes, which are skipped.
+	SaveWebhookSource(ctx context.Context, whID WebhookID, sourceID string, skipped []string) error
+	// Lists the ids of release notes the webhook is never called for.
+	ListSkippedReleaseNotes(context.Context, WebhookID) ([]string, error)
 }
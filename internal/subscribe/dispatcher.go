@@ -0,0 +1,191 @@
+package subscribe
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/btvoidx/mint"
+	"github.com/jonashiltl/openchangelog/internal"
+	"github.com/jonashiltl/openchangelog/internal/events"
+	"github.com/jonashiltl/openchangelog/internal/parse"
+	"github.com/jonashiltl/openchangelog/internal/source"
+	"github.com/jonashiltl/openchangelog/internal/store"
+	"github.com/jonashiltl/openchangelog/internal/xlog"
+)
+
+// Creates a new Dispatcher.
+func NewDispatcher(e *mint.Emitter, service *Service, parser *parse.Cache) *Dispatcher {
+	return &Dispatcher{
+		e:       e,
+		service: service,
+		parser:  parser,
+		locks:   make(map[store.ChangelogID]*sync.Mutex),
+	}
+}
+
+// Emails newly published release notes to the confirmed subscribers of a changelog.
+// New release notes are detected when the content of a source changes,
+// or once the publishedAt of a scheduled release note passes.
+type Dispatcher struct {
+	e       *mint.Emitter
+	service *Service
+	parser  *parse.Cache
+	offs    []func() <-chan struct{}
+	// each changelog is dispatched by one goroutine at a time, so release notes aren't sent twice
+	mu    sync.Mutex
+	locks map[store.ChangelogID]*sync.Mutex
+}
+
+// Starts listening to source changes and published release notes, does nothing if subscriptions are disabled.
+func (d *Dispatcher) Start() {
+	if !d.service.Enabled() {
+		return
+	}
+	d.offs = append(d.offs, mint.On(d.e, d.OnSourceChanged), mint.On(d.e, d.OnReleaseNotePublished))
+}
+
+// Stops listening to events.
+func (d *Dispatcher) Close() {
+	for _, off := range d.offs {
+		off()
+	}
+}
+
+func (d *Dispatcher) OnSourceChanged(e events.SourceContentChanged) {
+	go d.dispatchLogged(e.CL, e.Source)
+}
+
+func (d *Dispatcher) OnReleaseNotePublished(e events.ReleaseNotePublished) {
+	go d.dispatchLogged(e.CL, e.Source)
+}
+
+func (d *Dispatcher) dispatchLogged(cl store.Changelog, s source.Source) {
+	err := d.Dispatch(context.Background(), cl, s)
+	if err != nil {
+		slog.Error("failed to dispatch release notes to subscribers", slog.String("cid", cl.ID.String()), xlog.ErrAttr(err))
+	}
+}
+
+// Emails the published release notes of the source, which weren't dispatched yet, to all confirmed subscribers.
+// If nothing was dispatched for the source of the changelog yet, the existing release notes are only remembered,
+// so new subscribers don't receive the whole history.
+func (d *Dispatcher) Dispatch(ctx context.Context, cl store.Changelog, s source.Source) error {
+	if s == nil {
+		return nil
+	}
+
+	notes, subs, err := d.claim(ctx, cl, s)
+	if err != nil {
+		return err
+	}
+
+	// the release notes are already remembered, so the emails are sent without holding the lock
+	for _, n := range notes {
+		for _, sub := range subs {
+			err := d.service.sendReleaseNote(ctx, cl, sub, n)
+			if err != nil {
+				slog.Error("failed to email release note", slog.String("sub", sub.ID.String()), slog.String("release", n.ID), xlog.ErrAttr(err))
+			}
+		}
+	}
+	return nil
+}
+
+// Remembers the new release notes of the source as dispatched and returns them with the subscribers to email.
+// Holds the lock of the changelog, so concurrent dispatches of the same changelog can't claim a release note twice.
+func (d *Dispatcher) claim(ctx context.Context, cl store.Changelog, s source.Source) ([]releaseNote, []store.Subscriber, error) {
+	unlock := d.lock(cl.ID)
+	defer unlock()
+
+	loaded, err := s.Load(ctx, internal.NoPagination())
+	if err != nil {
+		return nil, nil, err
+	}
+	parsed := d.parser.Parse(ctx, s.ID(), loaded.Raw, internal.NoPagination())
+
+	dispatched, err := d.service.store.ListDispatchedReleaseNotes(ctx, cl.WorkspaceID, cl.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if source.IsCombined(cl) {
+		for i, n := range parsed.ReleaseNotes {
+			parsed.ReleaseNotes[i].Meta.ID = source.CombinedNoteID(cl, s.ID(), n.Meta.ID)
+		}
+		// a source added to the changelog shouldn't email it's whole history
+		dispatched = slices.DeleteFunc(dispatched, func(id string) bool {
+			return !source.IsCombinedNoteOf(s.ID(), id)
+		})
+	}
+
+	notes := newReleaseNotes(parsed.ReleaseNotes, dispatched, time.Now())
+	if len(notes) == 0 {
+		return nil, nil, nil
+	}
+
+	ids := make([]string, len(notes))
+	for i, n := range notes {
+		ids[i] = n.ID
+	}
+	// remember the release notes before sending, a failed email shouldn't cause duplicates for the other subscribers
+	err = d.service.store.SaveDispatchedReleaseNotes(ctx, cl.WorkspaceID, cl.ID, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(dispatched) == 0 {
+		return nil, nil, nil
+	}
+
+	subs, err := d.service.store.ListConfirmedSubscribers(ctx, cl.WorkspaceID, cl.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return notes, subs, nil
+}
+
+// Locks the changelog and returns the function to unlock it.
+// The locks are kept for the lifetime of the dispatcher, one per dispatched changelog.
+func (d *Dispatcher) lock(cid store.ChangelogID) func() {
+	d.mu.Lock()
+	l, ok := d.locks[cid]
+	if !ok {
+		l = &sync.Mutex{}
+		d.locks[cid] = l
+	}
+	d.mu.Unlock()
+
+	l.Lock()
+	return l.Unlock
+}
+
+// Returns the published release notes which are not part of dispatched, oldest first.
+// Consumes the content of the new release notes.
+func newReleaseNotes(parsed []parse.ParsedReleaseNote, dispatched []string, now time.Time) []releaseNote {
+	notes := make([]releaseNote, 0)
+	for _, n := range parsed {
+		if !n.Meta.Published(now) || slices.Contains(dispatched, n.Meta.ID) {
+			continue
+		}
+
+		var content strings.Builder
+		if n.Content != nil {
+			_, err := io.Copy(&content, n.Content)
+			if err != nil {
+				continue
+			}
+		}
+		notes = append(notes, releaseNote{
+			ID:          n.Meta.ID,
+			Title:       n.Meta.Title,
+			Description: n.Meta.Description,
+			Content:     content.String(),
+		})
+	}
+	// parsed release notes are sorted newest first
+	slices.Reverse(notes)
+	return notes
+}
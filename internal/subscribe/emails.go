@@ -0,0 +1,94 @@
+package subscribe
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"net/url"
+
+	"github.com/jonashiltl/openchangelog/internal/mail"
+	"github.com/jonashiltl/openchangelog/internal/store"
+)
+
+var confirmTemplate = template.Must(template.New("confirm").Parse(`
+<p>Please confirm your subscription to the {{ .Title }} changelog.</p>
+<p><a href="{{ .ConfirmURL }}">Confirm subscription</a></p>
+<p>If you didn't subscribe, you can ignore this email.</p>
+`))
+
+var releaseNoteTemplate = template.Must(template.New("release").Parse(`
+<h1><a href="{{ .URL }}">{{ .Note.Title }}</a></h1>
+{{ if .Note.Description }}<p>{{ .Note.Description }}</p>{{ end }}
+{{ .Content }}
+<hr>
+<p><small>You receive this email because you subscribed to the {{ .Title }} changelog. <a href="{{ .UnsubscribeURL }}">Unsubscribe</a></small></p>
+`))
+
+// A published release note, content is the parsed html.
+type releaseNote struct {
+	ID          string
+	Title       string
+	Description string
+	Content     string
+}
+
+func changelogTitle(cl store.Changelog) string {
+	if cl.Title.V() != "" {
+		return cl.Title.V()
+	}
+	return "Openchangelog"
+}
+
+func (s *Service) sendConfirmation(ctx context.Context, cl store.Changelog, sub store.Subscriber) error {
+	title := changelogTitle(cl)
+	args := map[string]any{
+		"Title":      title,
+		"ConfirmURL": s.confirmURL(sub),
+	}
+
+	var html bytes.Buffer
+	err := confirmTemplate.Execute(&html, args)
+	if err != nil {
+		return err
+	}
+
+	return s.sender.Send(ctx, mail.Message{
+		From:    s.cfg.Email.From,
+		To:      sub.Email,
+		Subject: fmt.Sprintf("Confirm your subscription to %s", title),
+		Text:    fmt.Sprintf("Please confirm your subscription to the %s changelog: %s\n\nIf you didn't subscribe, you can ignore this email.", title, s.confirmURL(sub)),
+		HTML:    html.String(),
+	})
+}
+
+func (s *Service) sendReleaseNote(ctx context.Context, cl store.Changelog, sub store.Subscriber, note releaseNote) error {
+	title := changelogTitle(cl)
+	noteURL := changelogLink(sub.ChangelogURL, "/release/"+url.PathEscape(note.ID), nil)
+	unsubscribeURL := s.UnsubscribeURL(sub)
+
+	var html bytes.Buffer
+	err := releaseNoteTemplate.Execute(&html, map[string]any{
+		"Title": title,
+		"Note":  note,
+		"URL":   noteURL,
+		// the content is rendered from the release notes of the changelog owner
+		"Content":        template.HTML(note.Content),
+		"UnsubscribeURL": unsubscribeURL,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.sender.Send(ctx, mail.Message{
+		From:    s.cfg.Email.From,
+		To:      sub.Email,
+		Subject: fmt.Sprintf("%s: %s", title, note.Title),
+		Text:    fmt.Sprintf("%s\n\n%s\n\nRead more: %s\n\nUnsubscribe: %s", note.Title, note.Description, noteURL, unsubscribeURL),
+		HTML:    html.String(),
+		Headers: map[string]string{
+			"List-Unsubscribe":      fmt.Sprintf("<%s>", unsubscribeURL),
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		},
+	})
+}
@@ -0,0 +1,226 @@
+package subscribe
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	netmail "net/mail"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/internal/config"
+	"github.com/jonashiltl/openchangelog/internal/errs"
+	"github.com/jonashiltl/openchangelog/internal/mail"
+	"github.com/jonashiltl/openchangelog/internal/store"
+)
+
+// Creates a new Service.
+func NewService(cfg config.Config, store store.Store, sender mail.Sender) *Service {
+	return &Service{
+		cfg:             cfg,
+		store:           store,
+		sender:          sender,
+		confirmations:   make(map[string]time.Time),
+		confirmInterval: confirmResendInterval,
+	}
+}
+
+// Confirmation emails are resent to an address at most once per interval,
+// so posting the subscribe form repeatedly can't flood the inbox of someone else.
+const confirmResendInterval = 10 * time.Minute
+
+// Manages the email subscriptions of changelogs.
+// Subscriptions need to be confirmed through an emailed link (double opt-in)
+// and can be cancelled through signed unsubscribe links.
+type Service struct {
+	cfg    config.Config
+	store  store.Store
+	sender mail.Sender
+	mu     sync.Mutex
+	// the time the last confirmation email was sent to an address
+	confirmations   map[string]time.Time
+	confirmInterval time.Duration
+}
+
+// Returns true if subscriptions are enabled.
+func (s *Service) Enabled() bool {
+	return s.cfg.HasSubscriptions()
+}
+
+var errDisabled = errs.NewBadRequest(errors.New("email subscriptions are not enabled"))
+
+// Subscribes the email to the changelog and sends the confirmation email.
+func (s *Service) Subscribe(ctx context.Context, cl store.Changelog, email string) error {
+	if !s.Enabled() {
+		return errDisabled
+	}
+	changelogURL, err := s.changelogURL(cl)
+	if err != nil {
+		return err
+	}
+
+	addr, err := netmail.ParseAddress(email)
+	if err != nil {
+		return errs.NewBadRequest(errors.New("invalid email address"))
+	}
+	address := strings.ToLower(addr.Address)
+
+	sub, err := s.store.GetSubscriberByEmail(ctx, cl.WorkspaceID, cl.ID, address)
+	if err == nil {
+		// don't reveal whether the email is already subscribed
+		if sub.IsConfirmed() || !s.allowConfirmation(address, time.Now()) {
+			return nil
+		}
+		return s.sendConfirmation(ctx, cl, sub)
+	}
+	if !isNotFound(err) {
+		return err
+	}
+
+	token, err := newConfirmToken()
+	if err != nil {
+		return err
+	}
+
+	sub, err = s.store.CreateSubscriber(ctx, store.Subscriber{
+		ID:           store.NewSubscriberID(),
+		WorkspaceID:  cl.WorkspaceID,
+		ChangelogID:  cl.ID,
+		Email:        address,
+		ChangelogURL: changelogURL,
+		ConfirmToken: token,
+	})
+	if err != nil {
+		return err
+	}
+	// the first confirmation of a subscription is always sent, only resends are throttled
+	s.allowConfirmation(address, time.Now())
+	return s.sendConfirmation(ctx, cl, sub)
+}
+
+// Returns true if no confirmation email was sent to the address within the resend interval and records now as the last send.
+// Addresses whose interval passed are forgotten, so the map only holds the recent ones.
+func (s *Service) allowConfirmation(address string, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for a, sent := range s.confirmations {
+		if now.Sub(sent) >= s.confirmInterval {
+			delete(s.confirmations, a)
+		}
+	}
+	if _, ok := s.confirmations[address]; ok {
+		return false
+	}
+	s.confirmations[address] = now
+	return true
+}
+
+// Confirms the subscription with the token of the confirmation link.
+func (s *Service) Confirm(ctx context.Context, token string) (store.Subscriber, error) {
+	if !s.Enabled() {
+		return store.Subscriber{}, errDisabled
+	}
+	if token == "" {
+		return store.Subscriber{}, errs.NewBadRequest(errors.New("missing confirmation token"))
+	}
+	return s.store.ConfirmSubscriber(ctx, token)
+}
+
+// Deletes the subscriber, signature needs to be the signature of the unsubscribe link.
+func (s *Service) Unsubscribe(ctx context.Context, subscriberID string, signature string) error {
+	if !s.Enabled() {
+		return errDisabled
+	}
+	if !s.validSignature(subscriberID, signature) {
+		return errs.NewBadRequest(errors.New("invalid unsubscribe link"))
+	}
+
+	sID, err := store.ParseSubscriberID(subscriberID)
+	if err != nil {
+		return err
+	}
+	return s.store.DeleteSubscriber(ctx, sID)
+}
+
+// Returns the signed link which deletes the subscription.
+func (s *Service) UnsubscribeURL(sub store.Subscriber) string {
+	q := url.Values{}
+	q.Set("sub", sub.ID.String())
+	q.Set("sig", s.sign(sub.ID.String()))
+	return changelogLink(sub.ChangelogURL, "/unsubscribe", q)
+}
+
+func (s *Service) confirmURL(sub store.Subscriber) string {
+	q := url.Values{}
+	q.Set("token", sub.ConfirmToken)
+	return changelogLink(sub.ChangelogURL, "/subscribe/confirm", q)
+}
+
+var errNoChangelogURL = errs.NewBadRequest(errors.New("changelog has no domain and email.baseUrl is not configured"))
+
+// Returns the url the changelog is served on, used for the links in emails.
+// Built from the domain or subdomain of the changelog, never from the request headers, which can be forged.
+func (s *Service) changelogURL(cl store.Changelog) (string, error) {
+	if cl.Domain.NullString().IsValid() {
+		return "https://" + cl.Domain.String(), nil
+	}
+	if s.cfg.Email.BaseURL == "" || cl.Subdomain == "" {
+		return "", errNoChangelogURL
+	}
+	base, err := url.Parse(s.cfg.Email.BaseURL)
+	if err != nil {
+		return "", err
+	}
+	return (&url.URL{Scheme: base.Scheme, Host: cl.Subdomain.String() + "." + base.Host}).String(), nil
+}
+
+// Joins the path and query with the changelog url.
+// The changelog url might contain the wid and cid query params, which are kept.
+func changelogLink(changelogURL string, path string, q url.Values) string {
+	u, err := url.Parse(changelogURL)
+	if err != nil {
+		return changelogURL + path
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + path
+	merged := u.Query()
+	for k, v := range q {
+		merged[k] = v
+	}
+	u.RawQuery = merged.Encode()
+	return u.String()
+}
+
+func (s *Service) sign(subscriberID string) string {
+	mac := hmac.New(sha256.New, []byte(s.cfg.Email.Secret))
+	mac.Write([]byte("unsubscribe:" + subscriberID))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *Service) validSignature(subscriberID string, signature string) bool {
+	sig, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	expected, _ := hex.DecodeString(s.sign(subscriberID))
+	return hmac.Equal(sig, expected)
+}
+
+func newConfirmToken() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func isNotFound(err error) bool {
+	var domErr errs.Error
+	return errors.As(err, &domErr) && domErr.DomainErr() == errs.ErrNotFound
+}
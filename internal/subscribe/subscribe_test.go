@@ -0,0 +1,374 @@
+package subscribe
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/apitypes"
+	"github.com/jonashiltl/openchangelog/internal"
+	"github.com/jonashiltl/openchangelog/internal/config"
+	"github.com/jonashiltl/openchangelog/internal/mail"
+	"github.com/jonashiltl/openchangelog/internal/parse"
+	"github.com/jonashiltl/openchangelog/internal/source"
+	"github.com/jonashiltl/openchangelog/internal/store"
+	"github.com/jonashiltl/openchangelog/migrations"
+)
+
+type fakeSender struct {
+	mu   sync.Mutex
+	sent []mail.Message
+}
+
+func (f *fakeSender) Send(ctx context.Context, msg mail.Message) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, msg)
+	return nil
+}
+
+func (f *fakeSender) last() mail.Message {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.sent[len(f.sent)-1]
+}
+
+type fakeSource struct {
+	notes []string
+}
+
+func (f *fakeSource) Load(ctx context.Context, page internal.Pagination) (source.LoadResult, error) {
+	raw := make([]source.RawReleaseNote, len(f.notes))
+	for i, n := range f.notes {
+		raw[i] = source.RawReleaseNote{Content: strings.NewReader(n)}
+	}
+	return source.LoadResult{Raw: raw}, nil
+}
+
+func (f *fakeSource) ID() source.ID {
+	return "fake"
+}
+
+func note(title string, publishedAt time.Time) string {
+	return fmt.Sprintf("---\ntitle: %s\npublishedAt: %s\n---\ncontent of %s", title, publishedAt.Format(time.RFC3339), title)
+}
+
+func setup(t *testing.T) (*Service, *fakeSender, store.Store, store.Changelog) {
+	t.Helper()
+	ctx := context.Background()
+	conn := fmt.Sprintf("file:%s?_foreign_keys=on", filepath.Join(t.TempDir(), "test.db"))
+
+	m, err := store.NewMigrator(conn, migrations.FS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	_, err = m.Up(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st, err := store.NewSQLiteStore(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ws, err := st.SaveWorkspace(ctx, store.Workspace{
+		ID:    store.NewWID(),
+		Name:  "test",
+		Token: store.NewToken(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cl, err := st.CreateChangelog(ctx, store.Changelog{
+		WorkspaceID: ws.ID,
+		ID:          store.NewCID(),
+		Title:       apitypes.NewString("Acme"),
+		Subdomain:   "acme",
+		ColorScheme: store.System,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.Config{
+		SqliteURL: conn,
+		Email: &config.EmailConfig{
+			From:    "changelog@example.com",
+			Secret:  "secret",
+			BaseURL: "https://openchangelog.com",
+		},
+	}
+	sender := &fakeSender{}
+	return NewService(cfg, st, sender), sender, st, cl
+}
+
+func TestSubscribeAndConfirm(t *testing.T) {
+	ctx := context.Background()
+	svc, sender, st, cl := setup(t)
+
+	err := svc.Subscribe(ctx, cl, "invalid")
+	if err == nil {
+		t.Fatal("expected invalid email to be rejected")
+	}
+
+	err = svc.Subscribe(ctx, cl, "Jane <Jane@Example.com>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sender.sent) != 1 {
+		t.Fatalf("expected confirmation email, got %d emails", len(sender.sent))
+	}
+
+	sub, err := st.GetSubscriberByEmail(ctx, cl.WorkspaceID, cl.ID, "jane@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sub.IsConfirmed() {
+		t.Fatal("expected subscriber to be unconfirmed")
+	}
+	if !strings.Contains(sender.last().Text, svc.confirmURL(sub)) {
+		t.Errorf("expected confirmation email to contain %s", svc.confirmURL(sub))
+	}
+
+	// subscribing again right away doesn't send another email
+	err = svc.Subscribe(ctx, cl, "jane@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sender.sent) != 1 {
+		t.Fatalf("expected confirmation resends to be throttled, got %d emails", len(sender.sent))
+	}
+
+	// subscribing again after the interval resends the confirmation
+	svc.confirmInterval = 0
+	err = svc.Subscribe(ctx, cl, "jane@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sender.sent) != 2 {
+		t.Fatalf("expected confirmation to be resent, got %d emails", len(sender.sent))
+	}
+
+	confirmed, err := svc.Confirm(ctx, sub.ConfirmToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !confirmed.IsConfirmed() {
+		t.Error("expected subscriber to be confirmed")
+	}
+
+	_, err = svc.Confirm(ctx, sub.ConfirmToken)
+	if !isNotFound(err) {
+		t.Errorf("expected confirmation token to be single use, got %v", err)
+	}
+
+	// confirmed subscribers don't receive another confirmation
+	err = svc.Subscribe(ctx, cl, "jane@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sender.sent) != 2 {
+		t.Errorf("expected no email for confirmed subscriber, got %d emails", len(sender.sent))
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	ctx := context.Background()
+	svc, _, st, cl := setup(t)
+
+	err := svc.Subscribe(ctx, cl, "jane@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub, err := st.GetSubscriberByEmail(ctx, cl.WorkspaceID, cl.ID, "jane@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := fmt.Sprintf("https://acme.openchangelog.com/unsubscribe?sig=%s&sub=%s", svc.sign(sub.ID.String()), sub.ID)
+	if svc.UnsubscribeURL(sub) != expected {
+		t.Errorf("expected unsubscribe url %s, got %s", expected, svc.UnsubscribeURL(sub))
+	}
+
+	tables := []struct {
+		name      string
+		signature string
+		valid     bool
+	}{
+		{
+			name:      "missing signature",
+			signature: "",
+		},
+		{
+			name:      "invalid signature",
+			signature: "abc",
+		},
+		{
+			name:      "signature of other subscriber",
+			signature: svc.sign(store.NewSubscriberID().String()),
+		},
+		{
+			name:      "valid signature",
+			signature: svc.sign(sub.ID.String()),
+			valid:     true,
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			err := svc.Unsubscribe(ctx, sub.ID.String(), table.signature)
+			if table.valid && err != nil {
+				t.Fatal(err)
+			}
+			if !table.valid && err == nil {
+				t.Fatal("expected unsubscribe to fail")
+			}
+		})
+	}
+
+	_, err = st.GetSubscriber(ctx, sub.ID)
+	if !isNotFound(err) {
+		t.Errorf("expected subscriber to be deleted, got %v", err)
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	ctx := context.Background()
+	svc, sender, st, cl := setup(t)
+
+	err := svc.Subscribe(ctx, cl, "jane@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub, err := st.GetSubscriberByEmail(ctx, cl.WorkspaceID, cl.ID, "jane@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = svc.Confirm(ctx, sub.ConfirmToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sent := len(sender.sent)
+
+	now := time.Now()
+	s := &fakeSource{notes: []string{note("v1", now.Add(-2*time.Hour))}}
+	d := NewDispatcher(nil, svc, parse.NewCache(parse.NewParser(parse.CreateGoldmark()), nil))
+
+	// the existing history is only remembered
+	err = d.Dispatch(ctx, cl, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sender.sent) != sent {
+		t.Fatalf("expected no emails for existing release notes, got %d", len(sender.sent)-sent)
+	}
+
+	s.notes = []string{
+		note("scheduled", now.Add(time.Hour)),
+		note("v3", now.Add(-time.Minute)),
+		note("v2", now.Add(-time.Hour)),
+		note("v1", now.Add(-2*time.Hour)),
+	}
+	err = d.Dispatch(ctx, cl, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sender.sent)-sent != 2 {
+		t.Fatalf("expected 2 emails, got %d", len(sender.sent)-sent)
+	}
+	first, second := sender.sent[sent], sender.sent[sent+1]
+	if first.Subject != "Acme: v2" || second.Subject != "Acme: v3" {
+		t.Errorf("expected oldest release note first, got %q and %q", first.Subject, second.Subject)
+	}
+	if first.Headers["List-Unsubscribe"] != fmt.Sprintf("<%s>", svc.UnsubscribeURL(sub)) {
+		t.Errorf("expected List-Unsubscribe header, got %s", first.Headers["List-Unsubscribe"])
+	}
+
+	// dispatching again doesn't send duplicates
+	err = d.Dispatch(ctx, cl, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sender.sent)-sent != 2 {
+		t.Errorf("expected no duplicate emails, got %d", len(sender.sent)-sent)
+	}
+}
+
+func TestChangelogURL(t *testing.T) {
+	svc, _, _, cl := setup(t)
+
+	withDomain := cl
+	withDomain.Domain = store.Domain(apitypes.NewString("changelog.acme.com"))
+	cfg := svc.cfg
+	cfg.Email = &config.EmailConfig{Secret: "secret"}
+	noBaseURL := NewService(cfg, svc.store, svc.sender)
+
+	tables := []struct {
+		name     string
+		svc      *Service
+		cl       store.Changelog
+		expected string
+	}{
+		{name: "subdomain", svc: svc, cl: cl, expected: "https://acme.openchangelog.com"},
+		{name: "domain", svc: svc, cl: withDomain, expected: "https://changelog.acme.com"},
+		{name: "domain without base url", svc: noBaseURL, cl: withDomain, expected: "https://changelog.acme.com"},
+		{name: "subdomain without base url", svc: noBaseURL, cl: cl},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			u, err := table.svc.changelogURL(table.cl)
+			if table.expected == "" {
+				if err == nil {
+					t.Errorf("expected error but got %s", u)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if u != table.expected {
+				t.Errorf("expected %s but got %s", table.expected, u)
+			}
+		})
+	}
+}
+
+func TestDispatcherLocksPerChangelog(t *testing.T) {
+	d := NewDispatcher(nil, nil, nil)
+	a, b := store.ChangelogID("cl_a"), store.ChangelogID("cl_b")
+
+	unlockA := d.lock(a)
+	lockedB := make(chan struct{})
+	unlocked := make(chan struct{})
+	go func() {
+		d.lock(b)()
+		close(lockedB)
+		unlock := d.lock(a)
+		close(unlocked)
+		unlock()
+	}()
+
+	select {
+	case <-lockedB:
+	case <-time.After(time.Second):
+		t.Fatal("expected another changelog not to be blocked")
+	}
+	select {
+	case <-unlocked:
+		t.Fatal("expected the changelog to stay locked")
+	case <-time.After(50 * time.Millisecond):
+	}
+	unlockA()
+	select {
+	case <-unlocked:
+	case <-time.After(time.Second):
+		t.Fatal("expected the changelog to be unlocked")
+	}
+}
@@ -0,0 +1,380 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/btvoidx/mint"
+	"github.com/jonashiltl/openchangelog/apitypes"
+	"github.com/jonashiltl/openchangelog/internal"
+	"github.com/jonashiltl/openchangelog/internal/config"
+	"github.com/jonashiltl/openchangelog/internal/events"
+	"github.com/jonashiltl/openchangelog/internal/parse"
+	"github.com/jonashiltl/openchangelog/internal/source"
+	"github.com/jonashiltl/openchangelog/internal/store"
+	"github.com/jonashiltl/openchangelog/internal/xlog"
+)
+
+const (
+	maxAttempts    = 5
+	defaultBackoff = 10 * time.Second
+	requestTimeout = 10 * time.Second
+)
+
+// Creates a new Dispatcher.
+// The webhook urls are defined by the workspaces, so they can't reach private addresses of the server's network.
+func NewDispatcher(cfg config.Config, e *mint.Emitter, st store.Store, parser *parse.Cache) *Dispatcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Dispatcher{
+		cfg:     cfg,
+		e:       e,
+		store:   st,
+		parser:  parser,
+		client:  &http.Client{Transport: source.NewPublicTransport(), Timeout: requestTimeout},
+		backoff: defaultBackoff,
+		ctx:     ctx,
+		cancel:  cancel,
+		pending: make(map[string]bool),
+	}
+}
+
+// Calls the webhooks of a changelog when release notes are published.
+// Release notes are published when they are added to the source,
+// or once their scheduled publishedAt passes.
+// The release notes which exist when a webhook is created, or when a source is first dispatched for it, are skipped.
+// Failed deliveries are retried with an exponential backoff, every attempt is saved in the delivery log.
+type Dispatcher struct {
+	cfg     config.Config
+	e       *mint.Emitter
+	store   store.Store
+	parser  *parse.Cache
+	client  *http.Client
+	backoff time.Duration
+	ctx     context.Context
+	cancel  context.CancelFunc
+	offs    []func() <-chan struct{}
+	wg      sync.WaitGroup
+	// guards pending
+	mu sync.Mutex
+	// deliveries which are in progress, keyed by webhook and release note id
+	pending map[string]bool
+}
+
+// Starts listening to source changes and published release notes,
+// and checks all changelogs with webhooks for new release notes.
+// Webhooks are only available in db mode.
+func (d *Dispatcher) Start() {
+	if !d.cfg.IsDBMode() {
+		return
+	}
+	d.offs = append(d.offs, mint.On(d.e, d.OnSourceChanged), mint.On(d.e, d.OnReleaseNotePublished), mint.On(d.e, d.OnWebhookCreated))
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.dispatchAll()
+	}()
+}
+
+// Stops listening to events and cancels pending deliveries.
+func (d *Dispatcher) Close() {
+	for _, off := range d.offs {
+		off()
+	}
+	d.cancel()
+	d.wg.Wait()
+}
+
+func (d *Dispatcher) OnSourceChanged(e events.SourceContentChanged) {
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.dispatchLogged(e.CL, e.Source)
+	}()
+}
+
+func (d *Dispatcher) OnReleaseNotePublished(e events.ReleaseNotePublished) {
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.dispatchLogged(e.CL, e.Source)
+	}()
+}
+
+// Records the existing release notes of all sources before the creation returns,
+// so release notes added right after are delivered.
+func (d *Dispatcher) OnWebhookCreated(e events.WebhookCreated) {
+	sources, err := source.NewSourcesFromStore(d.cfg, e.CL, nil)
+	if err != nil {
+		slog.Error("failed to create sources of webhook", slog.String("webhook", e.Webhook.ID.String()), xlog.ErrAttr(err))
+		return
+	}
+	for _, s := range sources {
+		err := d.recordSource(d.ctx, e.CL, e.Webhook, s)
+		if err != nil {
+			slog.Error("failed to record release notes of webhook", slog.String("webhook", e.Webhook.ID.String()), xlog.ErrAttr(err))
+		}
+	}
+}
+
+func (d *Dispatcher) dispatchAll() {
+	whs, err := d.store.ListAllWebhooks(d.ctx)
+	if err != nil {
+		slog.Error("failed to list webhooks", xlog.ErrAttr(err))
+		return
+	}
+
+	seen := make(map[store.ChangelogID]bool)
+	for _, wh := range whs {
+		if seen[wh.ChangelogID] {
+			continue
+		}
+		seen[wh.ChangelogID] = true
+
+		cl, err := d.store.GetChangelog(d.ctx, wh.WorkspaceID, wh.ChangelogID)
+		if err != nil {
+			slog.Error("failed to get changelog of webhook", slog.String("cid", wh.ChangelogID.String()), xlog.ErrAttr(err))
+			continue
+		}
+		sources, err := source.NewSourcesFromStore(d.cfg, cl, nil)
+		if err != nil {
+			slog.Error("failed to create sources of changelog", slog.String("cid", cl.ID.String()), xlog.ErrAttr(err))
+			continue
+		}
+		for _, s := range sources {
+			d.dispatchLogged(cl, s)
+		}
+	}
+}
+
+func (d *Dispatcher) dispatchLogged(cl store.Changelog, s source.Source) {
+	err := d.Dispatch(d.ctx, cl, s)
+	if err != nil {
+		slog.Error("failed to dispatch webhooks", slog.String("cid", cl.ID.String()), xlog.ErrAttr(err))
+	}
+}
+
+// Calls the webhooks of the changelog for the published release notes of the source they weren't called for yet.
+// If the source wasn't dispatched for a webhook yet, it's existing release notes are only recorded.
+// Deliveries happen in the background, oldest release note first.
+func (d *Dispatcher) Dispatch(ctx context.Context, cl store.Changelog, s source.Source) error {
+	if s == nil {
+		return nil
+	}
+	whs, err := d.store.ListWebhooks(ctx, cl.WorkspaceID, cl.ID)
+	if err != nil {
+		return err
+	}
+	if len(whs) == 0 {
+		return nil
+	}
+
+	notes, err := d.load(ctx, cl, s)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	articles := make(map[string]apitypes.Article)
+	for _, wh := range whs {
+		recorded, err := d.store.ListWebhookSources(ctx, wh.ID)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(recorded, string(s.ID())) {
+			err = d.store.SaveWebhookSource(ctx, wh.ID, string(s.ID()), publishedIDs(notes, now))
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		delivered, err := d.store.ListDeliveredReleaseNotes(ctx, wh.ID)
+		if err != nil {
+			return err
+		}
+		skipped, err := d.store.ListSkippedReleaseNotes(ctx, wh.ID)
+		if err != nil {
+			return err
+		}
+		known := slices.Concat(delivered, skipped)
+
+		var payloads []apitypes.WebhookPayload
+		d.mu.Lock()
+		for _, n := range notes {
+			if !isNew(n, known, now) || d.pending[pendingKey(wh, n.Meta.ID)] {
+				continue
+			}
+
+			a, ok := articles[n.Meta.ID]
+			if !ok {
+				a, err = toArticle(n)
+				if err != nil {
+					d.mu.Unlock()
+					return err
+				}
+				articles[n.Meta.ID] = a
+			}
+
+			d.pending[pendingKey(wh, n.Meta.ID)] = true
+			payloads = append(payloads, apitypes.WebhookPayload{
+				Event:       apitypes.ReleaseNotePublishedEvent,
+				WorkspaceID: cl.WorkspaceID.String(),
+				ChangelogID: cl.ID.String(),
+				Article:     a,
+			})
+		}
+		d.mu.Unlock()
+
+		if len(payloads) > 0 {
+			d.wg.Add(1)
+			go func() {
+				defer d.wg.Done()
+				for _, p := range payloads {
+					d.deliver(wh, p)
+				}
+			}()
+		}
+	}
+
+	return nil
+}
+
+// Records the existing release notes of the source for the webhook, they are skipped.
+// Does nothing if the source was already recorded.
+func (d *Dispatcher) recordSource(ctx context.Context, cl store.Changelog, wh store.Webhook, s source.Source) error {
+	recorded, err := d.store.ListWebhookSources(ctx, wh.ID)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(recorded, string(s.ID())) {
+		return nil
+	}
+	notes, err := d.load(ctx, cl, s)
+	if err != nil {
+		return err
+	}
+	return d.store.SaveWebhookSource(ctx, wh.ID, string(s.ID()), publishedIDs(notes, time.Now()))
+}
+
+// Loads and parses the release notes of the source, oldest first.
+// The source is loaded directly instead of through the loader, which would emit the SourceContentChanged event
+// and dispatch again.
+func (d *Dispatcher) load(ctx context.Context, cl store.Changelog, s source.Source) ([]parse.ParsedReleaseNote, error) {
+	loaded, err := s.Load(ctx, internal.NoPagination())
+	if err != nil {
+		return nil, err
+	}
+	parsed := d.parser.Parse(ctx, s.ID(), loaded.Raw, internal.NoPagination())
+
+	notes := slices.Clone(parsed.ReleaseNotes)
+	if source.IsCombined(cl) {
+		for i, n := range notes {
+			notes[i].Meta.ID = source.CombinedNoteID(cl, s.ID(), n.Meta.ID)
+		}
+	}
+	// parsed release notes are sorted newest first
+	slices.Reverse(notes)
+	return notes, nil
+}
+
+// Returns the ids of the published release notes, scheduled release notes are delivered once published.
+func publishedIDs(notes []parse.ParsedReleaseNote, now time.Time) []string {
+	ids := make([]string, 0, len(notes))
+	for _, n := range notes {
+		if n.Meta.Published(now) {
+			ids = append(ids, n.Meta.ID)
+		}
+	}
+	return ids
+}
+
+// Returns true if the release note is published and the webhook wasn't called for it or skipped it.
+func isNew(n parse.ParsedReleaseNote, known []string, now time.Time) bool {
+	return n.Meta.Published(now) && !slices.Contains(known, n.Meta.ID)
+}
+
+func pendingKey(wh store.Webhook, noteID string) string {
+	return fmt.Sprintf("%s/%s", wh.ID, noteID)
+}
+
+// Delivers the payload to the webhook, retries failed attempts with an exponential backoff.
+func (d *Dispatcher) deliver(wh store.Webhook, p apitypes.WebhookPayload) {
+	defer func() {
+		d.mu.Lock()
+		delete(d.pending, pendingKey(wh, p.Article.ID))
+		d.mu.Unlock()
+	}()
+
+	body, err := json.Marshal(p)
+	if err != nil {
+		slog.Error("failed to encode webhook payload", xlog.ErrAttr(err))
+		return
+	}
+
+	backoff := d.backoff
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		delivery := d.send(wh, p, body, attempt)
+		if d.ctx.Err() != nil {
+			// not saved, so the release note is delivered again after a restart
+			return
+		}
+		_, err := d.store.CreateWebhookDelivery(context.Background(), delivery)
+		if err != nil {
+			slog.Error("failed to save webhook delivery", slog.String("webhook", wh.ID.String()), xlog.ErrAttr(err))
+		}
+		if delivery.Success() || attempt == maxAttempts {
+			return
+		}
+
+		select {
+		case <-d.ctx.Done():
+			return
+		case <-time.After(backoff):
+			backoff *= 2
+		}
+	}
+}
+
+// Sends a single delivery attempt.
+func (d *Dispatcher) send(wh store.Webhook, p apitypes.WebhookPayload, body []byte, attempt int) store.WebhookDelivery {
+	delivery := store.WebhookDelivery{
+		ID:            store.NewWebhookDeliveryID(),
+		WebhookID:     wh.ID,
+		ReleaseNoteID: p.Article.ID,
+		Event:         p.Event,
+		Attempt:       attempt,
+	}
+
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodPost, wh.URL, bytes.NewReader(body))
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Openchangelog-Webhook")
+	req.Header.Set(EventHeader, p.Event)
+	req.Header.Set(DeliveryHeader, delivery.ID.String())
+	req.Header.Set(SignatureHeader, Sign(wh.Secret, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+	defer resp.Body.Close()
+
+	delivery.StatusCode = resp.StatusCode
+	// the response body isn't saved, the delivery log is returned by the api
+	if !delivery.Success() {
+		delivery.Error = fmt.Sprintf("unexpected status %d", resp.StatusCode)
+	}
+	return delivery
+}
@@ -0,0 +1,261 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btvoidx/mint"
+	"github.com/jonashiltl/openchangelog/apitypes"
+	"github.com/jonashiltl/openchangelog/internal"
+	"github.com/jonashiltl/openchangelog/internal/config"
+	"github.com/jonashiltl/openchangelog/internal/events"
+	"github.com/jonashiltl/openchangelog/internal/load"
+	"github.com/jonashiltl/openchangelog/internal/parse"
+	"github.com/jonashiltl/openchangelog/internal/source"
+	"github.com/jonashiltl/openchangelog/internal/store"
+	"github.com/jonashiltl/openchangelog/internal/xcache"
+	"github.com/jonashiltl/openchangelog/migrations"
+)
+
+type receiver struct {
+	mu       sync.Mutex
+	secret   string
+	payloads []apitypes.WebhookPayload
+	// number of requests which fail before succeeding
+	failures int
+	received chan struct{}
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	body, _ := io.ReadAll(r.Body)
+	if r.Header.Get(SignatureHeader) != Sign(rc.secret, body) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+	if rc.failures > 0 {
+		rc.failures--
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	var p apitypes.WebhookPayload
+	json.Unmarshal(body, &p)
+	rc.payloads = append(rc.payloads, p)
+	rc.received <- struct{}{}
+}
+
+// Writes a release note with the title as slug, without a publishedAt if it's zero.
+func writeNote(t *testing.T, dir string, title string, publishedAt time.Time) {
+	t.Helper()
+	content := fmt.Sprintf("---\ntitle: %s\nslug: %s\n---\ncontent of %s", title, title, title)
+	if !publishedAt.IsZero() {
+		content = fmt.Sprintf("---\ntitle: %s\nslug: %s\npublishedAt: %s\n---\ncontent of %s", title, title, publishedAt.Format(time.RFC3339), title)
+	}
+	err := os.WriteFile(filepath.Join(dir, title+".md"), []byte(content), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func setup(t *testing.T, dir string) (*Dispatcher, *load.Loader, store.Store, store.Changelog) {
+	t.Helper()
+	ctx := context.Background()
+	conn := fmt.Sprintf("file:%s?_foreign_keys=on", filepath.Join(t.TempDir(), "test.db"))
+
+	m, err := store.NewMigrator(conn, migrations.FS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	_, err = m.Up(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.Config{
+		SqliteURL: conn,
+		Local:     &config.LocalConfig{FilesPath: dir},
+	}
+	st, err := store.NewSQLiteStore(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ws, err := st.SaveWorkspace(ctx, store.Workspace{ID: store.NewWID(), Name: "test", Token: store.NewToken()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cl, err := st.CreateChangelog(ctx, store.Changelog{WorkspaceID: ws.ID, ID: store.NewCID(), ColorScheme: store.System})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lc, err := st.CreateLocalSource(ctx, store.LocalSource{ID: store.NewLocalID(), WorkspaceID: ws.ID, Path: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = st.SetChangelogLocalSource(ctx, ws.ID, cl.ID, lc.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cl, err = st.GetChangelog(ctx, ws.ID, cl.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := new(mint.Emitter)
+	cache := xcache.NewMemoryCache()
+	loader := load.NewLoader(cfg, st, cache, parse.NewCache(parse.NewParser(parse.CreateGoldmark()), cache), e)
+	t.Cleanup(loader.Close)
+	d := NewDispatcher(cfg, e, st, parse.NewCache(parse.NewParser(parse.CreateGoldmark()), cache))
+	d.backoff = time.Millisecond
+	// the receivers listen on the loopback address
+	d.client = &http.Client{Timeout: requestTimeout}
+	d.Start()
+	t.Cleanup(d.Close)
+	return d, loader, st, cl
+}
+
+func waitReceived(t *testing.T, rc *receiver) {
+	t.Helper()
+	select {
+	case <-rc.received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for webhook")
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	now := time.Now()
+	writeNote(t, dir, "old", now.Add(-24*time.Hour))
+
+	d, loader, st, cl := setup(t, dir)
+
+	rc := &receiver{secret: "secret", failures: 1, received: make(chan struct{}, 10)}
+	server := httptest.NewServer(rc)
+	defer server.Close()
+
+	wh, err := st.CreateWebhook(ctx, store.Webhook{
+		ID:          store.NewWebhookID(),
+		WorkspaceID: cl.WorkspaceID,
+		ChangelogID: cl.ID,
+		URL:         server.URL,
+		Secret:      "secret",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the existing release notes are skipped
+	d.OnWebhookCreated(events.WebhookCreated{CL: cl, Webhook: wh})
+
+	// back-dated and undated release notes are new too
+	writeNote(t, dir, "backdated", now.Add(-48*time.Hour))
+	writeNote(t, dir, "undated", time.Time{})
+	writeNote(t, dir, "scheduled", now.Add(time.Second))
+
+	// the loaded source changed and is dispatched
+	_, err = loader.LoadAndParseReleaseNotes(ctx, cl, internal.NoPagination())
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitReceived(t, rc)
+	waitReceived(t, rc)
+
+	// the scheduled release note is sent once it's publishedAt passes
+	waitReceived(t, rc)
+
+	// dispatching again doesn't send duplicates
+	s, err := source.NewSourceFromStore(d.cfg, cl, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = d.Dispatch(ctx, cl, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-rc.received:
+		t.Error("expected no duplicate deliveries")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if len(rc.payloads) != 3 {
+		t.Fatalf("expected 3 payloads, got %d", len(rc.payloads))
+	}
+	titles := make([]string, len(rc.payloads))
+	for i, p := range rc.payloads {
+		titles[i] = p.Article.Title
+	}
+	slices.Sort(titles)
+	if !slices.Equal(titles, []string{"backdated", "scheduled", "undated"}) {
+		t.Errorf("expected the backdated, scheduled and undated release notes, got %v", titles)
+	}
+	if rc.payloads[0].Event != apitypes.ReleaseNotePublishedEvent || rc.payloads[0].ChangelogID != cl.ID.String() {
+		t.Errorf("unexpected payload %+v", rc.payloads[0])
+	}
+	if rc.payloads[0].Article.HTMLContent == "" {
+		t.Error("expected article content")
+	}
+
+	deliveries, err := st.ListWebhookDeliveries(ctx, wh.ID, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the first attempt failed and was retried
+	if len(deliveries) != 4 {
+		t.Fatalf("expected 4 deliveries, got %d", len(deliveries))
+	}
+	failed := 0
+	for _, del := range deliveries {
+		if !del.Success() {
+			failed++
+			if del.StatusCode != http.StatusServiceUnavailable || del.Error == "" {
+				t.Errorf("expected failed delivery to be logged, got %+v", del)
+			}
+			if strings.Contains(del.Error, "unavailable") {
+				t.Errorf("expected the response body not to be logged, got %q", del.Error)
+			}
+		}
+	}
+	if failed != 1 {
+		t.Errorf("expected 1 failed delivery, got %d", failed)
+	}
+}
+
+func TestSendRejectsPrivateAddresses(t *testing.T) {
+	requested := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+	}))
+	defer server.Close()
+
+	d := NewDispatcher(config.Config{}, new(mint.Emitter), nil, nil)
+	defer d.Close()
+
+	wh := store.Webhook{ID: store.NewWebhookID(), URL: server.URL, Secret: "secret"}
+	p := apitypes.WebhookPayload{Event: apitypes.ReleaseNotePublishedEvent, Article: apitypes.Article{ID: "v1"}}
+	delivery := d.send(wh, p, []byte("{}"), 1)
+	if delivery.Success() || !strings.Contains(delivery.Error, source.ErrPrivateAddress.Error()) {
+		t.Errorf("expected the loopback address to be rejected, got %+v", delivery)
+	}
+	if requested {
+		t.Error("expected no request to reach the server")
+	}
+}
@@ -0,0 +1,76 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/url"
+
+	"github.com/jonashiltl/openchangelog/apitypes"
+	"github.com/jonashiltl/openchangelog/internal/errs"
+	"github.com/jonashiltl/openchangelog/internal/parse"
+)
+
+const (
+	// HMAC-SHA256 signature of the body with the webhook secret, formatted as sha256=<hex>
+	SignatureHeader = "X-Openchangelog-Signature"
+	EventHeader     = "X-Openchangelog-Event"
+	// Unique per delivery attempt
+	DeliveryHeader = "X-Openchangelog-Delivery"
+)
+
+// Creates a random secret to sign the payloads of a webhook.
+func NewSecret() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Returns the signature of body, which is sent in the SignatureHeader.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Returns an error if u can't be used as webhook url.
+func ValidateURL(u string) error {
+	parsed, err := url.Parse(u)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return errs.NewBadRequest(errors.New("webhook url must be an absolute http or https url"))
+	}
+	return nil
+}
+
+// Converts the parsed release note to the article of the webhook payload.
+// Consumes the content of the release note.
+func toArticle(n parse.ParsedReleaseNote) (apitypes.Article, error) {
+	var content []byte
+	if n.Content != nil {
+		var err error
+		content, err = io.ReadAll(n.Content)
+		if err != nil {
+			return apitypes.Article{}, err
+		}
+	}
+	return apitypes.Article{
+		ID:          n.Meta.ID,
+		Title:       n.Meta.Title,
+		Description: n.Meta.Description,
+		PublishedAt: n.Meta.PublishedAt,
+		Tags:        n.Meta.Tags,
+		Version:     n.Meta.Version,
+		Slug:        n.Meta.Slug,
+		Authors:     n.Meta.Authors,
+		Image:       n.Meta.Image,
+		Draft:       n.Meta.Draft,
+		Pinned:      n.Meta.Pinned,
+		HTMLContent: string(content),
+	}, nil
+}
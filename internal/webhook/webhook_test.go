@@ -0,0 +1,38 @@
+package webhook
+
+import "testing"
+
+func TestSign(t *testing.T) {
+	// generated with: echo -n '{"event":"test"}' | openssl dgst -sha256 -hmac secret
+	expected := "sha256=8419ab361b37d61b696d008ef7549a18325132dae5da84c7424e8e1c590d0498"
+	got := Sign("secret", []byte(`{"event":"test"}`))
+	if got != expected {
+		t.Errorf("expected signature %s, got %s", expected, got)
+	}
+	if Sign("secret", []byte("a")) == Sign("other", []byte("a")) {
+		t.Error("expected signature to depend on the secret")
+	}
+}
+
+func TestValidateURL(t *testing.T) {
+	tables := []struct {
+		url   string
+		valid bool
+	}{
+		{url: "https://example.com/hook", valid: true},
+		{url: "http://localhost:8080", valid: true},
+		{url: "ftp://example.com", valid: false},
+		{url: "/relative", valid: false},
+		{url: "", valid: false},
+	}
+
+	for _, table := range tables {
+		err := ValidateURL(table.url)
+		if table.valid && err != nil {
+			t.Errorf("expected %q to be valid, got %v", table.url, err)
+		}
+		if !table.valid && err == nil {
+			t.Errorf("expected %q to be invalid", table.url)
+		}
+	}
+}
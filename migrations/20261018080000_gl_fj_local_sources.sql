@@ -0,0 +1,69 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS gl_sources (
+    id TEXT NOT NULL,
+    workspace_id TEXT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    base_url TEXT NOT NULL,
+    project TEXT NOT NULL,
+    path TEXT NOT NULL,
+    ref TEXT NOT NULL,
+    token TEXT NOT NULL,
+    PRIMARY KEY (workspace_id, id)
+);
+
+CREATE TABLE IF NOT EXISTS fj_sources (
+    id TEXT NOT NULL,
+    workspace_id TEXT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    base_url TEXT NOT NULL,
+    project TEXT NOT NULL,
+    path TEXT NOT NULL,
+    ref TEXT NOT NULL,
+    token TEXT NOT NULL,
+    PRIMARY KEY (workspace_id, id)
+);
+
+CREATE TABLE IF NOT EXISTS local_sources (
+    id TEXT NOT NULL,
+    workspace_id TEXT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    path TEXT NOT NULL,
+    PRIMARY KEY (workspace_id, id)
+);
+
+-- same as changelog_source, one nullable view per source type
+CREATE VIEW changelog_gl_source AS
+SELECT gl.*
+FROM changelogs cl
+LEFT JOIN gl_sources gl
+    ON cl.workspace_id = gl.workspace_id
+    AND cl.source_id LIKE 'gl_%'
+    AND cl.source_id = gl.id
+GROUP BY source_id, gl.workspace_id;
+
+CREATE VIEW changelog_fj_source AS
+SELECT fj.*
+FROM changelogs cl
+LEFT JOIN fj_sources fj
+    ON cl.workspace_id = fj.workspace_id
+    AND cl.source_id LIKE 'fj_%'
+    AND cl.source_id = fj.id
+GROUP BY source_id, fj.workspace_id;
+
+CREATE VIEW changelog_local_source AS
+SELECT lc.*
+FROM changelogs cl
+LEFT JOIN local_sources lc
+    ON cl.workspace_id = lc.workspace_id
+    AND cl.source_id LIKE 'lc_%'
+    AND cl.source_id = lc.id
+GROUP BY source_id, lc.workspace_id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP VIEW changelog_local_source;
+DROP VIEW changelog_fj_source;
+DROP VIEW changelog_gl_source;
+DROP TABLE local_sources;
+DROP TABLE fj_sources;
+DROP TABLE gl_sources;
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS subscribers (
+    id TEXT PRIMARY KEY,
+    workspace_id TEXT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    changelog_id TEXT NOT NULL,
+    email TEXT NOT NULL,
+    changelog_url TEXT NOT NULL,
+    -- token of the double opt-in confirmation link, NULL once confirmed
+    confirm_token TEXT,
+    confirmed_at INTEGER,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch('now')),
+    FOREIGN KEY (workspace_id, changelog_id) REFERENCES changelogs(workspace_id, id) ON DELETE CASCADE
+) STRICT;
+
+CREATE UNIQUE INDEX subscribers_changelog_email ON subscribers(workspace_id, changelog_id, email);
+CREATE UNIQUE INDEX subscribers_confirm_token ON subscribers(confirm_token);
+
+-- release notes subscribers were already notified about
+CREATE TABLE IF NOT EXISTS dispatched_release_notes (
+    workspace_id TEXT NOT NULL,
+    changelog_id TEXT NOT NULL,
+    release_note_id TEXT NOT NULL,
+    dispatched_at INTEGER NOT NULL DEFAULT (unixepoch('now')),
+    PRIMARY KEY (workspace_id, changelog_id, release_note_id),
+    FOREIGN KEY (workspace_id, changelog_id) REFERENCES changelogs(workspace_id, id) ON DELETE CASCADE
+) STRICT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE dispatched_release_notes;
+DROP INDEX subscribers_confirm_token;
+DROP INDEX subscribers_changelog_email;
+DROP TABLE subscribers;
+-- +goose StatementEnd
@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS webhooks (
+    id TEXT PRIMARY KEY,
+    workspace_id TEXT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    changelog_id TEXT NOT NULL,
+    url TEXT NOT NULL,
+    -- signs the payloads
+    secret TEXT NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch('now')),
+    FOREIGN KEY (workspace_id, changelog_id) REFERENCES changelogs(workspace_id, id) ON DELETE CASCADE
+) STRICT;
+
+CREATE INDEX webhooks_changelog ON webhooks(workspace_id, changelog_id);
+
+-- every delivery attempt of a webhook
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id TEXT PRIMARY KEY,
+    webhook_id TEXT NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    release_note_id TEXT NOT NULL,
+    event TEXT NOT NULL,
+    attempt INTEGER NOT NULL,
+    -- 0 if no response was received
+    status_code INTEGER NOT NULL,
+    error TEXT,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch('now'))
+) STRICT;
+
+CREATE INDEX webhook_deliveries_webhook ON webhook_deliveries(webhook_id, release_note_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX webhook_deliveries_webhook;
+DROP TABLE webhook_deliveries;
+DROP INDEX webhooks_changelog;
+DROP TABLE webhooks;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS git_sources (
+    id TEXT NOT NULL,
+    workspace_id TEXT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    ref TEXT NOT NULL,
+    path TEXT NOT NULL,
+    PRIMARY KEY (workspace_id, id)
+);
+
+CREATE VIEW changelog_git_source AS
+SELECT git.*
+FROM changelogs cl
+LEFT JOIN git_sources git
+    ON cl.workspace_id = git.workspace_id
+    AND cl.source_id LIKE 'git_%'
+    AND cl.source_id = git.id
+GROUP BY source_id, git.workspace_id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP VIEW changelog_git_source;
+DROP TABLE git_sources;
+-- +goose StatementEnd
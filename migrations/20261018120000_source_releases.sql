@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- load release notes from the releases of the repository instead of markdown files
+ALTER TABLE gh_sources ADD COLUMN releases INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE gl_sources ADD COLUMN releases INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE fj_sources ADD COLUMN releases INTEGER NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE fj_sources DROP COLUMN releases;
+ALTER TABLE gl_sources DROP COLUMN releases;
+ALTER TABLE gh_sources DROP COLUMN releases;
+-- +goose StatementEnd
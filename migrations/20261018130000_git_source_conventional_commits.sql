@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- generate the release notes from the tags and conventional commits instead of markdown files
+ALTER TABLE git_sources ADD COLUMN conventional_commits INTEGER NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE git_sources DROP COLUMN conventional_commits;
+-- +goose StatementEnd
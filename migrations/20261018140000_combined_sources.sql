@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the ordered sources of changelogs combining multiple sources, changelogs.source_id holds the first one
+CREATE TABLE IF NOT EXISTS combined_sources (
+    workspace_id TEXT NOT NULL,
+    changelog_id TEXT NOT NULL,
+    source_id TEXT NOT NULL,
+    position INTEGER NOT NULL,
+    PRIMARY KEY (workspace_id, changelog_id, source_id),
+    FOREIGN KEY (workspace_id, changelog_id) REFERENCES changelogs(workspace_id, id) ON DELETE CASCADE
+) STRICT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE combined_sources;
+-- +goose StatementEnd
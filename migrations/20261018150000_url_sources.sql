@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS url_sources (
+    id TEXT NOT NULL,
+    workspace_id TEXT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    -- the url points to a manifest listing the urls of the release notes
+    manifest INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (workspace_id, id)
+);
+
+CREATE VIEW changelog_url_source AS
+SELECT urls.*
+FROM changelogs cl
+LEFT JOIN url_sources urls
+    ON cl.workspace_id = urls.workspace_id
+    AND cl.source_id LIKE 'url_%'
+    AND cl.source_id = urls.id
+GROUP BY source_id, urls.workspace_id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP VIEW changelog_url_source;
+DROP TABLE url_sources;
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS s3_sources (
+    id TEXT NOT NULL,
+    workspace_id TEXT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    endpoint TEXT NOT NULL,
+    region TEXT NOT NULL,
+    bucket TEXT NOT NULL,
+    prefix TEXT NOT NULL,
+    access_key_id TEXT NOT NULL,
+    secret_access_key TEXT NOT NULL,
+    PRIMARY KEY (workspace_id, id)
+);
+
+CREATE VIEW changelog_s3_source AS
+SELECT s3s.*
+FROM changelogs cl
+LEFT JOIN s3_sources s3s
+    ON cl.workspace_id = s3s.workspace_id
+    AND cl.source_id LIKE 's3_%'
+    AND cl.source_id = s3s.id
+GROUP BY source_id, s3s.workspace_id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP VIEW changelog_s3_source;
+DROP TABLE s3_sources;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- comma separated glob patterns selecting the files of directory sources
+ALTER TABLE gh_sources ADD COLUMN include_patterns TEXT NOT NULL DEFAULT '';
+ALTER TABLE gh_sources ADD COLUMN exclude_patterns TEXT NOT NULL DEFAULT '';
+ALTER TABLE gl_sources ADD COLUMN include_patterns TEXT NOT NULL DEFAULT '';
+ALTER TABLE gl_sources ADD COLUMN exclude_patterns TEXT NOT NULL DEFAULT '';
+ALTER TABLE fj_sources ADD COLUMN include_patterns TEXT NOT NULL DEFAULT '';
+ALTER TABLE fj_sources ADD COLUMN exclude_patterns TEXT NOT NULL DEFAULT '';
+ALTER TABLE local_sources ADD COLUMN include_patterns TEXT NOT NULL DEFAULT '';
+ALTER TABLE local_sources ADD COLUMN exclude_patterns TEXT NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE local_sources DROP COLUMN exclude_patterns;
+ALTER TABLE local_sources DROP COLUMN include_patterns;
+ALTER TABLE fj_sources DROP COLUMN exclude_patterns;
+ALTER TABLE fj_sources DROP COLUMN include_patterns;
+ALTER TABLE gl_sources DROP COLUMN exclude_patterns;
+ALTER TABLE gl_sources DROP COLUMN include_patterns;
+ALTER TABLE gh_sources DROP COLUMN exclude_patterns;
+ALTER TABLE gh_sources DROP COLUMN include_patterns;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the url of a GitHub Enterprise Server, empty for github.com
+ALTER TABLE gh_sources ADD COLUMN base_url TEXT NOT NULL DEFAULT '';
+-- the branch, tag or commit to load from, empty for the default branch
+ALTER TABLE gh_sources ADD COLUMN ref TEXT NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE gh_sources DROP COLUMN ref;
+ALTER TABLE gh_sources DROP COLUMN base_url;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- installations of the GitHub App, made by a workspace through the setup flow
+CREATE TABLE IF NOT EXISTS gh_installations (
+    workspace_id TEXT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    installation_id INTEGER NOT NULL,
+    -- the login of the user or organization the app is installed on
+    account TEXT NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch('now')),
+    PRIMARY KEY (workspace_id, installation_id)
+) STRICT;
+
+CREATE INDEX gh_sources_installation ON gh_sources(installation_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX gh_sources_installation;
+DROP TABLE gh_installations;
+-- +goose StatementEnd
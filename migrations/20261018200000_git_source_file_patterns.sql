@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- comma separated glob patterns selecting the files of git directory sources
+ALTER TABLE git_sources ADD COLUMN include_patterns TEXT NOT NULL DEFAULT '';
+ALTER TABLE git_sources ADD COLUMN exclude_patterns TEXT NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE git_sources DROP COLUMN exclude_patterns;
+ALTER TABLE git_sources DROP COLUMN include_patterns;
+-- +goose StatementEnd
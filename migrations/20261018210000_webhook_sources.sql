@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- sources whose existing release notes were recorded for a webhook, the first time the source was dispatched
+CREATE TABLE IF NOT EXISTS webhook_sources (
+    webhook_id TEXT NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    source_id TEXT NOT NULL,
+    PRIMARY KEY (webhook_id, source_id)
+) STRICT;
+
+-- release notes which existed before the webhook, it's never called for them
+CREATE TABLE IF NOT EXISTS webhook_skipped_release_notes (
+    webhook_id TEXT NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    release_note_id TEXT NOT NULL,
+    PRIMARY KEY (webhook_id, release_note_id)
+) STRICT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE webhook_skipped_release_notes;
+DROP TABLE webhook_sources;
+-- +goose StatementEnd